@@ -8,7 +8,9 @@ import (
 	"github.com/samber/do"
 	"github.com/zemetia/en-indo-be/constants"
 	"github.com/zemetia/en-indo-be/migrations"
+	"github.com/zemetia/en-indo-be/repository"
 	"github.com/zemetia/en-indo-be/script"
+	"github.com/zemetia/en-indo-be/service"
 	"gorm.io/gorm"
 )
 
@@ -21,6 +23,7 @@ func Commands(injector *do.Injector) bool {
 	seed := false
 	run := false
 	scriptFlag := false
+	rebuildOccurrences := false
 
 	for _, arg := range os.Args[1:] {
 		if arg == "--migrate" {
@@ -32,6 +35,9 @@ func Commands(injector *do.Injector) bool {
 		if arg == "--run" {
 			run = true
 		}
+		if arg == "--rebuild-occurrences" {
+			rebuildOccurrences = true
+		}
 		if strings.HasPrefix(arg, "--script:") {
 			scriptFlag = true
 			scriptName = strings.TrimPrefix(arg, "--script:")
@@ -52,6 +58,21 @@ func Commands(injector *do.Injector) bool {
 		log.Println("seeder completed successfully")
 	}
 
+	if rebuildOccurrences {
+		eventService := service.NewEventService(
+			repository.NewEventRepository(db),
+			repository.NewEventPICRepository(db),
+			repository.NewEventOccurrenceRepository(db),
+			repository.NewVenueRepository(db),
+			nil,
+		)
+		indexed, err := eventService.RebuildOccurrenceIndex()
+		if err != nil {
+			log.Fatalf("error rebuilding occurrence index: %v", err)
+		}
+		log.Printf("occurrence index rebuilt for %d events", indexed)
+	}
+
 	if scriptFlag {
 		if err := script.Script(scriptName, db); err != nil {
 			log.Fatalf("error script: %v", err)
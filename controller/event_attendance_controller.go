@@ -0,0 +1,587 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+type EventAttendanceController struct {
+	attendanceService service.EventAttendanceService
+	eventAuthService  service.EventAuthorizationService
+}
+
+func NewEventAttendanceController(attendanceService service.EventAttendanceService, eventAuthService service.EventAuthorizationService) *EventAttendanceController {
+	return &EventAttendanceController{
+		attendanceService: attendanceService,
+		eventAuthService:  eventAuthService,
+	}
+}
+
+// CheckIn godoc
+// @Summary Check in to an event occurrence
+// @Description Record that a person or a visitor attended one occurrence. The age group of a person is derived from their birth date unless given. Only PICs with edit permission and church admins record check-ins.
+// @Tags event-attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param checkIn body dto.CheckInRequest true "Check-in data"
+// @Success 201 {object} dto.EventAttendanceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /events/{id}/attendance/check-in [post]
+func (c *EventAttendanceController) CheckIn(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.CheckInRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	attendance, err := c.attendanceService.CheckIn(eventID, &req, actorPersonID(ctx))
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to check in",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attendance)
+}
+
+// IssueCheckInToken godoc
+// @Summary Issue a QR check-in token
+// @Description Sign a single-use token that checks a person in to one occurrence. The token expires shortly after the occurrence ends. Members get tokens for themselves; the event's editors may issue them for anyone.
+// @Tags event-attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param token body dto.IssueCheckInTokenRequest true "Person and occurrence"
+// @Success 201 {object} dto.CheckInTokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/tokens [post]
+func (c *EventAttendanceController) IssueCheckInToken(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.IssueCheckInTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !c.authorizeTokenHolder(ctx, eventID, req.PersonID) {
+		return
+	}
+
+	token, err := c.attendanceService.IssueCheckInToken(eventID, &req)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to issue check-in token",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, token)
+}
+
+// GetCheckInQRCode godoc
+// @Summary Get a QR check-in code
+// @Description Issue a check-in token for a person and occurrence and render it as a PNG QR code. Members get codes for themselves; the event's editors may issue them for anyone.
+// @Tags event-attendance
+// @Produce image/png
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Param personId query string true "Person ID"
+// @Param size query int false "Image size in pixels (128-1024, default 256)"
+// @Success 200 {file} file "PNG image"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/tokens/qr [get]
+func (c *EventAttendanceController) GetCheckInQRCode(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.IssueCheckInTokenRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	if req.OccurrenceDate == "" || req.PersonID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'occurrenceDate' or 'personId' query parameter",
+		})
+		return
+	}
+
+	if !c.authorizeTokenHolder(ctx, eventID, req.PersonID) {
+		return
+	}
+
+	png, err := c.attendanceService.RenderCheckInQRCode(eventID, &req)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to render check-in QR code",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Every request issues a fresh single-use token
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// CheckInWithToken godoc
+// @Summary Check in by scanning a QR code
+// @Description Verify a scanned check-in token and record the attendance. Replayed, expired and foreign tokens are rejected. Only PICs with edit permission and church admins scan codes.
+// @Tags event-attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param scan body dto.TokenCheckInRequest true "Scanned token"
+// @Success 201 {object} dto.EventAttendanceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /events/{id}/attendance/scan [post]
+func (c *EventAttendanceController) CheckInWithToken(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.TokenCheckInRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	attendance, err := c.attendanceService.CheckInWithToken(eventID, &req, actorPersonID(ctx))
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to check in",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attendance)
+}
+
+// ListAttendances godoc
+// @Summary List check-ins of an occurrence
+// @Tags event-attendance
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Param ageGroup query string false "adult, youth or kid"
+// @Param search query string false "Search by name or email"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} dto.EventAttendanceListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance [get]
+func (c *EventAttendanceController) ListAttendances(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.EventAttendanceFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	if req.OccurrenceDate == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'occurrenceDate' query parameter",
+		})
+		return
+	}
+
+	attendances, err := c.attendanceService.ListAttendances(eventID, &req)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to list attendances",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attendances)
+}
+
+// RecordHeadcount godoc
+// @Summary Record the headcount of an occurrence
+// @Description Store the anonymous headcount by age group, replacing an earlier count for the same occurrence
+// @Tags event-attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param headcount body dto.RecordHeadcountRequest true "Headcount data"
+// @Success 200 {object} dto.EventHeadcountResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/headcount [put]
+func (c *EventAttendanceController) RecordHeadcount(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.RecordHeadcountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	headcount, err := c.attendanceService.RecordHeadcount(eventID, &req, actorPersonID(ctx))
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to record headcount",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, headcount)
+}
+
+// GetHeadcount godoc
+// @Summary Get the headcount of an occurrence
+// @Tags event-attendance
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} dto.EventHeadcountResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/headcount [get]
+func (c *EventAttendanceController) GetHeadcount(ctx *gin.Context) {
+	eventID, occurrenceDate, ok := occurrenceDateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	headcount, err := c.attendanceService.GetHeadcount(eventID, occurrenceDate)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to get headcount",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, headcount)
+}
+
+// GetOccurrenceSummary godoc
+// @Summary Compare expected and actual attendance of an occurrence
+// @Tags event-attendance
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} dto.OccurrenceAttendanceSummary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/summary [get]
+func (c *EventAttendanceController) GetOccurrenceSummary(ctx *gin.Context) {
+	eventID, occurrenceDate, ok := occurrenceDateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	summary, err := c.attendanceService.GetOccurrenceSummary(eventID, occurrenceDate)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to get attendance summary",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// GetAttendanceReport godoc
+// @Summary Compare expected and actual attendance over a date range
+// @Tags event-attendance
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.AttendanceReportResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/attendance/report [get]
+func (c *EventAttendanceController) GetAttendanceReport(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.AttendanceReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	if req.StartDate == "" || req.EndDate == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'startDate' or 'endDate' query parameter",
+		})
+		return
+	}
+
+	report, err := c.attendanceService.GetAttendanceReport(eventID, &req)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to get attendance report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetAttendance godoc
+// @Summary Get a check-in
+// @Tags event-attendance
+// @Produce json
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} dto.EventAttendanceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /event-attendances/{id} [get]
+func (c *EventAttendanceController) GetAttendance(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid attendance ID format",
+		})
+		return
+	}
+
+	attendance, err := c.attendanceService.GetAttendance(id)
+	if err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to get attendance",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attendance)
+}
+
+// DeleteAttendance godoc
+// @Summary Undo a check-in
+// @Tags event-attendance
+// @Param id path string true "Attendance ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /event-attendances/{id} [delete]
+func (c *EventAttendanceController) DeleteAttendance(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid attendance ID format",
+		})
+		return
+	}
+
+	if err := c.attendanceService.DeleteAttendance(id); err != nil {
+		ctx.JSON(attendanceErrorStatus(err), gin.H{
+			"error":   "Failed to delete attendance",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetPersonAttendance godoc
+// @Summary Get a person's attendance history
+// @Tags event-attendance
+// @Produce json
+// @Param personId path string true "Person ID"
+// @Success 200 {array} dto.EventAttendanceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /persons/{personId}/event-attendance [get]
+func (c *EventAttendanceController) GetPersonAttendance(ctx *gin.Context) {
+	personID, err := uuid.Parse(ctx.Param("personId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid person ID format",
+		})
+		return
+	}
+
+	attendances, err := c.attendanceService.GetPersonAttendance(personID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get person attendance",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attendances)
+}
+
+func occurrenceDateQuery(ctx *gin.Context) (uuid.UUID, string, bool) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return uuid.Nil, "", false
+	}
+
+	occurrenceDate := ctx.Query("occurrenceDate")
+	if occurrenceDate == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'occurrenceDate' query parameter",
+		})
+		return uuid.Nil, "", false
+	}
+
+	return eventID, occurrenceDate, true
+}
+
+// actorPersonID returns the person of the authenticated user, if the route is authenticated
+// authorizeTokenHolder answers 403 unless the caller asks for a check-in token for themselves or may edit
+// the event, so a token always vouches for the person it names
+func (c *EventAttendanceController) authorizeTokenHolder(ctx *gin.Context, eventID uuid.UUID, holder string) bool {
+	holderID, err := uuid.Parse(holder)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid person ID format",
+		})
+		return false
+	}
+
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return false
+	}
+	if *personID == holderID {
+		return true
+	}
+
+	allowed, err := c.eventAuthService.CanPerform(ctx.Request.Context(), eventID, *personID, service.EventActionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"details": err.Error(),
+			})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check permissions",
+			"details": err.Error(),
+		})
+		return false
+	}
+	if !allowed {
+		respondAccessDenied(ctx, "Members may only get check-in codes for themselves. Only PICs with edit permission or church admins can issue them for others.")
+		return false
+	}
+	return true
+}
+
+func actorPersonID(ctx *gin.Context) *uuid.UUID {
+	personIDStr, exists := ctx.Get("person_id")
+	if !exists {
+		return nil
+	}
+	personID, err := uuid.Parse(personIDStr.(string))
+	if err != nil {
+		return nil
+	}
+	return &personID
+}
+
+func attendanceErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case message == "already checked in for this occurrence" ||
+		message == "check-in token has already been used":
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
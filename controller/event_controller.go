@@ -1,22 +1,27 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/zemetia/en-indo-be/dto"
 	"github.com/zemetia/en-indo-be/service"
+	"github.com/zemetia/en-indo-be/utils"
 )
 
 type EventController struct {
-	eventService service.EventService
+	eventService     service.EventService
+	eventAuthService service.EventAuthorizationService
 }
 
-func NewEventController(eventService service.EventService) *EventController {
+func NewEventController(eventService service.EventService, eventAuthService service.EventAuthorizationService) *EventController {
 	return &EventController{
-		eventService: eventService,
+		eventService:     eventService,
+		eventAuthService: eventAuthService,
 	}
 }
 
@@ -29,6 +34,8 @@ func NewEventController(eventService service.EventService) *EventController {
 // @Param event body dto.CreateEventRequest true "Event creation data"
 // @Success 201 {object} dto.EventResponse
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers one of the churches, or overrides a venue conflict without administering the venue's church"
+// @Failure 409 {object} map[string]interface{} "Venue is already booked; conflicts lists the overlapping occurrences"
 // @Failure 500 {object} map[string]interface{}
 // @Router /events [post]
 func (c *EventController) CreateEvent(ctx *gin.Context) {
@@ -41,9 +48,37 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 		return
 	}
 
+	if (req.ChurchID == nil || *req.ChurchID == uuid.Nil) && (req.VenueID == nil || *req.VenueID == uuid.Nil) {
+		if req.ChurchID = callerChurch(ctx, c.eventAuthService); req.ChurchID == nil {
+			return
+		}
+	}
+	if !authorizeChurches(ctx, c.eventAuthService, req.ChurchID, req.SharedChurchIDs, req.VenueID) {
+		return
+	}
+	if !authorizeVenueOverride(ctx, c.eventAuthService, req.AllowVenueConflict, req.VenueID, nil) {
+		return
+	}
+
 	event, err := c.eventService.CreateEvent(&req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
+		if respondVenueConflict(ctx, err) {
+			return
+		}
+		var rruleErr *service.RRuleError
+		if errors.As(err, &rruleErr) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid recurrence rule",
+				"details": err.Error(),
+				"part":    rruleErr.Part,
+			})
+			return
+		}
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "venue ") || strings.HasPrefix(err.Error(), "church ") {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{
 			"error":   "Failed to create event",
 			"details": err.Error(),
 		})
@@ -96,7 +131,9 @@ func (c *EventController) GetEvent(ctx *gin.Context) {
 // @Param event body dto.UpdateEventRequest true "Event update data"
 // @Success 200 {object} dto.EventResponse
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers a church the update assigns, or overrides a venue conflict without administering the venue's church"
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Venue is already booked; conflicts lists the overlapping occurrences"
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{id} [put]
 func (c *EventController) UpdateEvent(ctx *gin.Context) {
@@ -118,11 +155,24 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 		return
 	}
 
+	if !c.authorizeUpdateChurches(ctx, id, &req) {
+		return
+	}
+	if !c.authorizeEventVenueOverride(ctx, id, &req) {
+		return
+	}
+
 	event, err := c.eventService.UpdateEvent(id, &req)
 	if err != nil {
+		if respondVenueConflict(ctx, err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if err.Error() == "failed to get event: record not found" {
 			status = http.StatusNotFound
+		} else if strings.HasPrefix(err.Error(), "venue ") || strings.HasPrefix(err.Error(), "church ") ||
+			strings.HasPrefix(err.Error(), "invalid ") {
+			status = http.StatusBadRequest
 		}
 		ctx.JSON(status, gin.H{
 			"error":   "Failed to update event",
@@ -181,6 +231,7 @@ func (c *EventController) DeleteEvent(ctx *gin.Context) {
 // @Param type query string false "Event type (event, ibadah, spiritual_journey)"
 // @Param isPublic query bool false "Filter by public/private events"
 // @Param search query string false "Search in title, description, or location"
+// @Param churchId query string false "Filter by the church owning or sharing the event"
 // @Param startDate query string false "Filter events from this date (YYYY-MM-DD)"
 // @Param endDate query string false "Filter events until this date (YYYY-MM-DD)"
 // @Param page query int false "Page number (default: 1)"
@@ -207,6 +258,12 @@ func (c *EventController) ListEvents(ctx *gin.Context) {
 		req.Limit = 20
 	}
 
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
 	events, err := c.eventService.ListEvents(&req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -229,7 +286,9 @@ func (c *EventController) ListEvents(ctx *gin.Context) {
 // @Param update body dto.UpdateRecurringEventRequest true "Recurring event update data"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers a church the update assigns, or overrides a venue conflict without administering the venue's church"
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Venue is already booked"
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{id}/series [put]
 func (c *EventController) UpdateRecurringEvent(ctx *gin.Context) {
@@ -251,12 +310,22 @@ func (c *EventController) UpdateRecurringEvent(ctx *gin.Context) {
 		return
 	}
 
+	if !c.authorizeUpdateChurches(ctx, id, &req.Event) {
+		return
+	}
+	if !c.authorizeEventVenueOverride(ctx, id, &req.Event) {
+		return
+	}
+
 	err = c.eventService.UpdateRecurringEvent(id, &req)
 	if err != nil {
+		if respondVenueConflict(ctx, err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if err.Error() == "failed to get event: record not found" {
 			status = http.StatusNotFound
-		} else if err.Error() == "event is not recurring" {
+		} else if err.Error() == "event is not recurring" || strings.HasPrefix(err.Error(), "church ") {
 			status = http.StatusBadRequest
 		}
 		ctx.JSON(status, gin.H{
@@ -382,6 +451,7 @@ func (c *EventController) GetEventOccurrences(ctx *gin.Context) {
 // @Param startDate query string true "Start date (YYYY-MM-DD)"
 // @Param endDate query string true "End date (YYYY-MM-DD)"
 // @Param timezone query string false "Timezone for results"
+// @Param churchId query string false "Filter by the church owning or sharing the event"
 // @Success 200 {array} dto.EventOccurrenceResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
@@ -396,6 +466,12 @@ func (c *EventController) GetOccurrencesInRange(ctx *gin.Context) {
 		return
 	}
 
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
 	occurrences, err := c.eventService.GetOccurrencesInRange(&req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -408,6 +484,98 @@ func (c *EventController) GetOccurrencesInRange(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, occurrences)
 }
 
+// ListOccurrences godoc
+// @Summary List indexed event occurrences
+// @Description Get a paginated, filtered list of occurrences from all events within a date range, ordered by start time
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Param timezone query string false "Timezone for results; dates are read in each event's own timezone when omitted"
+// @Param type query string false "Event type"
+// @Param isPublic query bool false "Filter by public/private events"
+// @Param search query string false "Search in title, description, or location"
+// @Param churchId query string false "Filter by the church owning or sharing the event"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.EventOccurrenceListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/occurrences/list [get]
+func (c *EventController) ListOccurrences(ctx *gin.Context) {
+	var req dto.ListEventOccurrencesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
+	occurrences, err := c.eventService.ListOccurrences(&req)
+	if err != nil {
+		ctx.JSON(occurrenceErrorStatus(err), gin.H{
+			"error":   "Failed to list occurrences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
+// GetWeekOccurrences godoc
+// @Summary What's on this week
+// @Description Get a paginated list of occurrences in the Monday-to-Sunday week containing the given date
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param date query string false "Any date in the week (YYYY-MM-DD, default: today)"
+// @Param timezone query string false "Timezone for the week and the results (default: UTC)"
+// @Param type query string false "Event type"
+// @Param isPublic query bool false "Filter by public/private events"
+// @Param churchId query string false "Filter by the church owning or sharing the event"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.EventOccurrenceListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/occurrences/week [get]
+func (c *EventController) GetWeekOccurrences(ctx *gin.Context) {
+	var req dto.WeekEventOccurrencesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
+	occurrences, err := c.eventService.GetWeekOccurrences(&req)
+	if err != nil {
+		ctx.JSON(occurrenceErrorStatus(err), gin.H{
+			"error":   "Failed to get occurrences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
 // UpdateSingleOccurrence godoc
 // @Summary Update a single occurrence of a recurring event
 // @Description Update only one specific occurrence of a recurring event series
@@ -470,7 +638,9 @@ func (c *EventController) UpdateSingleOccurrence(ctx *gin.Context) {
 // @Param update body dto.UpdateFutureOccurrencesRequest true "Future occurrences update data"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers a church the update assigns, or overrides a venue conflict without administering the venue's church"
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Venue is already booked"
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{id}/future [put]
 func (c *EventController) UpdateFutureOccurrences(ctx *gin.Context) {
@@ -492,12 +662,22 @@ func (c *EventController) UpdateFutureOccurrences(ctx *gin.Context) {
 		return
 	}
 
+	if !c.authorizeUpdateChurches(ctx, id, &req.Event) {
+		return
+	}
+	if !c.authorizeEventVenueOverride(ctx, id, &req.Event) {
+		return
+	}
+
 	err = c.eventService.UpdateFutureOccurrences(id, &req)
 	if err != nil {
+		if respondVenueConflict(ctx, err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if err.Error() == "failed to get event: record not found" {
 			status = http.StatusNotFound
-		} else if err.Error() == "event is not recurring" {
+		} else if err.Error() == "event is not recurring" || strings.HasPrefix(err.Error(), "church ") {
 			status = http.StatusBadRequest
 		}
 		ctx.JSON(status, gin.H{
@@ -514,7 +694,7 @@ func (c *EventController) UpdateFutureOccurrences(ctx *gin.Context) {
 
 // ValidateRecurrenceRule godoc
 // @Summary Validate a recurrence rule
-// @Description Validate the format and logic of a recurrence rule
+// @Description Validate the format and logic of a recurrence rule, given as fields or as an RRULE string, and return its canonical RRULE
 // @Tags events
 // @Accept json
 // @Produce json
@@ -534,16 +714,22 @@ func (c *EventController) ValidateRecurrenceRule(ctx *gin.Context) {
 
 	err := c.eventService.ValidateRecurrenceRule(&req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
+		response := gin.H{
 			"error":   "Invalid recurrence rule",
 			"details": err.Error(),
-		})
+		}
+		var rruleErr *service.RRuleError
+		if errors.As(err, &rruleErr) {
+			response["part"] = rruleErr.Part
+		}
+		ctx.JSON(http.StatusBadRequest, response)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Recurrence rule is valid",
 		"rule":    req,
+		"rrule":   req.RRule,
 	})
 }
 
@@ -611,3 +797,202 @@ func (c *EventController) GetNextOccurrence(ctx *gin.Context) {
 		"nextOccurrence": nextOccurrence.Format("2006-01-02T15:04:05Z07:00"),
 	})
 }
+
+// occurrenceErrorStatus maps occurrence query errors: storage failures are 500, invalid input is 400
+func occurrenceErrorStatus(err error) int {
+	if strings.HasPrefix(err.Error(), "failed to") {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// eventViewer limits a list to the events the caller may see, answering the request when it cannot tell
+func eventViewer(ctx *gin.Context, authService service.EventAuthorizationService) (*dto.EventViewer, bool) {
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return nil, false
+	}
+	viewer, err := authService.EventViewer(ctx.Request.Context(), *personID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check event access",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+	return viewer, true
+}
+
+// callerChurch returns the church of the caller, which owns the events they create without naming a
+// church or a venue; it answers the request and returns nil when it cannot tell
+func callerChurch(ctx *gin.Context, authService service.EventAuthorizationService) *uuid.UUID {
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return nil
+	}
+	churchID, err := authService.ChurchIDOfPerson(ctx.Request.Context(), *personID)
+	if err != nil {
+		ctx.JSON(lookupErrorStatus(err), gin.H{
+			"error":   "Failed to find your church",
+			"details": err.Error(),
+		})
+		return nil
+	}
+	return &churchID
+}
+
+// authorizeChurches answers 403 unless the caller may give an event to each of its churches: the owner,
+// or without one the church of the venue it books, and the churches it is shared with. Nil and
+// nil-UUID churches are skipped, and an unknown venue is left for the service to report.
+func authorizeChurches(ctx *gin.Context, authService service.EventAuthorizationService, churchID *uuid.UUID, sharedChurchIDs []uuid.UUID, venueID *uuid.UUID) bool {
+	var churchIDs []uuid.UUID
+	if churchID != nil && *churchID != uuid.Nil {
+		churchIDs = append(churchIDs, *churchID)
+	} else if venueID != nil && *venueID != uuid.Nil {
+		venueChurchID, err := authService.ChurchIDOfVenue(*venueID)
+		if err != nil && !strings.HasSuffix(err.Error(), "record not found") {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to check church access",
+				"details": err.Error(),
+			})
+			return false
+		}
+		if err == nil {
+			churchIDs = append(churchIDs, *venueChurchID)
+		}
+	}
+	for _, id := range sharedChurchIDs {
+		if id != uuid.Nil {
+			churchIDs = append(churchIDs, id)
+		}
+	}
+	if len(churchIDs) == 0 {
+		return true
+	}
+
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Only signed-in members can assign events to a church")
+		return false
+	}
+	for _, id := range churchIDs {
+		allowed, err := authService.CanUseChurch(ctx.Request.Context(), *personID, id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to check church access",
+				"details": err.Error(),
+			})
+			return false
+		}
+		if !allowed {
+			respondAccessDenied(ctx, "Events can only be assigned to churches you belong to or administer")
+			return false
+		}
+	}
+	return true
+}
+
+// authorizeUpdateChurches checks the churches an update gives the event. Clearing the owner hands the
+// event to the church of its venue, which is the event's unless the update changes it.
+func (c *EventController) authorizeUpdateChurches(ctx *gin.Context, eventID uuid.UUID, req *dto.UpdateEventRequest) bool {
+	var sharedChurchIDs []uuid.UUID
+	if req.SharedChurchIDs != nil {
+		sharedChurchIDs = *req.SharedChurchIDs
+	}
+
+	var venueID *uuid.UUID
+	if req.ChurchID != nil && *req.ChurchID == uuid.Nil {
+		venueID = req.VenueID
+		if venueID == nil {
+			var err error
+			if venueID, err = c.eventAuthService.VenueIDOfEvent(eventID); err != nil {
+				ctx.JSON(lookupErrorStatus(err), gin.H{
+					"error":   "Failed to check church access",
+					"details": err.Error(),
+				})
+				return false
+			}
+		}
+	}
+	return authorizeChurches(ctx, c.eventAuthService, req.ChurchID, sharedChurchIDs, venueID)
+}
+
+// authorizeVenueOverride answers 403 unless the caller may book the venue over its other bookings when
+// the request asks to. The venue is venueID, or the one booked resolves when the request keeps it; a
+// request without a venue has nothing to override.
+func authorizeVenueOverride(ctx *gin.Context, authService service.EventAuthorizationService, allowConflict bool, venueID *uuid.UUID, booked func() (*uuid.UUID, error)) bool {
+	if !allowConflict {
+		return true
+	}
+	if venueID == nil && booked != nil {
+		var err error
+		venueID, err = booked()
+		if err != nil {
+			ctx.JSON(lookupErrorStatus(err), gin.H{
+				"error":   "Failed to check venue access",
+				"details": err.Error(),
+			})
+			return false
+		}
+	}
+	if venueID == nil || *venueID == uuid.Nil {
+		return true
+	}
+
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return false
+	}
+	allowed, err := authService.CanOverrideVenueConflict(ctx.Request.Context(), *personID, *venueID)
+	if err != nil {
+		ctx.JSON(lookupErrorStatus(err), gin.H{
+			"error":   "Failed to check venue access",
+			"details": err.Error(),
+		})
+		return false
+	}
+	if !allowed {
+		respondAccessDenied(ctx, "Only admins of the venue's church can book it over other bookings")
+		return false
+	}
+	return true
+}
+
+// authorizeEventVenueOverride checks the venue override of an update, whose venue is the event's
+// unless the update changes it
+func (c *EventController) authorizeEventVenueOverride(ctx *gin.Context, eventID uuid.UUID, req *dto.UpdateEventRequest) bool {
+	return authorizeVenueOverride(ctx, c.eventAuthService, req.AllowVenueConflict, req.VenueID, func() (*uuid.UUID, error) {
+		return c.eventAuthService.VenueIDOfEvent(eventID)
+	})
+}
+
+// lookupErrorStatus maps a failed lookup of the record a permission check needs
+func lookupErrorStatus(err error) int {
+	if strings.HasSuffix(err.Error(), "record not found") {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// respondAccessDenied answers 403 with the body the event permission middleware uses
+func respondAccessDenied(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusForbidden, utils.BuildResponseFailed("ACCESS_DENIED", message, nil))
+}
+
+// respondVenueConflict answers 409 with the conflicting occurrences when err is a venue conflict.
+// Resending the request with allowVenueConflict set books the venue anyway.
+func respondVenueConflict(ctx *gin.Context, err error) bool {
+	var conflictErr *service.VenueConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, gin.H{
+		"error":     "Venue is already booked",
+		"details":   err.Error(),
+		"conflicts": conflictErr.Conflicts,
+	})
+	return true
+}
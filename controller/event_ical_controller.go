@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+const icalContentType = "text/calendar; charset=utf-8"
+
+type EventICalController struct {
+	eventICalService service.EventICalService
+	eventAuthService service.EventAuthorizationService
+}
+
+func NewEventICalController(eventICalService service.EventICalService, eventAuthService service.EventAuthorizationService) *EventICalController {
+	return &EventICalController{
+		eventICalService: eventICalService,
+		eventAuthService: eventAuthService,
+	}
+}
+
+// ExportEvent godoc
+// @Summary Export a single event as iCalendar
+// @Description Export an event, its recurrence rule and exceptions as an RFC 5545 .ics file
+// @Tags events
+// @Produce text/calendar
+// @Param id path string true "Event ID"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/ical [get]
+func (c *EventICalController) ExportEvent(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	calendar, err := c.eventICalService.ExportEvent(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "failed to get event: record not found" {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, gin.H{
+			"error":   "Failed to export event",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.writeCalendar(ctx, fmt.Sprintf("event-%s.ics", id), calendar)
+}
+
+// ExportEvents godoc
+// @Summary Export filtered events as iCalendar
+// @Description Export every event matching the list filters as an RFC 5545 feed
+// @Tags events
+// @Produce text/calendar
+// @Param type query string false "Event type (event, ibadah, spiritual_journey)"
+// @Param isPublic query bool false "Filter by public/private events"
+// @Param search query string false "Search in title, description, or location"
+// @Param startDate query string false "Only series with occurrences from this date (YYYY-MM-DD)"
+// @Param endDate query string false "Only series with occurrences until this date (YYYY-MM-DD)"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/ical [get]
+func (c *EventICalController) ExportEvents(ctx *gin.Context) {
+	var req dto.EventFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
+	calendar, err := c.eventICalService.ExportEvents(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.writeCalendar(ctx, "events.ics", calendar)
+}
+
+// ExportPublicEvents godoc
+// @Summary Export public events as iCalendar
+// @Description Subscribable RFC 5545 feed containing only public events
+// @Tags events
+// @Produce text/calendar
+// @Param type query string false "Event type (event, ibadah, spiritual_journey)"
+// @Param search query string false "Search in title, description, or location"
+// @Param startDate query string false "Only series with occurrences from this date (YYYY-MM-DD)"
+// @Param endDate query string false "Only series with occurrences until this date (YYYY-MM-DD)"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/public/ical [get]
+func (c *EventICalController) ExportPublicEvents(ctx *gin.Context) {
+	var req dto.EventFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	calendar, err := c.eventICalService.ExportPublicEvents(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export public events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.writeCalendar(ctx, "public-events.ics", calendar)
+}
+
+// ImportEvents godoc
+// @Summary Import events from iCalendar
+// @Description Create events, recurrence rules and exceptions from an uploaded .ics file. Each VEVENT is reported separately.
+// @Tags events
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "iCalendar file (the raw request body is used when omitted)"
+// @Param dryRun query bool false "Validate and preview without creating events"
+// @Param type query string false "Event type for VEVENTs without a matching category"
+// @Param isPublic query bool false "Visibility for VEVENTs without CLASS"
+// @Param timezone query string false "Timezone for floating date-times (defaults to X-WR-TIMEZONE, then UTC)"
+// @Param churchId query string false "Church owning the imported events (default: the caller's church)"
+// @Success 200 {object} dto.ImportEventsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the church"
+// @Router /events/import [post]
+func (c *EventICalController) ImportEvents(ctx *gin.Context) {
+	var req dto.ImportEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if req.ChurchID == nil || *req.ChurchID == uuid.Nil {
+		if req.ChurchID = callerChurch(ctx, c.eventAuthService); req.ChurchID == nil {
+			return
+		}
+	}
+	if !authorizeChurches(ctx, c.eventAuthService, req.ChurchID, nil, nil) {
+		return
+	}
+
+	data, err := c.readCalendar(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to read iCalendar file",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := c.eventICalService.ImportCalendar(data, &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to import events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// readCalendar accepts either a multipart "file" field or the raw request body
+func (c *EventICalController) readCalendar(ctx *gin.Context) ([]byte, error) {
+	if fileHeader, err := ctx.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	data, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("request body is empty")
+	}
+	return data, nil
+}
+
+func (c *EventICalController) writeCalendar(ctx *gin.Context, filename string, calendar []byte) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, icalContentType, calendar)
+}
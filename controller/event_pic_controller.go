@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -11,12 +13,14 @@ import (
 )
 
 type EventPICController struct {
-	eventPICService service.EventPICService
+	eventPICService  service.EventPICService
+	eventAuthService service.EventAuthorizationService
 }
 
-func NewEventPICController(eventPICService service.EventPICService) *EventPICController {
+func NewEventPICController(eventPICService service.EventPICService, eventAuthService service.EventAuthorizationService) *EventPICController {
 	return &EventPICController{
-		eventPICService: eventPICService,
+		eventPICService:  eventPICService,
+		eventAuthService: eventAuthService,
 	}
 }
 
@@ -31,6 +35,7 @@ func NewEventPICController(eventPICService service.EventPICService) *EventPICCon
 // @Success 201 {object} dto.EventPICResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{eventId}/pics [post]
 func (c *EventPICController) CreateEventPIC(ctx *gin.Context) {
@@ -52,11 +57,13 @@ func (c *EventPICController) CreateEventPIC(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Get actual user ID from authentication context
-	createdBy := uuid.New() // Placeholder
+	createdBy := changedByPersonID(ctx)
 
 	eventPIC, err := c.eventPICService.CreateEventPIC(eventID, &req, createdBy)
 	if err != nil {
+		if respondPICScheduleConflict(ctx, err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if err.Error() == "event not found: record not found" {
 			status = http.StatusNotFound
@@ -143,8 +150,7 @@ func (c *EventPICController) UpdateEventPIC(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Get actual user ID from authentication context
-	updatedBy := uuid.New() // Placeholder
+	updatedBy := changedByPersonID(ctx)
 
 	eventPIC, err := c.eventPICService.UpdateEventPIC(id, &req, updatedBy)
 	if err != nil {
@@ -192,8 +198,7 @@ func (c *EventPICController) DeleteEventPIC(ctx *gin.Context) {
 		reason = "PIC removed"
 	}
 
-	// TODO: Get actual user ID from authentication context
-	deletedBy := uuid.New() // Placeholder
+	deletedBy := changedByPersonID(ctx)
 
 	err = c.eventPICService.DeleteEventPIC(id, deletedBy, reason)
 	if err != nil {
@@ -393,6 +398,7 @@ func (c *EventPICController) GetActivePersonPICs(ctx *gin.Context) {
 // @Param isActive query bool false "Filter by active status"
 // @Param isPrimary query bool false "Filter by primary status"
 // @Param search query string false "Search in person name, role"
+// @Param churchId query string false "Filter by the church owning or sharing the event"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 20)"
 // @Success 200 {object} dto.EventPICListResponse
@@ -417,6 +423,12 @@ func (c *EventPICController) ListEventPICs(ctx *gin.Context) {
 		req.Limit = 20
 	}
 
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
 	eventPICs, err := c.eventPICService.ListEventPICs(&req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -440,6 +452,7 @@ func (c *EventPICController) ListEventPICs(ctx *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{eventId}/pics/bulk [post]
 func (c *EventPICController) BulkAssignEventPICs(ctx *gin.Context) {
@@ -461,11 +474,13 @@ func (c *EventPICController) BulkAssignEventPICs(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Get actual user ID from authentication context
-	createdBy := uuid.New() // Placeholder
+	createdBy := changedByPersonID(ctx)
 
 	err = c.eventPICService.AssignMultiplePICs(eventID, &req, createdBy)
 	if err != nil {
+		if respondPICScheduleConflict(ctx, err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if err.Error() == "event not found: record not found" {
 			status = http.StatusNotFound
@@ -495,6 +510,7 @@ func (c *EventPICController) BulkAssignEventPICs(ctx *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /events/{eventId}/pics/transfer [post]
 func (c *EventPICController) TransferPICRole(ctx *gin.Context) {
@@ -516,12 +532,18 @@ func (c *EventPICController) TransferPICRole(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Get actual user ID from authentication context
-	changedBy := uuid.New() // Placeholder
+	changedBy := changedByPersonID(ctx)
 
 	err = c.eventPICService.TransferPICRole(eventID, &req, changedBy)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
+		if respondPICScheduleConflict(ctx, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		if err.Error() == "event not found: record not found" {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, gin.H{
 			"error":   "Failed to transfer PIC role",
 			"details": err.Error(),
 		})
@@ -599,6 +621,54 @@ func (c *EventPICController) GetPersonPICHistory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, history)
 }
 
+// GetPersonPICConflicts godoc
+// @Summary Get PIC schedule conflicts for a person
+// @Description List pairs of overlapping occurrences among the events a person is PIC of within a date range
+// @Tags event-pics
+// @Accept json
+// @Produce json
+// @Param personId path string true "Person ID"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.PersonPICConflictsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /persons/{personId}/event-pics/conflicts [get]
+func (c *EventPICController) GetPersonPICConflicts(ctx *gin.Context) {
+	personIDStr := ctx.Param("personId")
+	personID, err := uuid.Parse(personIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid person ID format",
+		})
+		return
+	}
+
+	var req dto.PersonPICConflictsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	conflicts, err := c.eventPICService.GetPersonPICConflicts(personID, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusInternalServerError
+		}
+		ctx.JSON(status, gin.H{
+			"error":   "Failed to get PIC schedule conflicts",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conflicts)
+}
+
 // GetExpiringPICs godoc
 // @Summary Get expiring PICs
 // @Description Get PICs that are expiring within a specified number of days
@@ -624,7 +694,12 @@ func (c *EventPICController) GetExpiringPICs(ctx *gin.Context) {
 		days = parsed
 	}
 
-	pics, err := c.eventPICService.GetExpiringPICs(days)
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+
+	pics, err := c.eventPICService.GetExpiringPICs(days, viewer)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to get expiring PICs",
@@ -689,4 +764,27 @@ func (c *EventPICController) ValidatePICPermissions(ctx *gin.Context) {
 		"hasPermission": hasPermission,
 		"action":        action,
 	})
-}
\ No newline at end of file
+}
+// respondPICScheduleConflict answers 409 with the overlapping occurrences when err is a PIC schedule
+// conflict. Resending the request with allowScheduleConflict set assigns the person anyway.
+func respondPICScheduleConflict(ctx *gin.Context, err error) bool {
+	var conflictErr *service.PICScheduleConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, gin.H{
+		"error":     "Person is already PIC at that time",
+		"details":   err.Error(),
+		"conflicts": conflictErr.Conflicts,
+	})
+	return true
+}
+
+// changedByPersonID is the signed-in person recorded in the PIC history. Every PIC change goes through
+// the event permission middleware, which only lets requests with a person through.
+func changedByPersonID(ctx *gin.Context) uuid.UUID {
+	if personID := actorPersonID(ctx); personID != nil {
+		return *personID
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,384 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+// EventPICRotationController handles per-occurrence PIC assignments and the rotations that fill them
+type EventPICRotationController struct {
+	eventPICService service.EventPICService
+}
+
+func NewEventPICRotationController(eventPICService service.EventPICService) *EventPICRotationController {
+	return &EventPICRotationController{
+		eventPICService: eventPICService,
+	}
+}
+
+// AssignOccurrencePIC godoc
+// @Summary Assign a PIC to one occurrence
+// @Description Put a person in a role for a single occurrence of an event, replacing whoever held it
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param pic body dto.AssignOccurrencePICRequest true "Occurrence PIC data"
+// @Success 201 {object} dto.EventOccurrencePICResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/occurrence-pics [post]
+func (c *EventPICRotationController) AssignOccurrencePIC(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.AssignOccurrencePICRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pic, err := c.eventPICService.AssignOccurrencePIC(eventID, &req, actorPersonID(ctx))
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to assign occurrence PIC",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, pic)
+}
+
+// GetOccurrencePICs godoc
+// @Summary Get per-occurrence PICs of an event
+// @Description Get the PICs assigned to single occurrences of an event between two dates
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} dto.EventOccurrencePICResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/occurrence-pics [get]
+func (c *EventPICRotationController) GetOccurrencePICs(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.OccurrencePICFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pics, err := c.eventPICService.GetOccurrencePICs(eventID, &req)
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to get occurrence PICs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pics)
+}
+
+// DeleteOccurrencePIC godoc
+// @Summary Remove a per-occurrence PIC
+// @Description Remove a PIC from a single occurrence
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Occurrence PIC ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-occurrence-pics/{id} [delete]
+func (c *EventPICRotationController) DeleteOccurrencePIC(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid occurrence PIC ID format",
+		})
+		return
+	}
+
+	if err := c.eventPICService.DeleteOccurrencePIC(id, actorPersonID(ctx)); err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to delete occurrence PIC",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreatePICRotation godoc
+// @Summary Create a PIC rotation
+// @Description Rotate a role of a recurring event through a pool of people, filling the next occurrences
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param rotation body dto.CreateEventPICRotationRequest true "Rotation data"
+// @Success 201 {object} dto.EventPICRotationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pic-rotations [post]
+func (c *EventPICRotationController) CreatePICRotation(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.CreateEventPICRotationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rotation, err := c.eventPICService.CreatePICRotation(eventID, &req)
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to create PIC rotation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rotation)
+}
+
+// GetPICRotations godoc
+// @Summary Get the PIC rotations of an event
+// @Description Get every PIC rotation of an event with its pool of people
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {array} dto.EventPICRotationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pic-rotations [get]
+func (c *EventPICRotationController) GetPICRotations(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	rotations, err := c.eventPICService.GetPICRotationsByEventID(eventID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get PIC rotations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rotations)
+}
+
+// GetPICRotation godoc
+// @Summary Get a PIC rotation by ID
+// @Description Get a PIC rotation with its pool of people
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Rotation ID"
+// @Success 200 {object} dto.EventPICRotationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /event-pic-rotations/{id} [get]
+func (c *EventPICRotationController) GetPICRotation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rotation ID format",
+		})
+		return
+	}
+
+	rotation, err := c.eventPICService.GetPICRotation(id)
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to get PIC rotation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rotation)
+}
+
+// UpdatePICRotation godoc
+// @Summary Update a PIC rotation
+// @Description Change the pool of people, fill-ahead count or active state of a PIC rotation
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Rotation ID"
+// @Param rotation body dto.UpdateEventPICRotationRequest true "Rotation update data"
+// @Success 200 {object} dto.EventPICRotationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-pic-rotations/{id} [put]
+func (c *EventPICRotationController) UpdatePICRotation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rotation ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateEventPICRotationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rotation, err := c.eventPICService.UpdatePICRotation(id, &req)
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to update PIC rotation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rotation)
+}
+
+// DeletePICRotation godoc
+// @Summary Delete a PIC rotation
+// @Description Stop a PIC rotation; occurrences it already filled keep their PICs
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Rotation ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-pic-rotations/{id} [delete]
+func (c *EventPICRotationController) DeletePICRotation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rotation ID format",
+		})
+		return
+	}
+
+	if err := c.eventPICService.DeletePICRotation(id); err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to delete PIC rotation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// FillPICRotation godoc
+// @Summary Fill upcoming occurrences from a PIC rotation
+// @Description Assign the rotation's people in turn to the next occurrences that have nobody in the role
+// @Tags event-pic-rotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Rotation ID"
+// @Param fill body dto.FillEventPICRotationRequest false "Fill options"
+// @Success 200 {object} dto.FillEventPICRotationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-pic-rotations/{id}/fill [post]
+func (c *EventPICRotationController) FillPICRotation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rotation ID format",
+		})
+		return
+	}
+
+	// The body is optional; without one the rotation is filled to its fill-ahead count
+	var req dto.FillEventPICRotationRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := c.eventPICService.FillPICRotation(id, &req)
+	if err != nil {
+		ctx.JSON(rotationErrorStatus(err), gin.H{
+			"error":   "Failed to fill PIC rotation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func rotationErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.HasSuffix(message, "already exists"):
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
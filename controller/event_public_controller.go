@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+// publicEventCacheControl lets browsers and CDNs reuse a page briefly before revalidating it
+const publicEventCacheControl = "public, max-age=60"
+
+type EventPublicController struct {
+	publicEventService service.PublicEventService
+}
+
+func NewEventPublicController(publicEventService service.PublicEventService) *EventPublicController {
+	return &EventPublicController{
+		publicEventService: publicEventService,
+	}
+}
+
+// ListPublicEvents godoc
+// @Summary List upcoming public events
+// @Description List the upcoming occurrences of public events for the website, without signing in. Recurring events are expanded into occurrences and only public fields are returned. Supports If-None-Match and If-Modified-Since.
+// @Tags events
+// @Produce json
+// @Param churchId query string false "Only events held at this church"
+// @Param provinsiId query int false "Only events at churches in this province"
+// @Param kabupatenId query int false "Only events at churches in this kabupaten"
+// @Param type query string false "Event type (event, ibadah, spiritual_journey)"
+// @Param startDate query string false "Start date (YYYY-MM-DD), defaults to today"
+// @Param endDate query string false "End date (YYYY-MM-DD), defaults to 90 days after the start date"
+// @Param timezone query string false "Timezone to return times in (defaults to each event's own timezone)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} dto.PublicEventListResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/public [get]
+func (c *EventPublicController) ListPublicEvents(ctx *gin.Context) {
+	var req dto.PublicEventFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	feed, err := c.publicEventService.ListPublicEvents(&req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusInternalServerError
+		}
+		ctx.JSON(status, gin.H{
+			"error":   "Failed to list public events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("ETag", feed.ETag)
+	ctx.Header("Last-Modified", feed.LastModified.UTC().Format(http.TimeFormat))
+	ctx.Header("Cache-Control", publicEventCacheControl)
+
+	if notModified(ctx, feed.ETag, feed.LastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", feed.Body)
+}
+
+// notModified evaluates the conditional request headers. If-None-Match takes precedence, so
+// If-Modified-Since is only used when the client sent no entity tags.
+func notModified(ctx *gin.Context, etag string, lastModified time.Time) bool {
+	if header := ctx.GetHeader("If-None-Match"); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := ctx.GetHeader("If-Modified-Since"); header != "" {
+		since, err := http.ParseTime(header)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
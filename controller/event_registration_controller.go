@@ -0,0 +1,429 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+type EventRegistrationController struct {
+	registrationService service.EventRegistrationService
+	eventAuthService    service.EventAuthorizationService
+}
+
+func NewEventRegistrationController(registrationService service.EventRegistrationService, eventAuthService service.EventAuthorizationService) *EventRegistrationController {
+	return &EventRegistrationController{
+		registrationService: registrationService,
+		eventAuthService:    eventAuthService,
+	}
+}
+
+// Register godoc
+// @Summary Register for an event occurrence
+// @Description Register a person or a visitor for one occurrence. When the occurrence is full the registration is waitlisted. Members register themselves; the event's editors may register anyone.
+// @Tags event-registrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param registration body dto.CreateEventRegistrationRequest true "Registration data"
+// @Success 201 {object} dto.EventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /events/{id}/registrations [post]
+func (c *EventRegistrationController) Register(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.CreateEventRegistrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if req.PersonID != nil && !c.authorizeRegistrant(ctx, eventID, req.PersonID) {
+		return
+	}
+
+	registration, err := c.registrationService.Register(eventID, &req)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to register for event",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, registration)
+}
+
+// ListRegistrations godoc
+// @Summary List registrants of an occurrence
+// @Description List registered, waitlisted and cancelled registrations of one occurrence together with its seat usage
+// @Tags event-registrations
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Param status query string false "registered, waitlisted or cancelled"
+// @Param search query string false "Search by name or email"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} dto.EventRegistrationListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/registrations [get]
+func (c *EventRegistrationController) ListRegistrations(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	registrations, err := c.registrationService.ListRegistrations(eventID, req)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to list registrations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registrations)
+}
+
+// GetOccurrenceCapacity godoc
+// @Summary Get seat usage of an occurrence
+// @Tags event-registrations
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} dto.OccurrenceCapacityResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/registrations/capacity [get]
+func (c *EventRegistrationController) GetOccurrenceCapacity(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	capacity, err := c.registrationService.GetOccurrenceCapacity(eventID, req.OccurrenceDate)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to get occurrence capacity",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, capacity)
+}
+
+// ExportRegistrations godoc
+// @Summary Export registrants of an occurrence as CSV
+// @Tags event-registrations
+// @Produce text/csv
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Param status query string false "registered, waitlisted or cancelled"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/registrations/export [get]
+func (c *EventRegistrationController) ExportRegistrations(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	data, err := c.registrationService.ExportRegistrations(eventID, req)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to export registrations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("registrations-%s-%s.csv", eventID, req.OccurrenceDate)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// FillFromWaitlist godoc
+// @Summary Promote waitlisted registrants into free seats
+// @Description Promote waitlisted registrations in order until the occurrence is full, e.g. after the capacity was raised
+// @Tags event-registrations
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {array} dto.EventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/registrations/promote [post]
+func (c *EventRegistrationController) FillFromWaitlist(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	promoted, err := c.registrationService.FillFromWaitlist(eventID, req.OccurrenceDate)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to promote waitlisted registrations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promoted)
+}
+
+// GetRegistration godoc
+// @Summary Get a registration
+// @Description Registrants see their own registration; the event's editors may see anyone's
+// @Tags event-registrations
+// @Produce json
+// @Param id path string true "Registration ID"
+// @Success 200 {object} dto.EventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /event-registrations/{id} [get]
+func (c *EventRegistrationController) GetRegistration(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid registration ID format",
+		})
+		return
+	}
+
+	if !c.authorizeRegistrationOf(ctx, id, "Failed to get registration") {
+		return
+	}
+
+	registration, err := c.registrationService.GetRegistration(id)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to get registration",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registration)
+}
+
+// UpdateRegistration godoc
+// @Summary Manage a registration
+// @Description Edit notes, promote a waitlisted registrant while seats are free, or cancel a registration
+// @Tags event-registrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Registration ID"
+// @Param registration body dto.UpdateEventRegistrationRequest true "Registration update"
+// @Success 200 {object} dto.EventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /event-registrations/{id} [put]
+func (c *EventRegistrationController) UpdateRegistration(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid registration ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateEventRegistrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	registration, err := c.registrationService.UpdateRegistration(id, &req)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to update registration",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registration)
+}
+
+// CancelRegistration godoc
+// @Summary Cancel a registration
+// @Description Cancel a registration; the first waitlisted registrant takes the freed seat. Registrants cancel their own registration; the event's editors may cancel anyone's.
+// @Tags event-registrations
+// @Produce json
+// @Param id path string true "Registration ID"
+// @Success 200 {object} dto.CancelEventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /event-registrations/{id}/cancel [post]
+func (c *EventRegistrationController) CancelRegistration(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid registration ID format",
+		})
+		return
+	}
+
+	if !c.authorizeRegistrationOf(ctx, id, "Failed to cancel registration") {
+		return
+	}
+
+	response, err := c.registrationService.CancelRegistration(id)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   "Failed to cancel registration",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPersonRegistrations godoc
+// @Summary Get a person's event registrations
+// @Tags event-registrations
+// @Produce json
+// @Param personId path string true "Person ID"
+// @Success 200 {array} dto.EventRegistrationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /persons/{personId}/event-registrations [get]
+func (c *EventRegistrationController) GetPersonRegistrations(ctx *gin.Context) {
+	personID, err := uuid.Parse(ctx.Param("personId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid person ID format",
+		})
+		return
+	}
+
+	registrations, err := c.registrationService.GetPersonRegistrations(personID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get person registrations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registrations)
+}
+
+func (c *EventRegistrationController) bindOccurrenceQuery(ctx *gin.Context) (uuid.UUID, *dto.EventRegistrationFilterRequest, bool) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return uuid.Nil, nil, false
+	}
+
+	var req dto.EventRegistrationFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return uuid.Nil, nil, false
+	}
+	if req.OccurrenceDate == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'occurrenceDate' query parameter",
+		})
+		return uuid.Nil, nil, false
+	}
+
+	return eventID, &req, true
+}
+
+// authorizeRegistrationOf checks the caller may act on the registration with the given ID as its
+// registrant or as an editor of its event
+func (c *EventRegistrationController) authorizeRegistrationOf(ctx *gin.Context, id uuid.UUID, failure string) bool {
+	eventID, personID, err := c.eventAuthService.RegistrationOwner(id)
+	if err != nil {
+		ctx.JSON(registrationErrorStatus(err), gin.H{
+			"error":   failure,
+			"details": err.Error(),
+		})
+		return false
+	}
+	return c.authorizeRegistrant(ctx, eventID, personID)
+}
+
+// authorizeRegistrant answers 403 unless the caller is the registrant or may edit the event. Visitors
+// have no account, so only the event's editors act on their registrations.
+func (c *EventRegistrationController) authorizeRegistrant(ctx *gin.Context, eventID uuid.UUID, registrantID *uuid.UUID) bool {
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return false
+	}
+	if registrantID != nil && *personID == *registrantID {
+		return true
+	}
+
+	allowed, err := c.eventAuthService.CanPerform(ctx.Request.Context(), eventID, *personID, service.EventActionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"details": err.Error(),
+			})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check permissions",
+			"details": err.Error(),
+		})
+		return false
+	}
+	if !allowed {
+		respondAccessDenied(ctx, "Members may only manage their own registrations. Only PICs with edit permission or church admins can manage them for others.")
+		return false
+	}
+	return true
+}
+
+func registrationErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case message == "already registered for this occurrence" ||
+		message == "occurrence is full":
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,322 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+type EventRundownController struct {
+	rundownService service.EventRundownService
+}
+
+func NewEventRundownController(rundownService service.EventRundownService) *EventRundownController {
+	return &EventRundownController{
+		rundownService: rundownService,
+	}
+}
+
+// GetRundown godoc
+// @Summary Get the run-sheet of an event occurrence
+// @Description Get the timed segments of one occurrence with their clock times in the event's timezone
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} dto.EventRundownResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown [get]
+func (c *EventRundownController) GetRundown(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	rundown, err := c.rundownService.GetRundown(eventID, req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to get run-sheet",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rundown)
+}
+
+// SetRundown godoc
+// @Summary Set the run-sheet of an event occurrence
+// @Description Replace the segments of one occurrence, each with a start offset and duration in minutes, a responsible person and notes. Every segment must end before the occurrence does. An empty list clears the run-sheet.
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param rundown body dto.SetEventRundownRequest true "Occurrence date and segments"
+// @Success 200 {object} dto.EventRundownResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown [put]
+func (c *EventRundownController) SetRundown(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SetEventRundownRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rundown, err := c.rundownService.SetRundown(eventID, &req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to set run-sheet",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rundown)
+}
+
+// ExportRundown godoc
+// @Summary Print the run-sheet of an event occurrence
+// @Description Render the run-sheet of one occurrence as a printable HTML page
+// @Tags event-rundown
+// @Produce html
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {string} string "HTML page"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown/export [get]
+func (c *EventRundownController) ExportRundown(ctx *gin.Context) {
+	eventID, req, ok := c.bindOccurrenceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	data, err := c.rundownService.ExportRundown(eventID, req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to export run-sheet",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("rundown-%s-%s.html", eventID, req.OccurrenceDate)
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", data)
+}
+
+// GetRundownTemplate godoc
+// @Summary Get the run-sheet template of an event
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} dto.RundownTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown/template [get]
+func (c *EventRundownController) GetRundownTemplate(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	template, err := c.rundownService.GetTemplate(eventID)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to get run-sheet template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// SetRundownTemplate godoc
+// @Summary Set the run-sheet template of an event
+// @Description Replace the segments the occurrences of the event are planned from. Every segment must end within the event's usual length.
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param template body dto.SetRundownTemplateRequest true "Template segments"
+// @Success 200 {object} dto.RundownTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown/template [put]
+func (c *EventRundownController) SetRundownTemplate(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SetRundownTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := c.rundownService.SetTemplate(eventID, &req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to set run-sheet template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// SaveRundownTemplate godoc
+// @Summary Save an occurrence's run-sheet as the template
+// @Description Make the run-sheet of one occurrence the template later occurrences are planned from
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrence body dto.SaveRundownTemplateRequest true "Occurrence date"
+// @Success 200 {object} dto.RundownTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown/template/from-occurrence [post]
+func (c *EventRundownController) SaveRundownTemplate(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SaveRundownTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := c.rundownService.SaveAsTemplate(eventID, &req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to save run-sheet template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// ApplyRundownTemplate godoc
+// @Summary Copy the run-sheet template to future occurrences
+// @Description Give the occurrences between two dates that have not started yet a copy of the template. Occurrences that already have a run-sheet keep it unless overwrite is set; occurrences too short for the template are skipped.
+// @Tags event-rundown
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param range body dto.ApplyRundownTemplateRequest true "Dates to apply the template to"
+// @Success 200 {object} dto.ApplyRundownTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/rundown/template/apply [post]
+func (c *EventRundownController) ApplyRundownTemplate(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.ApplyRundownTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := c.rundownService.ApplyTemplate(eventID, &req)
+	if err != nil {
+		ctx.JSON(rundownErrorStatus(err), gin.H{
+			"error":   "Failed to apply run-sheet template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c *EventRundownController) bindOccurrenceQuery(ctx *gin.Context) (uuid.UUID, *dto.EventRundownFilterRequest, bool) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return uuid.Nil, nil, false
+	}
+
+	var req dto.EventRundownFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return uuid.Nil, nil, false
+	}
+
+	return eventID, &req, true
+}
+
+func rundownErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,423 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+type EventTemplateController struct {
+	templateService  service.EventTemplateService
+	eventAuthService service.EventAuthorizationService
+}
+
+func NewEventTemplateController(templateService service.EventTemplateService, eventAuthService service.EventAuthorizationService) *EventTemplateController {
+	return &EventTemplateController{
+		templateService:  templateService,
+		eventAuthService: eventAuthService,
+	}
+}
+
+// CreateEventTemplate godoc
+// @Summary Create an event template
+// @Description Create a template from the event fields and PIC roles. PIC permissions left out are taken from the predefined role.
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param template body dto.CreateEventTemplateRequest true "Template data"
+// @Success 201 {object} dto.EventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the church"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates [post]
+func (c *EventTemplateController) CreateEventTemplate(ctx *gin.Context) {
+	var req dto.CreateEventTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !authorizeChurches(ctx, c.eventAuthService, req.ChurchID, nil, nil) {
+		return
+	}
+
+	template, err := c.templateService.CreateTemplate(&req)
+	if err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to create event template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, template)
+}
+
+// SaveEventAsTemplate godoc
+// @Summary Save an event as a template
+// @Description Save an event with its PICs, setlists and run-sheets as a template. With an occurrence date only that occurrence's setlist and run-sheet are kept; without one, every occurrence of a series that ends is kept.
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param template body dto.SaveEventTemplateRequest true "Template name and occurrence"
+// @Success 201 {object} dto.EventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/template [post]
+func (c *EventTemplateController) SaveEventAsTemplate(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SaveEventTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := c.templateService.SaveEventAsTemplate(eventID, &req)
+	if err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to save event as template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, template)
+}
+
+// ListEventTemplates godoc
+// @Summary List event templates
+// @Description List the templates of no church and of the churches the caller belongs to or administers
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param type query string false "Event type"
+// @Param search query string false "Search in name or title"
+// @Param churchId query string false "Only templates of this church"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.EventTemplateListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates [get]
+func (c *EventTemplateController) ListEventTemplates(ctx *gin.Context) {
+	var req dto.EventTemplateFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	viewer, ok := eventViewer(ctx, c.eventAuthService)
+	if !ok {
+		return
+	}
+	req.Viewer = viewer
+
+	templates, err := c.templateService.ListTemplates(&req)
+	if err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to list event templates",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// GetEventTemplate godoc
+// @Summary Get an event template
+// @Description Get a template with its PIC roles, setlists and run-sheets
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} dto.EventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the template's church"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates/{id} [get]
+func (c *EventTemplateController) GetEventTemplate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	template, err := c.templateService.GetTemplate(id)
+	if err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to get event template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// UpdateEventTemplate godoc
+// @Summary Update an event template
+// @Description Change the fields that are set; pics replaces every PIC role when set. Setlists and run-sheets are kept.
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param template body dto.UpdateEventTemplateRequest true "Fields to change"
+// @Success 200 {object} dto.EventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the church"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates/{id} [put]
+func (c *EventTemplateController) UpdateEventTemplate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateEventTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !authorizeChurches(ctx, c.eventAuthService, req.ChurchID, nil, nil) {
+		return
+	}
+
+	template, err := c.templateService.UpdateTemplate(id, &req)
+	if err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to update event template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// DeleteEventTemplate godoc
+// @Summary Delete an event template
+// @Description Events created from the template are not affected
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates/{id} [delete]
+func (c *EventTemplateController) DeleteEventTemplate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	if err := c.templateService.DeleteTemplate(id); err != nil {
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to delete event template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Event template deleted successfully",
+	})
+}
+
+// InstantiateEventTemplate godoc
+// @Summary Create an event from a template
+// @Description Create an event starting on the given date. PICs, setlists and run-sheets land on the same days relative to the start; a series end moves with it. PIC roles are filled from the request or the template's default person.
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param event body dto.InstantiateEventTemplateRequest true "Start date and overrides"
+// @Success 201 {object} dto.InstantiateEventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the template's or the new event's church, or overrides a venue conflict without administering the venue's church"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /event-templates/{id}/instantiate [post]
+func (c *EventTemplateController) InstantiateEventTemplate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	var req dto.InstantiateEventTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Without a venue in the request the event books the template's
+	venueOfTemplate := func() (*uuid.UUID, error) {
+		return c.eventAuthService.VenueIDOfTemplate(id)
+	}
+	if !c.authorizeNewEventChurch(ctx, func() (*uuid.UUID, error) {
+		return c.eventAuthService.ChurchIDOfTemplate(id)
+	}, venueOfTemplate, &req) {
+		return
+	}
+	if !authorizeVenueOverride(ctx, c.eventAuthService, req.AllowVenueConflict, req.VenueID, venueOfTemplate) {
+		return
+	}
+
+	result, err := c.templateService.InstantiateTemplate(id, &req)
+	if err != nil {
+		if respondVenueConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to create event from template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// CloneEvent godoc
+// @Summary Clone an event to new dates
+// @Description Copy an event with its PICs, setlists and run-sheets to start on the given date, shifting everything dated by the same number of days
+// @Tags event-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param event body dto.CloneEventRequest true "Start date and overrides"
+// @Success 201 {object} dto.InstantiateEventTemplateResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Caller neither belongs to nor administers the template's or the new event's church, or overrides a venue conflict without administering the venue's church"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/clone [post]
+func (c *EventTemplateController) CloneEvent(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.CloneEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Without a venue in the request the clone books the event's
+	venueOfEvent := func() (*uuid.UUID, error) {
+		return c.eventAuthService.VenueIDOfEvent(eventID)
+	}
+	if !c.authorizeNewEventChurch(ctx, func() (*uuid.UUID, error) {
+		return c.eventAuthService.ChurchIDOfEvent(eventID)
+	}, venueOfEvent, &req.InstantiateEventTemplateRequest) {
+		return
+	}
+	if !authorizeVenueOverride(ctx, c.eventAuthService, req.AllowVenueConflict, req.VenueID, venueOfEvent) {
+		return
+	}
+
+	result, err := c.templateService.CloneEvent(eventID, &req)
+	if err != nil {
+		if respondVenueConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(eventTemplateErrorStatus(err), gin.H{
+			"error":   "Failed to clone event",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// authorizeNewEventChurch checks the church an event created from a template or another event goes to:
+// the source's church, or without one the church of the venue it books. When it books none either, the
+// event goes to the caller's church.
+func (c *EventTemplateController) authorizeNewEventChurch(ctx *gin.Context, churchOf func() (*uuid.UUID, error), venueOf func() (*uuid.UUID, error), req *dto.InstantiateEventTemplateRequest) bool {
+	venueID := req.VenueID
+	churchID, err := churchOf()
+	if err == nil && churchID == nil && venueID == nil {
+		venueID, err = venueOf()
+	}
+	if err != nil {
+		ctx.JSON(lookupErrorStatus(err), gin.H{
+			"error":   "Failed to check church access",
+			"details": err.Error(),
+		})
+		return false
+	}
+	if churchID == nil && venueID == nil {
+		if churchID = callerChurch(ctx, c.eventAuthService); churchID == nil {
+			return false
+		}
+		req.ChurchID = churchID
+	}
+	return authorizeChurches(ctx, c.eventAuthService, churchID, nil, venueID)
+}
+
+func eventTemplateErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
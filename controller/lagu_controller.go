@@ -0,0 +1,867 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+type LaguController struct {
+	laguService service.LaguService
+}
+
+func NewLaguController(laguService service.LaguService) *LaguController {
+	return &LaguController{
+		laguService: laguService,
+	}
+}
+
+// CreateLagu godoc
+// @Summary Create a song
+// @Description Add a song with its lyrics and tags to the song library
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param lagu body dto.CreateLaguRequest true "Song data"
+// @Success 201 {object} dto.LaguDetailResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu [post]
+func (c *LaguController) CreateLagu(ctx *gin.Context) {
+	var req dto.CreateLaguRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	lagu, err := c.laguService.CreateLagu(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to create song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, lagu)
+}
+
+// ListLagu godoc
+// @Summary List songs
+// @Description Get a paginated list of songs. The search matches title, artist, genre, tags and lyrics, ignoring case and accents; every word must match.
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param search query string false "Search in title, artist, genre, tags and lyrics"
+// @Param genre query string false "Filter by genre"
+// @Param tagId query string false "Filter by tag ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.LaguListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu [get]
+func (c *LaguController) ListLagu(ctx *gin.Context) {
+	var req dto.LaguFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	songs, err := c.laguService.ListLagu(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to list songs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, songs)
+}
+
+// GetLagu godoc
+// @Summary Get song by ID
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.LaguDetailResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /lagu/{id} [get]
+func (c *LaguController) GetLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	lagu, err := c.laguService.GetLagu(id)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lagu)
+}
+
+// UpdateLagu godoc
+// @Summary Update a song
+// @Description Update a song; tagIds, when given, replaces all of its tags
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Song ID"
+// @Param lagu body dto.UpdateLaguRequest true "Song update data"
+// @Success 200 {object} dto.LaguDetailResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/{id} [put]
+func (c *LaguController) UpdateLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateLaguRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	lagu, err := c.laguService.UpdateLagu(id, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to update song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lagu)
+}
+
+// DeleteLagu godoc
+// @Summary Delete a song
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Song ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/{id} [delete]
+func (c *LaguController) DeleteLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	if err := c.laguService.DeleteLagu(id); err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to delete song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetLaguChart godoc
+// @Summary Get the chord chart of a song
+// @Description Get the ChordPro chart of a song, transposed from its key (nadaDasar) to the given key. Chords are spelled with sharps or flats following the key signature unless accidentals is given.
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Song ID"
+// @Param key query string false "Key to transpose to, e.g. D, Eb or Bm"
+// @Param from query string false "Key the chart is in, for songs without nadaDasar"
+// @Param accidentals query string false "Spell chords with sharp or flat"
+// @Success 200 {object} dto.LaguChartResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /lagu/{id}/chart [get]
+func (c *LaguController) GetLaguChart(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	var req dto.LaguChartRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	chart, err := c.laguService.GetLaguChart(id, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get chart",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, chart)
+}
+
+// ExportChordPro godoc
+// @Summary Export a song as ChordPro
+// @Description Download a song as a ChordPro file with title, artist, key and year directives, optionally transposed
+// @Tags lagu
+// @Produce plain
+// @Param id path string true "Song ID"
+// @Param key query string false "Key to transpose to"
+// @Param from query string false "Key the chart is in, for songs without nadaDasar"
+// @Param accidentals query string false "Spell chords with sharp or flat"
+// @Success 200 {string} string "ChordPro document"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /lagu/{id}/chordpro [get]
+func (c *LaguController) ExportChordPro(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	var req dto.LaguChartRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	chordPro, err := c.laguService.ExportChordPro(id, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to export song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("lagu-%s.cho", id)))
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", chordPro)
+}
+
+// ImportChordPro godoc
+// @Summary Import a song from ChordPro
+// @Description Create a song from an uploaded ChordPro file. The title, artist, key and year directives fill the song and the lyrics are taken from the chart.
+// @Tags lagu
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "ChordPro file (the raw request body is used when omitted)"
+// @Param genre query string false "Genre of the song"
+// @Param youtubeLink query string false "YouTube link of the song"
+// @Success 201 {object} dto.LaguDetailResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/import [post]
+func (c *LaguController) ImportChordPro(ctx *gin.Context) {
+	var req dto.ImportChordProRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, err := c.readChordPro(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to read ChordPro file",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	lagu, err := c.laguService.ImportChordPro(data, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to import song",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, lagu)
+}
+
+// GetLaguLyrics godoc
+// @Summary Get the lyrics of a song for projection
+// @Description Get the lyrics as plain text without chords, directives or comments, sections separated by a blank line
+// @Tags lagu
+// @Produce plain
+// @Param id path string true "Song ID"
+// @Success 200 {string} string "Lyrics"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /lagu/{id}/lyrics [get]
+func (c *LaguController) GetLaguLyrics(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	lyrics, err := c.laguService.GetLaguLyrics(id)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get lyrics",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.String(http.StatusOK, lyrics)
+}
+
+// readChordPro accepts either a multipart "file" field or the raw request body
+func (c *LaguController) readChordPro(ctx *gin.Context) ([]byte, error) {
+	if fileHeader, err := ctx.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	data, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("request body is empty")
+	}
+	return data, nil
+}
+
+// CreateTagLagu godoc
+// @Summary Create a song tag
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param tag body dto.CreateTagLaguRequest true "Tag data"
+// @Success 201 {object} dto.TagLaguResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /tag-lagu [post]
+func (c *LaguController) CreateTagLagu(ctx *gin.Context) {
+	var req dto.CreateTagLaguRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tag, err := c.laguService.CreateTagLagu(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to create tag",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, tag)
+}
+
+// ListTagLagu godoc
+// @Summary List song tags
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param search query string false "Search in tag name"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.TagLaguListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /tag-lagu [get]
+func (c *LaguController) ListTagLagu(ctx *gin.Context) {
+	var req dto.TagLaguFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tags, err := c.laguService.ListTagLagu(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to list tags",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// GetTagLagu godoc
+// @Summary Get song tag by ID
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} dto.TagLaguResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tag-lagu/{id} [get]
+func (c *LaguController) GetTagLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tag ID format",
+		})
+		return
+	}
+
+	tag, err := c.laguService.GetTagLagu(id)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get tag",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// UpdateTagLagu godoc
+// @Summary Rename a song tag
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param tag body dto.UpdateTagLaguRequest true "Tag update data"
+// @Success 200 {object} dto.TagLaguResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /tag-lagu/{id} [put]
+func (c *LaguController) UpdateTagLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tag ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateTagLaguRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tag, err := c.laguService.UpdateTagLagu(id, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to update tag",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// DeleteTagLagu godoc
+// @Summary Delete a song tag
+// @Description Delete a tag and remove it from every song carrying it
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /tag-lagu/{id} [delete]
+func (c *LaguController) DeleteTagLagu(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tag ID format",
+		})
+		return
+	}
+
+	if err := c.laguService.DeleteTagLagu(id); err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to delete tag",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetEventSetlist godoc
+// @Summary Get the setlist of an event occurrence
+// @Description Get the songs of one occurrence of an event in the order they are sung
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} dto.EventSetlistResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/setlist [get]
+func (c *LaguController) GetEventSetlist(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.EventSetlistFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	setlist, err := c.laguService.GetEventSetlist(eventID, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get setlist",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setlist)
+}
+
+// SetEventSetlist godoc
+// @Summary Set the setlist of an event occurrence
+// @Description Replace the setlist of one occurrence with the given songs, in order, each with an optional performed key, song leader and notes. An empty list clears the setlist.
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param setlist body dto.SetEventSetlistRequest true "Occurrence date and ordered songs"
+// @Success 200 {object} dto.EventSetlistResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/setlist [put]
+func (c *LaguController) SetEventSetlist(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SetEventSetlistRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	setlist, err := c.laguService.SetEventSetlist(eventID, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to set setlist",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setlist)
+}
+
+// ExportEventSetlist godoc
+// @Summary Export the setlist of an event occurrence
+// @Description Export the songs of one occurrence in setlist order with their lyrics split into slides, for presentation software: plain text with slides separated by blank lines, a zip of OpenLyrics files, one per song, or a printable HTML lyrics sheet
+// @Tags lagu
+// @Produce plain
+// @Produce application/zip
+// @Produce html
+// @Param id path string true "Event ID"
+// @Param occurrenceDate query string true "Occurrence date (YYYY-MM-DD)"
+// @Param format query string false "text (default), openlyrics or html"
+// @Param slideLines query int false "Lines per slide (default: 4)"
+// @Success 200 {string} string "Setlist file"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/setlist/export [get]
+func (c *LaguController) ExportEventSetlist(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SetlistExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, err := c.laguService.ExportEventSetlist(eventID, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to export setlist",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("setlist-%s-%s", eventID, req.OccurrenceDate)
+	switch req.Format {
+	case service.SetlistExportOpenLyrics:
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+		ctx.Data(http.StatusOK, "application/zip", data)
+	case service.SetlistExportHTML:
+		ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename+".html"))
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", data)
+	default:
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".txt"))
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+	}
+}
+
+// GetLaguHistory godoc
+// @Summary Get the times a song was sung
+// @Description List the occurrences whose setlist had the song, most recent first, with the date it was last sung. Only dates up to today count unless endDate is given.
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param id path string true "Song ID"
+// @Param churchId query string false "Only events owned by or shared with this church"
+// @Param startDate query string false "Start date (YYYY-MM-DD)"
+// @Param endDate query string false "End date (YYYY-MM-DD), default today"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.LaguHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/{id}/history [get]
+func (c *LaguController) GetLaguHistory(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid song ID format",
+		})
+		return
+	}
+
+	var req dto.LaguHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	history, err := c.laguService.GetLaguHistory(id, &req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get song history",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// GetRecentLagu godoc
+// @Summary List recently sung songs
+// @Description List the songs in the setlists of the last weeks up to today, most sung first
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param churchId query string false "Only events owned by or shared with this church"
+// @Param weeks query int false "Number of weeks to look back (default: 6)"
+// @Success 200 {object} dto.RecentLaguListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/recent [get]
+func (c *LaguController) GetRecentLagu(ctx *gin.Context) {
+	var req dto.RecentLaguRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	songs, err := c.laguService.GetRecentLagu(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to list recent songs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, songs)
+}
+
+// GetSongUsageReport godoc
+// @Summary Report song usage
+// @Description Count the occurrences each song was sung at between two dates, per church and optionally per month, quarter or year, with the dates it was sung, for licensing reports. Every occurrence of a recurring event counts; cancelled occurrences do not.
+// @Tags lagu
+// @Accept json
+// @Produce json
+// @Param churchId query string false "Only events owned by or shared with this church"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Param period query string false "all (default), month, quarter or year"
+// @Success 200 {object} dto.SongUsageReportResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/usage-report [get]
+func (c *LaguController) GetSongUsageReport(ctx *gin.Context) {
+	var req dto.SongUsageReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	report, err := c.laguService.GetSongUsageReport(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to get song usage report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportSongUsageReport godoc
+// @Summary Export song usage as CSV
+// @Description Export the song usage report as CSV, one line per church, period and song
+// @Tags lagu
+// @Produce text/csv
+// @Param churchId query string false "Only events owned by or shared with this church"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Param period query string false "all (default), month, quarter or year"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lagu/usage-report/export [get]
+func (c *LaguController) ExportSongUsageReport(ctx *gin.Context) {
+	var req dto.SongUsageReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, err := c.laguService.ExportSongUsageReport(&req)
+	if err != nil {
+		ctx.JSON(laguErrorStatus(err), gin.H{
+			"error":   "Failed to export song usage report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("song-usage-%s-%s.csv", req.StartDate, req.EndDate)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// laguErrorStatus maps song library service errors to HTTP status codes
+func laguErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(message, "already exists"):
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
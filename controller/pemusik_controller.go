@@ -0,0 +1,535 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+type PemusikController struct {
+	pemusikService   service.PemusikService
+	eventAuthService service.EventAuthorizationService
+}
+
+func NewPemusikController(pemusikService service.PemusikService, eventAuthService service.EventAuthorizationService) *PemusikController {
+	return &PemusikController{
+		pemusikService:   pemusikService,
+		eventAuthService: eventAuthService,
+	}
+}
+
+// GetKebutuhan godoc
+// @Summary Get the instruments an event needs
+// @Description Get how many players of each instrument every occurrence of the event needs
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} dto.KebutuhanPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/kebutuhan [get]
+func (c *PemusikController) GetKebutuhan(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	kebutuhan, err := c.pemusikService.GetKebutuhan(eventID)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to get musician requirements",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, kebutuhan)
+}
+
+// SetKebutuhan godoc
+// @Summary Set the instruments an event needs
+// @Description Replace the instruments every occurrence of the event needs and how many players of each. An empty list clears them.
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param kebutuhan body dto.SetKebutuhanPemusikRequest true "Instruments and players needed"
+// @Success 200 {object} dto.KebutuhanPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/kebutuhan [put]
+func (c *PemusikController) SetKebutuhan(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SetKebutuhanPemusikRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	kebutuhan, err := c.pemusikService.SetKebutuhan(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to set musician requirements",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, kebutuhan)
+}
+
+// SubmitKetersediaan godoc
+// @Summary Submit musician availability
+// @Description Record whether a musician can play at one occurrence of the event and on which instruments. A later submission for the same occurrence replaces the earlier one. Musicians submit their own availability; the event's editors may submit anyone's.
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param ketersediaan body dto.SubmitKetersediaanRequest true "Availability data"
+// @Success 200 {object} dto.KetersediaanPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/ketersediaan [post]
+func (c *PemusikController) SubmitKetersediaan(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.SubmitKetersediaanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !c.authorizeMusician(ctx, eventID, req.PersonID) {
+		return
+	}
+
+	ketersediaan, err := c.pemusikService.SubmitKetersediaan(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to submit availability",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ketersediaan)
+}
+
+// GetKetersediaan godoc
+// @Summary List musician availability
+// @Description List what musicians said about the occurrences of the event between two dates
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} dto.KetersediaanPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/ketersediaan [get]
+func (c *PemusikController) GetKetersediaan(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.PemusikRangeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ketersediaan, err := c.pemusikService.GetKetersediaan(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to get availability",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ketersediaan)
+}
+
+// DeleteKetersediaan godoc
+// @Summary Delete a musician availability
+// @Description Musicians delete their own availability; the event's editors may delete anyone's
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Availability ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /ketersediaan-pemusik/{id} [delete]
+func (c *PemusikController) DeleteKetersediaan(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid availability ID format",
+		})
+		return
+	}
+
+	eventID, personID, err := c.eventAuthService.KetersediaanOwner(id)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to delete availability",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !c.authorizeMusician(ctx, eventID, personID) {
+		return
+	}
+
+	if err := c.pemusikService.DeleteKetersediaan(id); err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to delete availability",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GenerateJadwal godoc
+// @Summary Generate the musician roster
+// @Description Fill the instruments the event needs at every occurrence between two dates from the available musicians, balancing load across weeks. Places set by hand are kept and occurrences whose roster is published are skipped. The generated places are drafts until published.
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param range body dto.PemusikRangeRequest true "Dates to generate the roster for"
+// @Success 200 {object} dto.GenerateJadwalPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/jadwal/generate [post]
+func (c *PemusikController) GenerateJadwal(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.PemusikRangeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	jadwal, err := c.pemusikService.GenerateJadwal(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to generate roster",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jadwal)
+}
+
+// PublishJadwal godoc
+// @Summary Publish the musician roster
+// @Description Publish the draft roster places between two dates and notify each musician of their places
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param range body dto.PemusikRangeRequest true "Dates to publish the roster for"
+// @Success 200 {object} dto.PublishJadwalPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/jadwal/publish [post]
+func (c *PemusikController) PublishJadwal(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.PemusikRangeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := c.pemusikService.PublishJadwal(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to publish roster",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetJadwal godoc
+// @Summary Get the musician roster
+// @Description List the roster places of the occurrences of the event between two dates, drafts included
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param startDate query string true "Start date (YYYY-MM-DD)"
+// @Param endDate query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} dto.JadwalPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/jadwal [get]
+func (c *PemusikController) GetJadwal(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.PemusikRangeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	jadwal, err := c.pemusikService.GetJadwal(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to get roster",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jadwal)
+}
+
+// AddJadwal godoc
+// @Summary Put a musician on the roster
+// @Description Put a musician on the roster of one occurrence by hand. Generating the roster again keeps the place. On a published roster the place is published at once and the musician is notified.
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param jadwal body dto.AddJadwalPemusikRequest true "Roster place data"
+// @Success 201 {object} dto.JadwalPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /events/{id}/pemusik/jadwal [post]
+func (c *PemusikController) AddJadwal(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return
+	}
+
+	var req dto.AddJadwalPemusikRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	jadwal, err := c.pemusikService.AddJadwal(eventID, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to add to roster",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, jadwal)
+}
+
+// UpdateJadwal godoc
+// @Summary Change a roster place
+// @Description Swap the musician or change the instrument of a roster place by hand. Generating the roster again keeps the place.
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Roster place ID"
+// @Param jadwal body dto.UpdateJadwalPemusikRequest true "Roster place update data"
+// @Success 200 {object} dto.JadwalPemusikResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /jadwal-pemusik/{id} [put]
+func (c *PemusikController) UpdateJadwal(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid roster place ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateJadwalPemusikRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	jadwal, err := c.pemusikService.UpdateJadwal(id, &req)
+	if err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to update roster place",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jadwal)
+}
+
+// DeleteJadwal godoc
+// @Summary Take a musician off the roster
+// @Tags pemusik
+// @Accept json
+// @Produce json
+// @Param id path string true "Roster place ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /jadwal-pemusik/{id} [delete]
+func (c *PemusikController) DeleteJadwal(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid roster place ID format",
+		})
+		return
+	}
+
+	if err := c.pemusikService.DeleteJadwal(id); err != nil {
+		ctx.JSON(pemusikErrorStatus(err), gin.H{
+			"error":   "Failed to delete roster place",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// authorizeMusician lets the caller act for a musician when it is the musician themselves or someone who
+// may edit the event, and answers the request otherwise
+func (c *PemusikController) authorizeMusician(ctx *gin.Context, eventID, musicianID uuid.UUID) bool {
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return false
+	}
+	if *personID == musicianID {
+		return true
+	}
+
+	allowed, err := c.eventAuthService.CanPerform(ctx.Request.Context(), eventID, *personID, service.EventActionEdit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"details": err.Error(),
+			})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check permissions",
+			"details": err.Error(),
+		})
+		return false
+	}
+	if !allowed {
+		respondAccessDenied(ctx, "Musicians may only manage their own availability. Only PICs with edit permission or church admins can manage it for others.")
+		return false
+	}
+	return true
+}
+
+func pemusikErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "person is already on the roster"):
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
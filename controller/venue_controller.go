@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+type VenueController struct {
+	venueService     service.VenueService
+	eventAuthService service.EventAuthorizationService
+}
+
+func NewVenueController(venueService service.VenueService, eventAuthService service.EventAuthorizationService) *VenueController {
+	return &VenueController{
+		venueService:     venueService,
+		eventAuthService: eventAuthService,
+	}
+}
+
+// CreateVenue godoc
+// @Summary Create a venue
+// @Description Create a hall or room of a church that events can be booked into
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param venue body dto.CreateVenueRequest true "Venue data"
+// @Success 201 {object} dto.VenueResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /venues [post]
+func (c *VenueController) CreateVenue(ctx *gin.Context) {
+	var req dto.CreateVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Only admins of the church may add venues to it
+	personID := actorPersonID(ctx)
+	if personID == nil {
+		respondAccessDenied(ctx, "Your account is not linked to a person")
+		return
+	}
+	allowed, err := c.eventAuthService.IsChurchAdmin(ctx.Request.Context(), *personID, &req.ChurchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check church access",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !allowed {
+		respondAccessDenied(ctx, "Only admins of the church can add venues to it")
+		return
+	}
+
+	venue, err := c.venueService.CreateVenue(&req)
+	if err != nil {
+		ctx.JSON(venueErrorStatus(err), gin.H{
+			"error":   "Failed to create venue",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, venue)
+}
+
+// ListVenues godoc
+// @Summary List venues
+// @Description Get a paginated list of venues, optionally of one church
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param churchId query string false "Church ID"
+// @Param isActive query bool false "Filter by active/inactive venues"
+// @Param search query string false "Search in name or description"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20)"
+// @Success 200 {object} dto.VenueListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /venues [get]
+func (c *VenueController) ListVenues(ctx *gin.Context) {
+	var req dto.VenueFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	venues, err := c.venueService.ListVenues(&req)
+	if err != nil {
+		ctx.JSON(venueErrorStatus(err), gin.H{
+			"error":   "Failed to list venues",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venues)
+}
+
+// GetVenue godoc
+// @Summary Get venue by ID
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Success 200 {object} dto.VenueResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /venues/{id} [get]
+func (c *VenueController) GetVenue(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid venue ID format",
+		})
+		return
+	}
+
+	venue, err := c.venueService.GetVenue(id)
+	if err != nil {
+		ctx.JSON(venueErrorStatus(err), gin.H{
+			"error":   "Failed to get venue",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+// UpdateVenue godoc
+// @Summary Update a venue
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Param venue body dto.UpdateVenueRequest true "Venue update data"
+// @Success 200 {object} dto.VenueResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /venues/{id} [put]
+func (c *VenueController) UpdateVenue(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid venue ID format",
+		})
+		return
+	}
+
+	var req dto.UpdateVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	venue, err := c.venueService.UpdateVenue(id, &req)
+	if err != nil {
+		ctx.JSON(venueErrorStatus(err), gin.H{
+			"error":   "Failed to update venue",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+// DeleteVenue godoc
+// @Summary Delete a venue
+// @Description Delete a venue that no event is booked into; deactivate venues that are still in use
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /venues/{id} [delete]
+func (c *VenueController) DeleteVenue(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid venue ID format",
+		})
+		return
+	}
+
+	if err := c.venueService.DeleteVenue(id); err != nil {
+		ctx.JSON(venueErrorStatus(err), gin.H{
+			"error":   "Failed to delete venue",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// venueErrorStatus maps venue service errors to HTTP status codes
+func venueErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(message, "already exists"), strings.Contains(message, "still booked"):
+		return http.StatusConflict
+	case strings.HasPrefix(message, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event attendance DTOs
+
+type CheckInRequest struct {
+	OccurrenceDate string     `json:"occurrenceDate" validate:"required"` // YYYY-MM-DD in the event's timezone
+	PersonID       *uuid.UUID `json:"personId,omitempty"`                 // either personId or visitorId
+	VisitorID      *uuid.UUID `json:"visitorId,omitempty"`
+	AgeGroup       string     `json:"ageGroup,omitempty" validate:"omitempty,oneof=adult youth kid"` // derived from the birth date of a person when empty
+	Notes          string     `json:"notes,omitempty"`
+}
+
+type RecordHeadcountRequest struct {
+	OccurrenceDate string `json:"occurrenceDate" validate:"required"`
+	Adults         int    `json:"adults" validate:"min=0"`
+	Youth          int    `json:"youth" validate:"min=0"`
+	Kids           int    `json:"kids" validate:"min=0"`
+	Notes          string `json:"notes,omitempty"`
+}
+
+type EventAttendanceFilterRequest struct {
+	OccurrenceDate string `form:"occurrenceDate" validate:"required"`
+	AgeGroup       string `form:"ageGroup,omitempty"`
+	Search         string `form:"search,omitempty"`
+	Page           int    `form:"page,omitempty"`
+	Limit          int    `form:"limit,omitempty"`
+}
+
+type AttendanceReportRequest struct {
+	StartDate string `form:"startDate" validate:"required"`
+	EndDate   string `form:"endDate" validate:"required"`
+}
+
+type EventAttendanceResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	EventID        uuid.UUID       `json:"eventId"`
+	EventTitle     string          `json:"eventTitle,omitempty"`
+	OccurrenceDate time.Time       `json:"occurrenceDate"`
+	PersonID       *uuid.UUID      `json:"personId,omitempty"`
+	Person         *PersonSummary  `json:"person,omitempty"`
+	VisitorID      *uuid.UUID      `json:"visitorId,omitempty"`
+	Visitor        *VisitorSummary `json:"visitor,omitempty"`
+	RegistrationID *uuid.UUID      `json:"registrationId,omitempty"`
+	AgeGroup       string          `json:"ageGroup"`
+	Method         string          `json:"method"`
+	CheckedInAt    time.Time       `json:"checkedInAt"`
+	CheckedInByID  *uuid.UUID      `json:"checkedInById,omitempty"`
+	Notes          string          `json:"notes"`
+}
+
+type EventAttendanceListResponse struct {
+	Attendances []EventAttendanceResponse `json:"attendances"`
+	TotalCount  int                       `json:"totalCount"`
+	Page        int                       `json:"page"`
+	Limit       int                       `json:"limit"`
+}
+
+type EventHeadcountResponse struct {
+	EventID        uuid.UUID  `json:"eventId"`
+	OccurrenceDate time.Time  `json:"occurrenceDate"`
+	Adults         int        `json:"adults"`
+	Youth          int        `json:"youth"`
+	Kids           int        `json:"kids"`
+	Total          int        `json:"total"`
+	Notes          string     `json:"notes"`
+	RecordedByID   *uuid.UUID `json:"recordedById,omitempty"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// Attendance numbers split by age group
+type AttendanceCounts struct {
+	Total  int `json:"total"`
+	Adults int `json:"adults"`
+	Youth  int `json:"youth"`
+	Kids   int `json:"kids"`
+}
+
+// Expected against actual attendance of one occurrence
+type OccurrenceAttendanceSummary struct {
+	EventID        uuid.UUID        `json:"eventId"`
+	EventTitle     string           `json:"eventTitle"`
+	OccurrenceDate time.Time        `json:"occurrenceDate"`
+	StartDatetime  time.Time        `json:"startDatetime"`
+	Expected       AttendanceCounts `json:"expected"`
+	CheckedIn      AttendanceCounts `json:"checkedIn"`      // individual check-ins
+	Headcount      AttendanceCounts `json:"headcount"`      // anonymous headcount
+	Actual         AttendanceCounts `json:"actual"`         // check-ins plus headcount
+	Variance       AttendanceCounts `json:"variance"`       // actual minus expected
+	AttendanceRate float64          `json:"attendanceRate"` // actual total as a percentage of expected, 0 without an expectation
+}
+
+type AttendanceReportResponse struct {
+	EventID           uuid.UUID                     `json:"eventId"`
+	EventTitle        string                        `json:"eventTitle"`
+	StartDate         string                        `json:"startDate"`
+	EndDate           string                        `json:"endDate"`
+	Occurrences       []OccurrenceAttendanceSummary `json:"occurrences"`
+	TotalExpected     AttendanceCounts              `json:"totalExpected"`
+	TotalActual       AttendanceCounts              `json:"totalActual"`
+	AverageAttendance float64                       `json:"averageAttendance"` // mean actual total per occurrence
+	AttendanceRate    float64                       `json:"attendanceRate"`
+}
+
+// QR code check-in
+
+type IssueCheckInTokenRequest struct {
+	OccurrenceDate string `json:"occurrenceDate" form:"occurrenceDate" validate:"required"`
+	PersonID       string `json:"personId" form:"personId" validate:"required"`
+	Size           int    `json:"-" form:"size,omitempty"` // QR code size in pixels
+}
+
+type CheckInTokenResponse struct {
+	Token          string    `json:"token"`
+	EventID        uuid.UUID `json:"eventId"`
+	OccurrenceDate time.Time `json:"occurrenceDate"`
+	PersonID       uuid.UUID `json:"personId"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+type TokenCheckInRequest struct {
+	Token          string `json:"token" validate:"required"`
+	OccurrenceDate string `json:"occurrenceDate" validate:"required"` // occurrence the usher is checking people in to
+}
@@ -15,12 +15,21 @@ type CreateEventRequest struct {
 	Type        string `json:"type" validate:"required,oneof=event ibadah spiritual_journey"`
 
 	EventDate     string `json:"eventDate" validate:"required"`
-	EventLocation string `json:"eventLocation" validate:"required,max=255"`
+	EventLocation string `json:"eventLocation" validate:"required_without=VenueID,max=255"`
 	StartTime     string `json:"startTime" validate:"required"`
 	EndTime       string `json:"endTime" validate:"required"`
 	AllDay        bool   `json:"allDay"`
 	Timezone      string `json:"timezone" validate:"required"`
 
+	// Booking a venue checks it for overlapping occurrences; AllowVenueConflict is the admin override
+	VenueID            *uuid.UUID `json:"venueId,omitempty"`
+	AllowVenueConflict bool       `json:"allowVenueConflict,omitempty"`
+
+	// Owning church, which defaults to the venue's church or without a venue to the creator's;
+	// SharedChurchIDs lists the other churches holding the event
+	ChurchID        *uuid.UUID  `json:"churchId,omitempty"`
+	SharedChurchIDs []uuid.UUID `json:"sharedChurchIds,omitempty"`
+
 	IsPublic              bool       `json:"isPublic"`
 	DiscipleshipJourneyID *uuid.UUID `json:"discipleshipJourneyId,omitempty"`
 
@@ -38,7 +47,9 @@ type CreateEventRequest struct {
 }
 
 type CreateRecurrenceRuleRequest struct {
-	Frequency  string   `json:"frequency" validate:"required,oneof=DAILY WEEKLY MONTHLY YEARLY"`
+	// RFC 5545 RRULE, e.g. "RRULE:FREQ=MONTHLY;BYDAY=SU;BYSETPOS=2". Takes precedence over the fields below.
+	RRule      string   `json:"rrule,omitempty"`
+	Frequency  string   `json:"frequency" validate:"required_without=RRule,omitempty,oneof=DAILY WEEKLY MONTHLY YEARLY"`
 	Interval   int      `json:"interval,omitempty"`
 	ByWeekday  []string `json:"byWeekday,omitempty"`
 	ByMonthDay []int64  `json:"byMonthDay,omitempty"`
@@ -48,6 +59,8 @@ type CreateRecurrenceRuleRequest struct {
 	ByYearDay  []int64  `json:"byYearDay,omitempty"` // day of year (1-366)
 	Count      *int     `json:"count,omitempty"`
 	Until      *string  `json:"until,omitempty"`
+	// Extra dates (RDATE) as YYYY-MM-DD at the event's start time or YYYY-MM-DDTHH:MM, kept with an RRule
+	RDates []string `json:"rdates,omitempty"`
 }
 
 // Event update request
@@ -65,6 +78,14 @@ type UpdateEventRequest struct {
 	AllDay        *bool   `json:"allDay,omitempty"`
 	Timezone      *string `json:"timezone,omitempty"`
 
+	// VenueID moves the event to another venue; the nil UUID removes the booking
+	VenueID            *uuid.UUID `json:"venueId,omitempty"`
+	AllowVenueConflict bool       `json:"allowVenueConflict,omitempty"`
+
+	// ChurchID moves the event to another church; SharedChurchIDs replaces the churches it is shared with
+	ChurchID        *uuid.UUID   `json:"churchId,omitempty"`
+	SharedChurchIDs *[]uuid.UUID `json:"sharedChurchIds,omitempty"`
+
 	IsPublic              *bool      `json:"isPublic,omitempty"`
 	DiscipleshipJourneyID *uuid.UUID `json:"discipleshipJourneyId,omitempty"`
 
@@ -122,6 +143,13 @@ type EventResponse struct {
 	AllDay        bool      `json:"allDay"`
 	Timezone      string    `json:"timezone"`
 
+	VenueID *uuid.UUID     `json:"venueId,omitempty"`
+	Venue   *VenueResponse `json:"venue,omitempty"`
+
+	ChurchID       *uuid.UUID            `json:"churchId,omitempty"`
+	Church         *EventChurchResponse  `json:"church,omitempty"`
+	SharedChurches []EventChurchResponse `json:"sharedChurches,omitempty"`
+
 	IsPublic              bool       `json:"isPublic"`
 	DiscipleshipJourneyID *uuid.UUID `json:"discipleshipJourneyId,omitempty"`
 
@@ -142,6 +170,11 @@ type EventResponse struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+type EventChurchResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
 type RecurrenceRuleResponse struct {
 	ID         uuid.UUID  `json:"id"`
 	Frequency  string     `json:"frequency"`
@@ -154,6 +187,8 @@ type RecurrenceRuleResponse struct {
 	ByYearDay  []int64    `json:"byYearDay"`
 	Count      *int       `json:"count"`
 	Until      *time.Time `json:"until"`
+	RDates     []string   `json:"rdates"` // extra dates (RDATE), YYYY-MM-DD or YYYY-MM-DDTHH:MM
+	RRule      string     `json:"rrule"`  // canonical RFC 5545 form of the rule
 }
 
 type LaguResponse struct {
@@ -163,9 +198,50 @@ type LaguResponse struct {
 
 // Request for getting event occurrences
 type GetEventOccurrencesRequest struct {
-	StartDate string `form:"startDate" validate:"required"`
-	EndDate   string `form:"endDate" validate:"required"`
-	Timezone  string `form:"timezone,omitempty"`
+	StartDate string     `form:"startDate" validate:"required"`
+	EndDate   string     `form:"endDate" validate:"required"`
+	Timezone  string     `form:"timezone,omitempty"`
+	ChurchID  *uuid.UUID `form:"churchId,omitempty"` // only for occurrences across events
+
+	Viewer *EventViewer `form:"-"`
+}
+
+// Request for a filtered, paginated page of occurrences from the occurrence index
+type ListEventOccurrencesRequest struct {
+	StartDate string     `form:"startDate" validate:"required"`
+	EndDate   string     `form:"endDate" validate:"required"`
+	Timezone  string     `form:"timezone,omitempty"`
+	Type      string     `form:"type,omitempty"`
+	IsPublic  *bool      `form:"isPublic,omitempty"`
+	Search    string     `form:"search,omitempty"`
+	ChurchID  *uuid.UUID `form:"churchId,omitempty"`
+	Page      int        `form:"page,omitempty"`
+	Limit     int        `form:"limit,omitempty"`
+
+	Viewer *EventViewer `form:"-"`
+}
+
+// Request for the occurrences of one week (Monday to Sunday)
+type WeekEventOccurrencesRequest struct {
+	Date     string     `form:"date,omitempty"` // any day of the week; defaults to today in Timezone
+	Timezone string     `form:"timezone,omitempty"`
+	Type     string     `form:"type,omitempty"`
+	IsPublic *bool      `form:"isPublic,omitempty"`
+	ChurchID *uuid.UUID `form:"churchId,omitempty"`
+	Page     int        `form:"page,omitempty"`
+	Limit    int        `form:"limit,omitempty"`
+
+	Viewer *EventViewer `form:"-"`
+}
+
+// Paginated occurrence response
+type EventOccurrenceListResponse struct {
+	Occurrences []EventOccurrenceResponse `json:"occurrences"`
+	StartDate   string                    `json:"startDate"`
+	EndDate     string                    `json:"endDate"`
+	TotalCount  int                       `json:"totalCount"`
+	Page        int                       `json:"page"`
+	Limit       int                       `json:"limit"`
 }
 
 // Request for updating single occurrence
@@ -201,12 +277,22 @@ type EventListResponse struct {
 
 // Event filter request
 type EventFilterRequest struct {
-	Type      string `form:"type,omitempty"`
-	IsPublic  *bool  `form:"isPublic,omitempty"`
-	StartDate string `form:"startDate,omitempty"`
-	EndDate   string `form:"endDate,omitempty"`
-	Search    string `form:"search,omitempty"`
-	Page      int    `form:"page,omitempty"`
-	Limit     int    `form:"limit,omitempty"`
-	Timezone  string `form:"timezone,omitempty"`
+	Type      string     `form:"type,omitempty"`
+	IsPublic  *bool      `form:"isPublic,omitempty"`
+	StartDate string     `form:"startDate,omitempty"`
+	EndDate   string     `form:"endDate,omitempty"`
+	Search    string     `form:"search,omitempty"`
+	ChurchID  *uuid.UUID `form:"churchId,omitempty"` // owned by or shared with the church
+	Page      int        `form:"page,omitempty"`
+	Limit     int        `form:"limit,omitempty"`
+	Timezone  string     `form:"timezone,omitempty"`
+
+	Viewer *EventViewer `form:"-"`
+}
+
+// EventViewer limits reads to the events a signed-in person may see: public events, events owned by or
+// shared with one of their churches and events they are a PIC of. Controllers set it; nil reads everything.
+type EventViewer struct {
+	PersonID  uuid.UUID
+	ChurchIDs []uuid.UUID
 }
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Import status values for each VEVENT in an uploaded calendar
+const (
+	ImportStatusCreated     = "created"
+	ImportStatusWouldCreate = "would_create"
+	ImportStatusFailed      = "failed"
+)
+
+// Request options for importing an .ics file
+type ImportEventsRequest struct {
+	DryRun   bool       `form:"dryRun"`
+	Type     string     `form:"type,omitempty"`     // type for events without a matching CATEGORIES value, defaults to "event"
+	IsPublic bool       `form:"isPublic"`           // visibility for events without a CLASS property
+	Timezone string     `form:"timezone,omitempty"` // zone for UTC and floating times, defaults to X-WR-TIMEZONE or UTC
+	ChurchID *uuid.UUID `form:"churchId,omitempty"` // church owning the imported events, defaults to the importer's
+}
+
+// Exception that will be (or was) created for an imported series
+type ImportedExceptionResponse struct {
+	OccurrenceDate string     `json:"occurrenceDate"`
+	IsSkipped      bool       `json:"isSkipped"`
+	OverrideStart  *time.Time `json:"overrideStart,omitempty"`
+	OverrideEnd    *time.Time `json:"overrideEnd,omitempty"`
+}
+
+// Result for a single VEVENT
+type ImportedEventResponse struct {
+	Index      int                         `json:"index"`
+	UID        string                      `json:"uid,omitempty"`
+	Summary    string                      `json:"summary"`
+	Status     string                      `json:"status"`
+	Error      string                      `json:"error,omitempty"`
+	Warnings   []string                    `json:"warnings,omitempty"`
+	Request    *CreateEventRequest         `json:"request,omitempty"`
+	Event      *EventResponse              `json:"event,omitempty"`
+	Exceptions []ImportedExceptionResponse `json:"exceptions,omitempty"`
+}
+
+type ImportEventsResponse struct {
+	DryRun  bool                    `json:"dryRun"`
+	Total   int                     `json:"total"`
+	Created int                     `json:"created"`
+	Failed  int                     `json:"failed"`
+	Results []ImportedEventResponse `json:"results"`
+}
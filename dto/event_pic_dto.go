@@ -15,6 +15,9 @@ type CreateEventPICRequest struct {
 	IsPrimary   bool      `json:"isPrimary"`
 	StartDate   string    `json:"startDate" validate:"required"` // YYYY-MM-DD format
 	EndDate     *string   `json:"endDate,omitempty"`             // YYYY-MM-DD format, nullable
+
+	// Assigning someone who is already PIC of an overlapping occurrence is rejected unless this is set
+	AllowScheduleConflict bool `json:"allowScheduleConflict,omitempty"`
 	
 	// Permissions
 	CanEdit      bool `json:"canEdit"`
@@ -150,8 +153,11 @@ type EventPICFilterRequest struct {
 	IsActive  *bool      `form:"isActive,omitempty"`
 	IsPrimary *bool      `form:"isPrimary,omitempty"`
 	Search    string     `form:"search,omitempty"` // Search in person name, role
+	ChurchID  *uuid.UUID `form:"churchId,omitempty"` // PICs of events owned by or shared with the church
 	Page      int        `form:"page,omitempty"`
 	Limit     int        `form:"limit,omitempty"`
+
+	Viewer *EventViewer `form:"-"`
 }
 
 // Response wrappers
@@ -183,4 +189,123 @@ type TransferEventPICRequest struct {
 	TransferType string    `json:"transferType" validate:"required,oneof=replace add_as_secondary"`
 	Reason       string    `json:"reason,omitempty"`
 	EffectiveDate string   `json:"effectiveDate" validate:"required"` // YYYY-MM-DD format
-}
\ No newline at end of file
+
+	AllowScheduleConflict bool `json:"allowScheduleConflict,omitempty"`
+}
+
+// Schedule conflicts
+
+// PICScheduleConflictResponse is an occurrence the person is already PIC of that overlaps
+// an occurrence of the event they are being assigned to
+type PICScheduleConflictResponse struct {
+	EventID        uuid.UUID `json:"eventId"`
+	EventTitle     string    `json:"eventTitle"`
+	Role           string    `json:"role"`
+	OccurrenceDate string    `json:"occurrenceDate"` // date of the conflicting event's occurrence
+	StartDatetime  time.Time `json:"startDatetime"`
+	EndDatetime    time.Time `json:"endDatetime"`
+	ConflictsWith  string    `json:"conflictsWith"` // date of the occurrence of the event being assigned
+}
+
+type PersonPICConflictsRequest struct {
+	StartDate string `form:"startDate" validate:"required"` // YYYY-MM-DD format
+	EndDate   string `form:"endDate" validate:"required"`   // YYYY-MM-DD format
+}
+
+// PICOccurrenceSummary is one occurrence of an event the person is PIC of
+type PICOccurrenceSummary struct {
+	EventPICID     uuid.UUID `json:"eventPicId"`
+	EventID        uuid.UUID `json:"eventId"`
+	EventTitle     string    `json:"eventTitle"`
+	Role           string    `json:"role"`
+	OccurrenceDate string    `json:"occurrenceDate"`
+	StartDatetime  time.Time `json:"startDatetime"`
+	EndDatetime    time.Time `json:"endDatetime"`
+}
+
+// PICOccurrenceConflict is a pair of overlapping occurrences, the earlier one first
+type PICOccurrenceConflict struct {
+	First  PICOccurrenceSummary `json:"first"`
+	Second PICOccurrenceSummary `json:"second"`
+}
+
+type PersonPICConflictsResponse struct {
+	PersonID  uuid.UUID               `json:"personId"`
+	StartDate string                  `json:"startDate"`
+	EndDate   string                  `json:"endDate"`
+	Conflicts []PICOccurrenceConflict `json:"conflicts"`
+}
+// Occurrence-level assignments
+
+// AssignOccurrencePICRequest puts a person in a role for one occurrence, overriding any rotation
+type AssignOccurrencePICRequest struct {
+	OccurrenceDate string    `json:"occurrenceDate" validate:"required"` // YYYY-MM-DD format, in the event's timezone
+	PersonID       uuid.UUID `json:"personId" validate:"required"`
+	Role           string    `json:"role" validate:"required,min=1,max=100"`
+	Notes          string    `json:"notes,omitempty"`
+}
+
+type OccurrencePICFilterRequest struct {
+	StartDate string `form:"startDate" validate:"required"` // YYYY-MM-DD format
+	EndDate   string `form:"endDate" validate:"required"`   // YYYY-MM-DD format
+}
+
+type EventOccurrencePICResponse struct {
+	ID             uuid.UUID     `json:"id"`
+	EventID        uuid.UUID     `json:"eventId"`
+	OccurrenceDate string        `json:"occurrenceDate"`
+	PersonID       uuid.UUID     `json:"personId"`
+	Person         PersonSummary `json:"person"`
+	Role           string        `json:"role"`
+	RotationID     *uuid.UUID    `json:"rotationId,omitempty"`
+	IsOverride     bool          `json:"isOverride"` // assigned by hand rather than by a rotation
+	Notes          string        `json:"notes"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Rotations
+
+type CreateEventPICRotationRequest struct {
+	Role      string      `json:"role" validate:"required,min=1,max=100"`
+	PersonIDs []uuid.UUID `json:"personIds" validate:"required,min=1"` // in turn order
+	Strategy  string      `json:"strategy,omitempty" validate:"omitempty,oneof=round_robin"`
+	FillAhead *int        `json:"fillAhead,omitempty" validate:"omitempty,min=0,max=52"` // defaults to 4
+}
+
+type UpdateEventPICRotationRequest struct {
+	PersonIDs *[]uuid.UUID `json:"personIds,omitempty" validate:"omitempty,min=1"` // replaces the pool and restarts from the first person
+	FillAhead *int         `json:"fillAhead,omitempty" validate:"omitempty,min=0,max=52"`
+	IsActive  *bool        `json:"isActive,omitempty"`
+}
+
+type FillEventPICRotationRequest struct {
+	Occurrences int  `json:"occurrences,omitempty" validate:"omitempty,min=1,max=52"` // defaults to the rotation's fillAhead
+	Overwrite   bool `json:"overwrite"`                                               // reassign occurrences the rotation already filled
+}
+
+type EventPICRotationMemberResponse struct {
+	PersonID uuid.UUID     `json:"personId"`
+	Person   PersonSummary `json:"person"`
+	Position int           `json:"position"`
+}
+
+type EventPICRotationResponse struct {
+	ID           uuid.UUID                        `json:"id"`
+	EventID      uuid.UUID                        `json:"eventId"`
+	Role         string                           `json:"role"`
+	Strategy     string                           `json:"strategy"`
+	FillAhead    int                              `json:"fillAhead"`
+	NextPosition int                              `json:"nextPosition"`
+	IsActive     bool                             `json:"isActive"`
+	Members      []EventPICRotationMemberResponse `json:"members"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type FillEventPICRotationResponse struct {
+	RotationID uuid.UUID                    `json:"rotationId"`
+	Assigned   []EventOccurrencePICResponse `json:"assigned"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Public events feed DTOs. Responses carry only what the public website shows: no PICs, capacity,
+// registration or internal notes.
+
+type PublicEventFilterRequest struct {
+	ChurchID    string `form:"churchId,omitempty"`
+	ProvinsiID  uint   `form:"provinsiId,omitempty"`
+	KabupatenID uint   `form:"kabupatenId,omitempty"`
+	Type        string `form:"type,omitempty"`
+	StartDate   string `form:"startDate,omitempty"` // YYYY-MM-DD format, defaults to today
+	EndDate     string `form:"endDate,omitempty"`   // YYYY-MM-DD format, defaults to 90 days after the start date
+	Timezone    string `form:"timezone,omitempty"`  // times are in each event's own timezone when omitted
+	Page        int    `form:"page,omitempty"`
+	Limit       int    `form:"limit,omitempty"`
+}
+
+type PublicChurchResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Kabupaten string    `json:"kabupaten,omitempty"`
+	Provinsi  string    `json:"provinsi,omitempty"`
+}
+
+// PublicEventOccurrenceResponse is one upcoming occurrence of a public event
+type PublicEventOccurrenceResponse struct {
+	EventID        uuid.UUID              `json:"eventId"`
+	OccurrenceDate string                 `json:"occurrenceDate"` // in the event's timezone
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	BannerImage    string                 `json:"bannerImage,omitempty"`
+	Type           string                 `json:"type"`
+	Location       string                 `json:"location"`
+	Venue          string                 `json:"venue,omitempty"`
+	Church         *PublicChurchResponse  `json:"church,omitempty"`
+	SharedWith     []PublicChurchResponse `json:"sharedWith,omitempty"` // other churches holding the event
+	StartDatetime  time.Time              `json:"startDatetime"`
+	EndDatetime    time.Time              `json:"endDatetime"`
+	AllDay         bool                   `json:"allDay"`
+	Timezone       string                 `json:"timezone"`
+	IsRecurring    bool                   `json:"isRecurring"`
+}
+
+type PublicEventListResponse struct {
+	Occurrences []PublicEventOccurrenceResponse `json:"occurrences"`
+	StartDate   string                          `json:"startDate"`
+	EndDate     string                          `json:"endDate"`
+	TotalCount  int                             `json:"totalCount"`
+	Page        int                             `json:"page"`
+	Limit       int                             `json:"limit"`
+}
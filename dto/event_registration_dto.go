@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event registration (RSVP) DTOs
+
+type CreateEventRegistrationRequest struct {
+	OccurrenceDate string     `json:"occurrenceDate" validate:"required"` // YYYY-MM-DD in the event's timezone
+	PersonID       *uuid.UUID `json:"personId,omitempty"`                 // either personId or visitorId
+	VisitorID      *uuid.UUID `json:"visitorId,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+}
+
+type UpdateEventRegistrationRequest struct {
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=registered cancelled"`
+	Notes  *string `json:"notes,omitempty"`
+}
+
+type EventRegistrationFilterRequest struct {
+	OccurrenceDate string `form:"occurrenceDate" validate:"required"`
+	Status         string `form:"status,omitempty"`
+	Search         string `form:"search,omitempty"`
+	Page           int    `form:"page,omitempty"`
+	Limit          int    `form:"limit,omitempty"`
+}
+
+type VisitorSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	PhoneNumber string    `json:"phoneNumber"`
+	IGUsername  string    `json:"igUsername"`
+}
+
+type EventRegistrationResponse struct {
+	ID               uuid.UUID       `json:"id"`
+	EventID          uuid.UUID       `json:"eventId"`
+	EventTitle       string          `json:"eventTitle,omitempty"`
+	OccurrenceDate   time.Time       `json:"occurrenceDate"`
+	PersonID         *uuid.UUID      `json:"personId,omitempty"`
+	Person           *PersonSummary  `json:"person,omitempty"`
+	VisitorID        *uuid.UUID      `json:"visitorId,omitempty"`
+	Visitor          *VisitorSummary `json:"visitor,omitempty"`
+	Status           string          `json:"status"`
+	WaitlistPosition int             `json:"waitlistPosition,omitempty"` // 1-based, only while waitlisted
+	Notes            string          `json:"notes"`
+	PromotedAt       *time.Time      `json:"promotedAt"`
+	CancelledAt      *time.Time      `json:"cancelledAt"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+}
+
+// Seat usage of one occurrence
+type OccurrenceCapacityResponse struct {
+	EventID        uuid.UUID `json:"eventId"`
+	OccurrenceDate time.Time `json:"occurrenceDate"`
+	Capacity       int       `json:"capacity"`
+	Registered     int       `json:"registered"`
+	Waitlisted     int       `json:"waitlisted"`
+	Available      int       `json:"available"`
+}
+
+type EventRegistrationListResponse struct {
+	Capacity      OccurrenceCapacityResponse  `json:"capacity"`
+	Registrations []EventRegistrationResponse `json:"registrations"`
+	TotalCount    int                         `json:"totalCount"`
+	Page          int                         `json:"page"`
+	Limit         int                         `json:"limit"`
+}
+
+type CancelEventRegistrationResponse struct {
+	Registration EventRegistrationResponse  `json:"registration"`
+	Promoted     *EventRegistrationResponse `json:"promoted"` // waitlisted registration that took the freed seat
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Run-sheet (rundown) DTOs
+
+// RundownSegmentRequest is one timed segment of a run-sheet
+type RundownSegmentRequest struct {
+	Title       string     `json:"title" binding:"required,max=255"`
+	Type        string     `json:"type,omitempty" binding:"omitempty,max=50"` // e.g. opening, worship, announcements, sermon, offering
+	StartOffset int        `json:"startOffset" binding:"min=0"`               // minutes after the occurrence starts
+	Duration    int        `json:"duration" binding:"required,min=1"`         // minutes
+	PersonID    *uuid.UUID `json:"personId,omitempty"`                        // responsible person
+	Notes       string     `json:"notes,omitempty"`
+}
+
+// SetEventRundownRequest replaces the run-sheet of one occurrence; segments are ordered by start offset
+// and an empty list clears it
+type SetEventRundownRequest struct {
+	OccurrenceDate string                  `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD format, in the event's timezone
+	Segments       []RundownSegmentRequest `json:"segments" binding:"dive"`
+}
+
+type EventRundownFilterRequest struct {
+	OccurrenceDate string `form:"occurrenceDate" binding:"required"` // YYYY-MM-DD format
+}
+
+type RundownSegmentResponse struct {
+	ID          uuid.UUID      `json:"id"`
+	Position    int            `json:"position"`
+	Title       string         `json:"title"`
+	Type        string         `json:"type"`
+	StartOffset int            `json:"startOffset"`
+	Duration    int            `json:"duration"`
+	StartsAt    *time.Time     `json:"startsAt,omitempty"` // in the event's timezone, only on occurrence run-sheets
+	EndsAt      *time.Time     `json:"endsAt,omitempty"`
+	Person      *PersonSummary `json:"person,omitempty"`
+	Notes       string         `json:"notes"`
+}
+
+type EventRundownResponse struct {
+	EventID        uuid.UUID                `json:"eventId"`
+	OccurrenceDate string                   `json:"occurrenceDate"`
+	StartDatetime  time.Time                `json:"startDatetime"`
+	EndDatetime    time.Time                `json:"endDatetime"`
+	Segments       []RundownSegmentResponse `json:"segments"`
+}
+
+// Run-sheet template
+
+// SetRundownTemplateRequest replaces the run-sheet the occurrences of the event are planned from
+type SetRundownTemplateRequest struct {
+	Segments []RundownSegmentRequest `json:"segments" binding:"dive"`
+}
+
+// SaveRundownTemplateRequest makes the run-sheet of an occurrence the template
+type SaveRundownTemplateRequest struct {
+	OccurrenceDate string `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD format
+}
+
+// ApplyRundownTemplateRequest copies the template onto the occurrences between two dates that have not
+// started yet. Occurrences with a run-sheet keep it unless Overwrite is set.
+type ApplyRundownTemplateRequest struct {
+	StartDate string `json:"startDate" binding:"required"` // YYYY-MM-DD format
+	EndDate   string `json:"endDate" binding:"required"`   // YYYY-MM-DD format
+	Overwrite bool   `json:"overwrite,omitempty"`
+}
+
+type RundownTemplateResponse struct {
+	EventID  uuid.UUID                `json:"eventId"`
+	Segments []RundownSegmentResponse `json:"segments"`
+}
+
+type ApplyRundownTemplateResponse struct {
+	EventID uuid.UUID           `json:"eventId"`
+	Applied []string            `json:"applied"` // occurrence dates given the template
+	Skipped []SkippedOccurrence `json:"skipped"`
+}
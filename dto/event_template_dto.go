@@ -0,0 +1,218 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event template DTOs
+
+// EventTemplatePICRequest is a PIC role events created from the template get. Permissions left out
+// are taken from the predefined role.
+type EventTemplatePICRequest struct {
+	RoleID      *uuid.UUID `json:"roleId,omitempty"`
+	Role        string     `json:"role,omitempty" binding:"required_without=RoleID,max=100"`
+	Description string     `json:"description,omitempty"`
+	PersonID    *uuid.UUID `json:"personId,omitempty"` // default person, can be replaced when instantiating
+	IsPrimary   bool       `json:"isPrimary"`
+	StartOffset int        `json:"startOffset"`         // days after the event's first date the assignment starts
+	EndOffset   *int       `json:"endOffset,omitempty"` // days after the first date it ends, empty for ongoing
+
+	CanEdit           *bool `json:"canEdit,omitempty"`
+	CanDelete         *bool `json:"canDelete,omitempty"`
+	CanAssignPIC      *bool `json:"canAssignPIC,omitempty"`
+	NotifyOnChanges   *bool `json:"notifyOnChanges,omitempty"`
+	NotifyOnReminders *bool `json:"notifyOnReminders,omitempty"`
+}
+
+type CreateEventTemplateRequest struct {
+	Name          string     `json:"name" binding:"required,max=255"`
+	Title         string     `json:"title" binding:"required,max=255"`
+	BannerImage   string     `json:"bannerImage,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Capacity      int        `json:"capacity,omitempty" binding:"omitempty,min=0"`
+	Type          string     `json:"type" binding:"required,oneof=event ibadah spiritual_journey"`
+	EventLocation string     `json:"eventLocation,omitempty" binding:"omitempty,max=255"`
+	VenueID       *uuid.UUID `json:"venueId,omitempty"`
+	ChurchID      *uuid.UUID `json:"churchId,omitempty"`
+	StartTime     string     `json:"startTime" binding:"required"` // HH:MM
+	EndTime       string     `json:"endTime" binding:"required"`   // HH:MM
+	AllDay        bool       `json:"allDay"`
+	Timezone      string     `json:"timezone" binding:"required"`
+	IsPublic      bool       `json:"isPublic"`
+
+	// Expected participant counts for planning
+	ExpectedParticipants *int `json:"expectedParticipants,omitempty"`
+	ExpectedAdults       *int `json:"expectedAdults,omitempty"`
+	ExpectedYouth        *int `json:"expectedYouth,omitempty"`
+	ExpectedKids         *int `json:"expectedKids,omitempty"`
+
+	// RFC 5545 RRULE without UNTIL; a series ending on a date sets UntilOffset, in days after the first date
+	RRule       string `json:"rrule,omitempty"`
+	UntilOffset *int   `json:"untilOffset,omitempty" binding:"omitempty,min=0"`
+
+	PICs []EventTemplatePICRequest `json:"pics,omitempty" binding:"dive"`
+}
+
+// UpdateEventTemplateRequest changes the fields that are set; PICs replaces every PIC role when set
+type UpdateEventTemplateRequest struct {
+	Name          *string    `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Title         *string    `json:"title,omitempty" binding:"omitempty,min=1,max=255"`
+	BannerImage   *string    `json:"bannerImage,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	Capacity      *int       `json:"capacity,omitempty" binding:"omitempty,min=0"`
+	Type          *string    `json:"type,omitempty" binding:"omitempty,oneof=event ibadah spiritual_journey"`
+	EventLocation *string    `json:"eventLocation,omitempty" binding:"omitempty,max=255"`
+	VenueID       *uuid.UUID `json:"venueId,omitempty"`  // the nil UUID removes the venue
+	ChurchID      *uuid.UUID `json:"churchId,omitempty"` // the nil UUID removes the church
+	StartTime     *string    `json:"startTime,omitempty"`
+	EndTime       *string    `json:"endTime,omitempty"`
+	AllDay        *bool      `json:"allDay,omitempty"`
+	Timezone      *string    `json:"timezone,omitempty"`
+	IsPublic      *bool      `json:"isPublic,omitempty"`
+
+	ExpectedParticipants *int `json:"expectedParticipants,omitempty"`
+	ExpectedAdults       *int `json:"expectedAdults,omitempty"`
+	ExpectedYouth        *int `json:"expectedYouth,omitempty"`
+	ExpectedKids         *int `json:"expectedKids,omitempty"`
+
+	RRule       *string `json:"rrule,omitempty"`       // empty string removes the recurrence
+	UntilOffset *int    `json:"untilOffset,omitempty"` // negative removes the end date
+
+	PICs *[]EventTemplatePICRequest `json:"pics,omitempty" binding:"omitempty,dive"`
+}
+
+// SaveEventTemplateRequest saves an event as a template. With an occurrence date only that occurrence's
+// setlist and run-sheet are kept; without one, every occurrence of a series with an end is kept.
+type SaveEventTemplateRequest struct {
+	Name           string `json:"name" binding:"required,max=255"`
+	OccurrenceDate string `json:"occurrenceDate,omitempty"` // YYYY-MM-DD format
+}
+
+type EventTemplateFilterRequest struct {
+	Type     string     `form:"type,omitempty"`
+	Search   string     `form:"search,omitempty"`
+	ChurchID *uuid.UUID `form:"churchId,omitempty"`
+	Page     int        `form:"page,omitempty"`
+	Limit    int        `form:"limit,omitempty"`
+
+	// Set by the controller to limit the list to templates of the caller's churches
+	Viewer *EventViewer `form:"-"`
+}
+
+// TemplatePICAssignment fills a PIC role of the template for the new event
+type TemplatePICAssignment struct {
+	Role     string    `json:"role" binding:"required"`
+	PersonID uuid.UUID `json:"personId" binding:"required"`
+}
+
+// InstantiateEventTemplateRequest creates an event from a template starting on StartDate. Everything
+// dated in the template moves with it; a new StartTime keeps the event's length.
+type InstantiateEventTemplateRequest struct {
+	StartDate          string                  `json:"startDate" binding:"required"` // YYYY-MM-DD format
+	StartTime          string                  `json:"startTime,omitempty"`          // HH:MM, the template's by default
+	Title              string                  `json:"title,omitempty" binding:"omitempty,max=255"`
+	VenueID            *uuid.UUID              `json:"venueId,omitempty"`
+	AllowVenueConflict bool                    `json:"allowVenueConflict,omitempty"`
+	PICs               []TemplatePICAssignment `json:"pics,omitempty" binding:"dive"`
+
+	// Set by the controller to the caller's church, owning the new event when neither the template nor
+	// the request gives it a church or a venue
+	ChurchID *uuid.UUID `json:"-"`
+}
+
+// CloneEventRequest copies an event to new dates the way saving it as a template and instantiating would
+type CloneEventRequest struct {
+	InstantiateEventTemplateRequest
+	OccurrenceDate string `json:"occurrenceDate,omitempty"` // occurrence whose setlist and run-sheet are copied
+}
+
+type EventTemplatePICResponse struct {
+	ID                uuid.UUID      `json:"id"`
+	RoleID            *uuid.UUID     `json:"roleId,omitempty"`
+	Role              string         `json:"role"`
+	Description       string         `json:"description"`
+	Person            *PersonSummary `json:"person,omitempty"`
+	IsPrimary         bool           `json:"isPrimary"`
+	StartOffset       int            `json:"startOffset"`
+	EndOffset         *int           `json:"endOffset,omitempty"`
+	CanEdit           bool           `json:"canEdit"`
+	CanDelete         bool           `json:"canDelete"`
+	CanAssignPIC      bool           `json:"canAssignPIC"`
+	NotifyOnChanges   bool           `json:"notifyOnChanges"`
+	NotifyOnReminders bool           `json:"notifyOnReminders"`
+}
+
+type EventTemplateSetlistItemResponse struct {
+	DayOffset    int            `json:"dayOffset"`
+	Position     int            `json:"position"`
+	LaguID       uuid.UUID      `json:"laguId"`
+	Judul        string         `json:"judul"`
+	Artis        string         `json:"artis"`
+	PerformedKey string         `json:"performedKey"`
+	SongLeader   *PersonSummary `json:"songLeader,omitempty"`
+	Notes        string         `json:"notes"`
+}
+
+type EventTemplateRundownSegmentResponse struct {
+	DayOffset   *int           `json:"dayOffset"` // empty for the event's run-sheet template
+	Position    int            `json:"position"`
+	Title       string         `json:"title"`
+	Type        string         `json:"type"`
+	StartOffset int            `json:"startOffset"`
+	Duration    int            `json:"duration"`
+	Person      *PersonSummary `json:"person,omitempty"`
+	Notes       string         `json:"notes"`
+}
+
+type EventTemplateResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	SourceEventID *uuid.UUID `json:"sourceEventId,omitempty"`
+
+	Title         string     `json:"title"`
+	BannerImage   string     `json:"bannerImage,omitempty"`
+	Description   string     `json:"description"`
+	Capacity      int        `json:"capacity"`
+	Type          string     `json:"type"`
+	EventLocation string     `json:"eventLocation"`
+	VenueID       *uuid.UUID `json:"venueId,omitempty"`
+	VenueName     string     `json:"venueName,omitempty"`
+	ChurchID      *uuid.UUID `json:"churchId,omitempty"`
+	StartTime     string     `json:"startTime"`
+	EndTime       string     `json:"endTime"`
+	AllDay        bool       `json:"allDay"`
+	Timezone      string     `json:"timezone"`
+	IsPublic      bool       `json:"isPublic"`
+
+	ExpectedParticipants int `json:"expectedParticipants"`
+	ExpectedAdults       int `json:"expectedAdults"`
+	ExpectedYouth        int `json:"expectedYouth"`
+	ExpectedKids         int `json:"expectedKids"`
+
+	RRule       string `json:"rrule,omitempty"`
+	UntilOffset *int   `json:"untilOffset,omitempty"`
+
+	PICs            []EventTemplatePICResponse            `json:"pics,omitempty"`
+	SetlistItems    []EventTemplateSetlistItemResponse    `json:"setlistItems,omitempty"`
+	RundownSegments []EventTemplateRundownSegmentResponse `json:"rundownSegments,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type EventTemplateListResponse struct {
+	Templates  []EventTemplateResponse `json:"templates"`
+	TotalCount int                     `json:"totalCount"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+}
+
+// InstantiateEventTemplateResponse is the created event, the PIC roles nobody was given and the
+// days of the template the new event does not occur on
+type InstantiateEventTemplateResponse struct {
+	Event         *EventResponse      `json:"event"`
+	UnfilledRoles []string            `json:"unfilledRoles"`
+	Skipped       []SkippedOccurrence `json:"skipped"`
+}
@@ -0,0 +1,252 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Song (Lagu) library DTOs
+
+type CreateLaguRequest struct {
+	Judul       string      `json:"judul" binding:"required,min=1,max=255"`
+	Artis       string      `json:"artis,omitempty" binding:"omitempty,max=255"`
+	YoutubeLink string      `json:"youtubeLink,omitempty" binding:"omitempty,url,max=255"`
+	Genre       string      `json:"genre,omitempty" binding:"omitempty,max=100"`
+	Lirik       string      `json:"lirik" binding:"required_without=Chart"`        // taken from the chart when omitted
+	Chart       string      `json:"chart,omitempty"`                               // ChordPro, in NadaDasar
+	NadaDasar   string      `json:"nadaDasar,omitempty" binding:"omitempty,max=4"` // e.g. "G", "Bb", "F#m"
+	TahunRilis  int         `json:"tahunRilis,omitempty"`
+	TagIDs      []uuid.UUID `json:"tagIds,omitempty"`
+}
+
+type UpdateLaguRequest struct {
+	Judul       *string      `json:"judul,omitempty" binding:"omitempty,min=1,max=255"`
+	Artis       *string      `json:"artis,omitempty" binding:"omitempty,max=255"`
+	YoutubeLink *string      `json:"youtubeLink,omitempty" binding:"omitempty,max=255"`
+	Genre       *string      `json:"genre,omitempty" binding:"omitempty,max=100"`
+	Lirik       *string      `json:"lirik,omitempty" binding:"omitempty,min=1"`
+	Chart       *string      `json:"chart,omitempty"` // also replaces the lyrics unless lirik is given
+	NadaDasar   *string      `json:"nadaDasar,omitempty" binding:"omitempty,max=4"`
+	TahunRilis  *int         `json:"tahunRilis,omitempty"`
+	TagIDs      *[]uuid.UUID `json:"tagIds,omitempty"` // replaces the song's tags
+}
+
+// LaguFilterRequest searches title, artist, genre, tags and lyrics, ignoring case and accents
+type LaguFilterRequest struct {
+	Search string `form:"search,omitempty"`
+	Genre  string `form:"genre,omitempty"`
+	TagID  string `form:"tagId,omitempty"`
+	Page   int    `form:"page,omitempty"`
+	Limit  int    `form:"limit,omitempty"`
+}
+
+type LaguDetailResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	Judul       string            `json:"judul"`
+	Artis       string            `json:"artis"`
+	YoutubeLink string            `json:"youtubeLink"`
+	Genre       string            `json:"genre"`
+	Lirik       string            `json:"lirik,omitempty"`
+	Chart       string            `json:"chart,omitempty"`
+	NadaDasar   string            `json:"nadaDasar"`
+	TahunRilis  int               `json:"tahunRilis"`
+	Tags        []TagLaguResponse `json:"tags"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+type LaguListResponse struct {
+	Lagu       []LaguDetailResponse `json:"lagu"`
+	TotalCount int                  `json:"totalCount"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+}
+
+// Chord charts
+
+// LaguChartRequest asks for the chart in another key. Without Key the chart comes back as it is stored.
+type LaguChartRequest struct {
+	Key         string `form:"key,omitempty"`                                              // e.g. "D", "Eb" or "Bm"
+	From        string `form:"from,omitempty"`                                             // key the chart is in when the song has no NadaDasar
+	Accidentals string `form:"accidentals,omitempty" binding:"omitempty,oneof=sharp flat"` // default follows the key signature
+}
+
+type LaguChartResponse struct {
+	LaguID      uuid.UUID `json:"laguId"`
+	Judul       string    `json:"judul"`
+	OriginalKey string    `json:"originalKey"`
+	Key         string    `json:"key"`
+	Semitones   int       `json:"semitones"`   // 0 to 11 up from the original key
+	Accidentals string    `json:"accidentals"` // sharp or flat
+	Chart       string    `json:"chart"`
+}
+
+// ImportChordProRequest sets the fields a ChordPro file has no directive for
+type ImportChordProRequest struct {
+	Genre       string `form:"genre,omitempty" binding:"omitempty,max=100"`
+	YoutubeLink string `form:"youtubeLink,omitempty" binding:"omitempty,url,max=255"`
+}
+
+// Tags
+
+type CreateTagLaguRequest struct {
+	Nama string `json:"nama" binding:"required,min=1,max=255"`
+}
+
+type UpdateTagLaguRequest struct {
+	Nama string `json:"nama" binding:"required,min=1,max=255"`
+}
+
+type TagLaguFilterRequest struct {
+	Search string `form:"search,omitempty"`
+	Page   int    `form:"page,omitempty"`
+	Limit  int    `form:"limit,omitempty"`
+}
+
+type TagLaguResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Nama string    `json:"nama"`
+}
+
+type TagLaguListResponse struct {
+	Tags       []TagLaguResponse `json:"tags"`
+	TotalCount int               `json:"totalCount"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+}
+
+// Occurrence setlists
+
+// SetlistItemRequest is one song of a setlist; the performed key defaults to the song's NadaDasar
+type SetlistItemRequest struct {
+	LaguID       uuid.UUID  `json:"laguId" binding:"required"`
+	PerformedKey string     `json:"performedKey,omitempty" binding:"omitempty,max=4"` // e.g. "Bb"
+	SongLeaderID *uuid.UUID `json:"songLeaderId,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+}
+
+// SetEventSetlistRequest replaces the setlist of one occurrence; the songs are sung in the given order
+// and an empty list clears it
+type SetEventSetlistRequest struct {
+	OccurrenceDate string               `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD format, in the event's timezone
+	Items          []SetlistItemRequest `json:"items" binding:"dive"`
+}
+
+type EventSetlistFilterRequest struct {
+	OccurrenceDate string `form:"occurrenceDate" binding:"required"` // YYYY-MM-DD format
+}
+
+type SetlistItemResponse struct {
+	ID           uuid.UUID      `json:"id"`
+	Position     int            `json:"position"`
+	LaguID       uuid.UUID      `json:"laguId"`
+	Judul        string         `json:"judul"`
+	Artis        string         `json:"artis"`
+	NadaDasar    string         `json:"nadaDasar"`
+	PerformedKey string         `json:"performedKey"` // NadaDasar when no other key was set
+	SongLeader   *PersonSummary `json:"songLeader,omitempty"`
+	Notes        string         `json:"notes"`
+}
+
+type EventSetlistResponse struct {
+	EventID        uuid.UUID             `json:"eventId"`
+	OccurrenceDate string                `json:"occurrenceDate"`
+	Items          []SetlistItemResponse `json:"items"`
+}
+
+// Setlist history
+
+// LaguHistoryRequest pages through the times a song was sung, up to today unless EndDate is given
+type LaguHistoryRequest struct {
+	ChurchID  string `form:"churchId,omitempty"`
+	StartDate string `form:"startDate,omitempty"` // YYYY-MM-DD format
+	EndDate   string `form:"endDate,omitempty"`   // YYYY-MM-DD format
+	Page      int    `form:"page,omitempty"`
+	Limit     int    `form:"limit,omitempty"`
+}
+
+type LaguUsageResponse struct {
+	EventID        uuid.UUID      `json:"eventId"`
+	EventTitle     string         `json:"eventTitle"`
+	OccurrenceDate string         `json:"occurrenceDate"`
+	Position       int            `json:"position"`
+	PerformedKey   string         `json:"performedKey"`
+	SongLeader     *PersonSummary `json:"songLeader,omitempty"`
+	Notes          string         `json:"notes"`
+}
+
+type LaguHistoryResponse struct {
+	LaguID     uuid.UUID           `json:"laguId"`
+	Judul      string              `json:"judul"`
+	LastSungOn *string             `json:"lastSungOn"` // null when the song was never sung in the range
+	TimesSung  int                 `json:"timesSung"`
+	Usage      []LaguUsageResponse `json:"usage"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+}
+
+// RecentLaguRequest lists the songs sung in the last Weeks weeks up to today
+type RecentLaguRequest struct {
+	ChurchID string `form:"churchId,omitempty"`
+	Weeks    int    `form:"weeks,omitempty" binding:"omitempty,min=1,max=104"` // default 6
+}
+
+type RecentLaguResponse struct {
+	LaguID     uuid.UUID `json:"laguId"`
+	Judul      string    `json:"judul"`
+	Artis      string    `json:"artis"`
+	TimesSung  int       `json:"timesSung"`
+	LastSungOn string    `json:"lastSungOn"`
+}
+
+type RecentLaguListResponse struct {
+	ChurchID  *uuid.UUID           `json:"churchId,omitempty"`
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+	Lagu      []RecentLaguResponse `json:"lagu"`
+}
+
+// Song usage report
+
+// SongUsageReportRequest reports the songs sung between two dates, split per church and, if Period is
+// given, per month, quarter or year
+type SongUsageReportRequest struct {
+	ChurchID  string `form:"churchId,omitempty"`
+	StartDate string `form:"startDate" binding:"required"`                                      // YYYY-MM-DD format
+	EndDate   string `form:"endDate" binding:"required"`                                        // YYYY-MM-DD format
+	Period    string `form:"period,omitempty" binding:"omitempty,oneof=all month quarter year"` // default all
+}
+
+// SongUsageReportRow is how often one song was sung at one church in one period. Every occurrence
+// of a recurring event counts; a reprise in the same occurrence counts once.
+type SongUsageReportRow struct {
+	ChurchID    *uuid.UUID `json:"churchId"` // null for events of no church
+	ChurchName  string     `json:"churchName"`
+	PeriodStart string     `json:"periodStart"`
+	PeriodEnd   string     `json:"periodEnd"`
+	LaguID      uuid.UUID  `json:"laguId"`
+	Judul       string     `json:"judul"`
+	Artis       string     `json:"artis"`
+	TimesUsed   int        `json:"timesUsed"`
+	FirstUsedOn string     `json:"firstUsedOn"`
+	LastUsedOn  string     `json:"lastUsedOn"`
+	Dates       []string   `json:"dates"` // one per occurrence, so a date repeats when sung at two services that day
+}
+
+type SongUsageReportResponse struct {
+	ChurchID  *uuid.UUID           `json:"churchId,omitempty"`
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+	Period    string               `json:"period"`
+	Rows      []SongUsageReportRow `json:"rows"`
+}
+
+// Setlist export
+
+// SetlistExportRequest exports the setlist of one occurrence, in order, with the lyrics split into slides
+type SetlistExportRequest struct {
+	OccurrenceDate string `form:"occurrenceDate" binding:"required"`                               // YYYY-MM-DD format
+	Format         string `form:"format,omitempty" binding:"omitempty,oneof=text openlyrics html"` // default text
+	SlideLines     int    `form:"slideLines,omitempty" binding:"omitempty,min=1,max=12"`           // lines per slide, default 4
+}
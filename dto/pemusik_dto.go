@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Musician (pemusik) scheduling DTOs
+
+// Instruments every occurrence needs
+
+type KebutuhanPemusikItem struct {
+	AlatMusik string `json:"alatMusik" binding:"required,max=255"`
+	Jumlah    int    `json:"jumlah" binding:"required,min=1"` // players needed
+}
+
+// SetKebutuhanPemusikRequest replaces the instruments the event needs at every occurrence
+type SetKebutuhanPemusikRequest struct {
+	Items []KebutuhanPemusikItem `json:"items" binding:"dive"`
+}
+
+type KebutuhanPemusikResponse struct {
+	EventID uuid.UUID              `json:"eventId"`
+	Items   []KebutuhanPemusikItem `json:"items"`
+}
+
+// Availability
+
+// SubmitKetersediaanRequest records whether a musician can play at an occurrence and on what.
+// A later submission for the same occurrence replaces the earlier one.
+type SubmitKetersediaanRequest struct {
+	OccurrenceDate string    `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD format, in the event's timezone
+	PersonID       uuid.UUID `json:"personId" binding:"required"`
+	Ketersediaan   string    `json:"ketersediaan" binding:"required,oneof=tersedia mungkin tidak_tersedia"`
+	AlatMusik      []string  `json:"alatMusik,omitempty"` // required unless tidak_tersedia
+	Notes          string    `json:"notes,omitempty"`
+}
+
+// PemusikRangeRequest selects the occurrences between two dates, both included
+type PemusikRangeRequest struct {
+	StartDate string `form:"startDate" json:"startDate" binding:"required"` // YYYY-MM-DD format
+	EndDate   string `form:"endDate" json:"endDate" binding:"required"`     // YYYY-MM-DD format
+}
+
+type KetersediaanPemusikResponse struct {
+	ID             uuid.UUID     `json:"id"`
+	EventID        uuid.UUID     `json:"eventId"`
+	OccurrenceDate string        `json:"occurrenceDate"`
+	PersonID       uuid.UUID     `json:"personId"`
+	Person         PersonSummary `json:"person"`
+	Ketersediaan   string        `json:"ketersediaan"`
+	AlatMusik      []string      `json:"alatMusik"`
+	IsTerjadwal    bool          `json:"isTerjadwal"`
+	Notes          string        `json:"notes"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// Roster
+
+// AddJadwalPemusikRequest puts a musician on the roster of an occurrence by hand
+type AddJadwalPemusikRequest struct {
+	OccurrenceDate string    `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD format
+	PersonID       uuid.UUID `json:"personId" binding:"required"`
+	AlatMusik      string    `json:"alatMusik" binding:"required,max=255"`
+	Notes          string    `json:"notes,omitempty"`
+}
+
+type UpdateJadwalPemusikRequest struct {
+	PersonID  *uuid.UUID `json:"personId,omitempty"`
+	AlatMusik *string    `json:"alatMusik,omitempty" binding:"omitempty,min=1,max=255"`
+	Notes     *string    `json:"notes,omitempty"`
+}
+
+type JadwalPemusikResponse struct {
+	ID             uuid.UUID     `json:"id"`
+	EventID        uuid.UUID     `json:"eventId"`
+	OccurrenceDate string        `json:"occurrenceDate"`
+	PersonID       uuid.UUID     `json:"personId"`
+	Person         PersonSummary `json:"person"`
+	AlatMusik      string        `json:"alatMusik"`
+	Status         string        `json:"status"`   // draft, published
+	IsManual       bool          `json:"isManual"` // kept when the roster is generated again
+	Notes          string        `json:"notes"`
+	PublishedAt    *time.Time    `json:"publishedAt,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// UnfilledAlatMusik is an instrument the roster could not find enough available musicians for
+type UnfilledAlatMusik struct {
+	OccurrenceDate string `json:"occurrenceDate"`
+	AlatMusik      string `json:"alatMusik"`
+	Missing        int    `json:"missing"`
+}
+
+type SkippedOccurrence struct {
+	OccurrenceDate string `json:"occurrenceDate"`
+	Reason         string `json:"reason"`
+}
+
+type GenerateJadwalPemusikResponse struct {
+	EventID  uuid.UUID               `json:"eventId"`
+	Jadwal   []JadwalPemusikResponse `json:"jadwal"`
+	Unfilled []UnfilledAlatMusik     `json:"unfilled"`
+	Skipped  []SkippedOccurrence     `json:"skipped"`
+}
+
+type PublishJadwalPemusikResponse struct {
+	EventID   uuid.UUID `json:"eventId"`
+	Published int       `json:"published"` // roster places published
+	Notified  int       `json:"notified"`  // musicians told about their places
+}
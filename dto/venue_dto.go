@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateVenueRequest struct {
+	ChurchID    uuid.UUID `json:"churchId" binding:"required"`
+	Name        string    `json:"name" binding:"required,max=255"`
+	Description string    `json:"description,omitempty"`
+	Capacity    int       `json:"capacity,omitempty" binding:"omitempty,min=0"`
+	IsActive    *bool     `json:"isActive,omitempty"`
+}
+
+type UpdateVenueRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Description *string `json:"description,omitempty"`
+	Capacity    *int    `json:"capacity,omitempty" binding:"omitempty,min=0"`
+	IsActive    *bool   `json:"isActive,omitempty"`
+}
+
+type VenueFilterRequest struct {
+	ChurchID string `form:"churchId,omitempty"`
+	IsActive *bool  `form:"isActive,omitempty"`
+	Search   string `form:"search,omitempty"`
+	Page     int    `form:"page,omitempty"`
+	Limit    int    `form:"limit,omitempty"`
+}
+
+type VenueResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ChurchID    uuid.UUID `json:"churchId"`
+	ChurchName  string    `json:"churchName,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Capacity    int       `json:"capacity"`
+	IsActive    bool      `json:"isActive"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type VenueListResponse struct {
+	Venues     []VenueResponse `json:"venues"`
+	TotalCount int             `json:"totalCount"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+// VenueConflictResponse is an occurrence of another event that overlaps the event being saved
+type VenueConflictResponse struct {
+	EventID        uuid.UUID `json:"eventId"`
+	EventTitle     string    `json:"eventTitle"`
+	OccurrenceDate string    `json:"occurrenceDate"` // date of the conflicting event's occurrence
+	StartDatetime  time.Time `json:"startDatetime"`
+	EndDatetime    time.Time `json:"endDatetime"`
+	ConflictsWith  string    `json:"conflictsWith"` // date of the occurrence of the event being saved
+}
@@ -16,6 +16,8 @@ type Event struct {
 
 	EventDate        time.Time       `gorm:"type:datetime;not null"`
 	EventLocation    string          `gorm:"type:varchar(255);not null"`
+	VenueID          *uuid.UUID      `gorm:"type:char(36);index"` // booked venue; EventLocation stays as free text
+	Venue            *Venue          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	StartDatetime    time.Time       `gorm:"not null"`
 	EndDatetime      time.Time       `gorm:"not null"`
 	AllDay           bool            `gorm:"default:false"`
@@ -23,6 +25,11 @@ type Event struct {
 	RecurrenceRuleID *uuid.UUID      `gorm:"type:char(36);index"`
 	RecurrenceRule   *RecurrenceRule `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 
+	// The church that owns the event; events shared with other campuses list them in Churches
+	ChurchID *uuid.UUID `gorm:"type:char(36);index"`
+	Church   *Church    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Churches []Church   `gorm:"many2many:event_churches;"`
+
 	IsPublic              bool                 `gorm:"default:false"`
 	DiscipleshipJourneyID *uuid.UUID           `gorm:"type:char(36);index"`
 	DiscipleshipJourney   *DiscipleshipJourney `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
@@ -53,6 +60,7 @@ type RecurrenceRule struct {
 	ByYearDay  string     `gorm:"type:text"`                    // JSON string: day of year (1-366)
 	Count      *int       `gorm:""`                             // optional: limit total occurrences
 	Until      *time.Time `gorm:""`                             // optional: end date for occurrences
+	RDates     string     `gorm:"type:text"`                    // JSON string: ["2025-04-17","2025-04-18T19:00"], extra dates (RDATE)
 
 	Timestamp
 }
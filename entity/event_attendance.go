@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventAttendance is an individual check-in of a person or a visitor at a single occurrence of an event
+type EventAttendance struct {
+	ID             uuid.UUID          `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID          `gorm:"type:char(36);not null;index:idx_event_attendance_occurrence;uniqueIndex:idx_event_attendance_person;uniqueIndex:idx_event_attendance_visitor"`
+	Event          Event              `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time          `gorm:"type:date;not null;index:idx_event_attendance_occurrence;uniqueIndex:idx_event_attendance_person;uniqueIndex:idx_event_attendance_visitor"` // occurrence date in the event's timezone
+	PersonID       *uuid.UUID         `gorm:"type:char(36);uniqueIndex:idx_event_attendance_person"`
+	Person         *Person            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	VisitorID      *uuid.UUID         `gorm:"type:char(36);uniqueIndex:idx_event_attendance_visitor"`
+	Visitor        *Visitor           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:VisitorID"`
+	RegistrationID *uuid.UUID         `gorm:"type:char(36);index"` // RSVP the attendee checked in against, if any
+	Registration   *EventRegistration `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:RegistrationID"`
+
+	AgeGroup      string     `gorm:"type:varchar(10);not null"` // adult, youth, kid
+	Method        string     `gorm:"type:varchar(20);not null;default:'manual'"`
+	CheckedInAt   time.Time  `gorm:"not null"`
+	CheckedInByID *uuid.UUID `gorm:"type:char(36)"` // person who recorded the check-in
+	Notes         string     `gorm:"type:text"`
+
+	Timestamp
+}
+
+func (ea *EventAttendance) BeforeCreate(tx *gorm.DB) error {
+	if ea.ID == uuid.Nil {
+		ea.ID = uuid.New()
+	}
+	return nil
+}
+
+// EventHeadcount holds the anonymous attendance of a single occurrence, counted by age group
+type EventHeadcount struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_headcount_occurrence"`
+	Event          Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time  `gorm:"type:date;not null;uniqueIndex:idx_event_headcount_occurrence"`
+	Adults         int        `gorm:"not null;default:0"`
+	Youth          int        `gorm:"not null;default:0"`
+	Kids           int        `gorm:"not null;default:0"`
+	Notes          string     `gorm:"type:text"`
+	RecordedByID   *uuid.UUID `gorm:"type:char(36)"`
+
+	Timestamp
+}
+
+func (eh *EventHeadcount) BeforeCreate(tx *gorm.DB) error {
+	if eh.ID == uuid.Nil {
+		eh.ID = uuid.New()
+	}
+	return nil
+}
+
+// EventCheckInToken records an issued QR check-in token so that each token can be redeemed only once
+type EventCheckInToken struct {
+	ID             uuid.UUID        `gorm:"type:char(36);primary_key"` // the token's jti
+	EventID        uuid.UUID        `gorm:"type:char(36);not null;index:idx_event_check_in_token_occurrence"`
+	Event          Event            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time        `gorm:"type:date;not null;index:idx_event_check_in_token_occurrence"`
+	PersonID       uuid.UUID        `gorm:"type:char(36);not null;index"`
+	Person         Person           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	ExpiresAt      time.Time        `gorm:"not null"`
+	UsedAt         *time.Time       `gorm:""`
+	AttendanceID   *uuid.UUID       `gorm:"type:char(36)"`
+	Attendance     *EventAttendance `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:AttendanceID"`
+
+	Timestamp
+}
+
+func (ect *EventCheckInToken) BeforeCreate(tx *gorm.DB) error {
+	if ect.ID == uuid.Nil {
+		ect.ID = uuid.New()
+	}
+	return nil
+}
+
+// Attendance age groups, matching Event.ExpectedAdults/ExpectedYouth/ExpectedKids
+const (
+	AgeGroupAdult = "adult"
+	AgeGroupYouth = "youth"
+	AgeGroupKid   = "kid"
+)
+
+// Age limits used to derive the age group of a person from their birth date
+const (
+	YouthMinAge = 13
+	AdultMinAge = 18
+)
+
+// Check-in methods
+const (
+	CheckInMethodManual = "manual"
+	CheckInMethodQRCode = "qr_code"
+)
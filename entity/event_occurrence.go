@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventOccurrence is one materialized occurrence of an event, kept so range queries do not have to
+// expand every recurrence rule. Rows are derived from the event, its rule and its exceptions and are
+// replaced whenever those change; they are never edited on their own.
+type EventOccurrence struct {
+	ID             uuid.UUID `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_event_occurrence"`
+	Event          Event     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_event_occurrence;index"` // occurrence date in the event's timezone
+	StartsAt       time.Time `gorm:"not null;index"`                                            // absolute start instant, overrides applied
+	EndsAt         time.Time `gorm:"not null"`
+	IsException    bool      `gorm:"default:false"`
+	ExceptionNotes string    `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+func (eo *EventOccurrence) BeforeCreate(tx *gorm.DB) error {
+	if eo.ID == uuid.Nil {
+		eo.ID = uuid.New()
+	}
+	return nil
+}
@@ -67,6 +67,49 @@ type EventPICHistory struct {
 	Timestamp
 }
 
+// EventOccurrencePIC assigns a person to a role for a single occurrence of an event, on top of the
+// series-wide EventPIC assignments. Rows filled by a rotation keep its ID; manual overrides have none.
+type EventOccurrencePIC struct {
+	ID             uuid.UUID         `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID         `gorm:"type:char(36);not null;uniqueIndex:idx_event_occurrence_pic_role"`
+	Event          Event             `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time         `gorm:"type:date;not null;uniqueIndex:idx_event_occurrence_pic_role"` // in the event's timezone
+	Role           string            `gorm:"type:varchar(100);not null;uniqueIndex:idx_event_occurrence_pic_role"`
+	PersonID       uuid.UUID         `gorm:"type:char(36);not null;index"`
+	Person         Person            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	RotationID     *uuid.UUID        `gorm:"type:char(36);index"`
+	Rotation       *EventPICRotation `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:RotationID"`
+	Notes          string            `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+// EventPICRotation fills a role on the upcoming occurrences of a recurring event from a pool of people
+type EventPICRotation struct {
+	ID           uuid.UUID                `gorm:"type:char(36);primary_key"`
+	EventID      uuid.UUID                `gorm:"type:char(36);not null;index"`
+	Event        Event                    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	Role         string                   `gorm:"type:varchar(100);not null"`
+	Strategy     string                   `gorm:"type:varchar(20);default:'round_robin';not null"`
+	FillAhead    int                      `gorm:"default:4;not null"` // how many upcoming occurrences are kept filled
+	NextPosition int                      `gorm:"default:0;not null"` // member whose turn is next
+	IsActive     bool                     `gorm:"default:true;not null"`
+	Members      []EventPICRotationMember `gorm:"foreignKey:RotationID"`
+
+	Timestamp
+}
+
+// EventPICRotationMember is one person in the pool of a rotation, in turn order
+type EventPICRotationMember struct {
+	ID         uuid.UUID `gorm:"type:char(36);primary_key"`
+	RotationID uuid.UUID `gorm:"type:char(36);not null;index"`
+	PersonID   uuid.UUID `gorm:"type:char(36);not null"`
+	Person     Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	Position   int       `gorm:"not null"`
+
+	TimestampHardDelete
+}
+
 func (ep *EventPIC) BeforeCreate(tx *gorm.DB) error {
 	if ep.ID == uuid.Nil {
 		ep.ID = uuid.New()
@@ -91,6 +134,27 @@ func (eph *EventPICHistory) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (eop *EventOccurrencePIC) BeforeCreate(tx *gorm.DB) error {
+	if eop.ID == uuid.Nil {
+		eop.ID = uuid.New()
+	}
+	return nil
+}
+
+func (epr *EventPICRotation) BeforeCreate(tx *gorm.DB) error {
+	if epr.ID == uuid.Nil {
+		epr.ID = uuid.New()
+	}
+	return nil
+}
+
+func (eprm *EventPICRotationMember) BeforeCreate(tx *gorm.DB) error {
+	if eprm.ID == uuid.Nil {
+		eprm.ID = uuid.New()
+	}
+	return nil
+}
+
 // Business logic constants
 const (
 	EventPICActionAssigned           = "assigned"
@@ -104,4 +168,7 @@ const (
 	EventPICRoleTechnical  = "Technical PIC"
 	EventPICRoleLogistics  = "Logistics PIC"
 	EventPICRoleRegistration = "Registration PIC"
+
+	// Rotation strategies
+	EventPICRotationRoundRobin = "round_robin"
 )
\ No newline at end of file
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventRegistration is an RSVP of a person or a visitor for a single occurrence of an event
+type EventRegistration struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID  `gorm:"type:char(36);not null;index:idx_event_registration_occurrence"`
+	Event          Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time  `gorm:"type:date;not null;index:idx_event_registration_occurrence"` // occurrence date in the event's timezone
+	PersonID       *uuid.UUID `gorm:"type:char(36);index"`
+	Person         *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	VisitorID      *uuid.UUID `gorm:"type:char(36);index"`
+	Visitor        *Visitor   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:VisitorID"`
+
+	Status      string     `gorm:"type:varchar(20);not null;index"` // registered, waitlisted, cancelled
+	Notes       string     `gorm:"type:text"`
+	PromotedAt  *time.Time `gorm:""` // when the registration moved from the waitlist to registered
+	CancelledAt *time.Time `gorm:""`
+
+	Timestamp
+}
+
+func (er *EventRegistration) BeforeCreate(tx *gorm.DB) error {
+	if er.ID == uuid.Nil {
+		er.ID = uuid.New()
+	}
+	return nil
+}
+
+// Registration statuses
+const (
+	EventRegistrationStatusRegistered = "registered"
+	EventRegistrationStatusWaitlisted = "waitlisted"
+	EventRegistrationStatusCancelled  = "cancelled"
+)
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventReminderLog records a reminder sent to a PIC for one occurrence at one offset.
+// The unique index is what guarantees a reminder is never sent twice, also across restarts.
+type EventReminderLog struct {
+	ID              uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID         uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_reminder_log"`
+	Event           Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate  time.Time  `gorm:"type:date;not null;uniqueIndex:idx_event_reminder_log"` // occurrence date in the event's timezone
+	PersonID        uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_reminder_log"`
+	Person          Person     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PersonID"`
+	OffsetMinutes   int        `gorm:"not null;uniqueIndex:idx_event_reminder_log"` // how long before the start the reminder is due
+	OccurrenceStart time.Time  `gorm:"not null"`
+	NotificationID  *uuid.UUID `gorm:"type:char(36)"`
+	EmailSentAt     *time.Time `gorm:""`
+	Error           string     `gorm:"type:text"` // delivery error, if any; failed reminders are not retried
+
+	Timestamp
+}
+
+func (erl *EventReminderLog) BeforeCreate(tx *gorm.DB) error {
+	if erl.ID == uuid.Nil {
+		erl.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventRundownSegment is one timed part of the run-sheet of one occurrence of an event
+type EventRundownSegment struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_rundown_position"`
+	Event          Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time  `gorm:"type:date;not null;index;uniqueIndex:idx_event_rundown_position"` // in the event's timezone
+	Position       int        `gorm:"not null;uniqueIndex:idx_event_rundown_position"`                 // 1 comes first
+	Title          string     `gorm:"type:varchar(255);not null"`
+	Type           string     `gorm:"type:varchar(50)"`    // e.g. opening, worship, announcements, sermon, offering
+	StartOffset    int        `gorm:"not null;default:0"`  // minutes after the occurrence starts
+	Duration       int        `gorm:"not null"`            // minutes
+	PersonID       *uuid.UUID `gorm:"type:char(36);index"` // responsible person
+	Person         *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:PersonID"`
+	Notes          string     `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+// EventRundownTemplateSegment is one segment of the run-sheet the occurrences of an event are planned from
+type EventRundownTemplateSegment struct {
+	ID          uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID     uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_rundown_template_position"`
+	Event       Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	Position    int        `gorm:"not null;uniqueIndex:idx_event_rundown_template_position"`
+	Title       string     `gorm:"type:varchar(255);not null"`
+	Type        string     `gorm:"type:varchar(50)"`
+	StartOffset int        `gorm:"not null;default:0"` // minutes after the occurrence starts
+	Duration    int        `gorm:"not null"`           // minutes
+	PersonID    *uuid.UUID `gorm:"type:char(36);index"`
+	Person      *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:PersonID"`
+	Notes       string     `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+func (s *EventRundownSegment) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *EventRundownTemplateSegment) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
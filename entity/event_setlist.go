@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventSetlistItem is one song in the setlist of one occurrence of an event
+type EventSetlistItem struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key"`
+	EventID        uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_setlist_position"`
+	Event          Event      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	OccurrenceDate time.Time  `gorm:"type:date;not null;index;uniqueIndex:idx_event_setlist_position"` // in the event's timezone
+	Position       int        `gorm:"not null;uniqueIndex:idx_event_setlist_position"`                 // 1 is sung first
+	LaguID         uuid.UUID  `gorm:"type:char(36);not null;index"`
+	Lagu           Lagu       `gorm:"constraint:OnUpdate:CASCADE;foreignKey:LaguID"`
+	PerformedKey   string     `gorm:"type:varchar(4)"` // key it is sung in when not Lagu.NadaDasar, e.g. "Bb"
+	SongLeaderID   *uuid.UUID `gorm:"type:char(36);index"`
+	SongLeader     *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:SongLeaderID"`
+	Notes          string     `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+func (i *EventSetlistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
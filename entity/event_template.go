@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventTemplate is an event saved to be created again at other dates. Dates inside the template are
+// kept as day offsets from the event's first date, so instantiating it shifts everything together.
+type EventTemplate struct {
+	ID            uuid.UUID  `gorm:"type:char(36);primary_key"`
+	Name          string     `gorm:"type:varchar(255);not null"`
+	SourceEventID *uuid.UUID `gorm:"type:char(36);index"` // event the template was saved from
+	SourceEvent   *Event     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:SourceEventID"`
+
+	Title         string     `gorm:"type:varchar(255);not null"`
+	BannerImage   string     `gorm:"type:varchar(255);null"`
+	Description   string     `gorm:"type:text"`
+	Capacity      int        `gorm:"default:99999"`
+	Type          string     `gorm:"type:varchar(255);not null"` // event, ibadah, spiritual journey
+	EventLocation string     `gorm:"type:varchar(255)"`
+	VenueID       *uuid.UUID `gorm:"type:char(36);index"`
+	Venue         *Venue     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ChurchID      *uuid.UUID `gorm:"type:char(36);index"`      // church the template belongs to and its events are given to
+	StartTime     string     `gorm:"type:varchar(5);not null"` // HH:MM wall clock in Timezone
+	EndTime       string     `gorm:"type:varchar(5);not null"`
+	AllDay        bool       `gorm:"default:false"`
+	Timezone      string     `gorm:"type:varchar(64);not null"`
+	IsPublic      bool       `gorm:"default:false"`
+
+	// Expected participant counts for planning
+	ExpectedParticipants int `gorm:"default:0"`
+	ExpectedAdults       int `gorm:"default:0"`
+	ExpectedYouth        int `gorm:"default:0"`
+	ExpectedKids         int `gorm:"default:0"`
+
+	// Recurrence, without UNTIL; a series that ends on a date keeps it as days after the first date
+	RRule       string `gorm:"type:text"`
+	UntilOffset *int   `gorm:""`
+
+	PICs            []EventTemplatePIC            `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	SetlistItems    []EventTemplateSetlistItem    `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	RundownSegments []EventTemplateRundownSegment `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Timestamp
+}
+
+// EventTemplatePIC is a PIC role filled on events created from a template, optionally by a default person
+type EventTemplatePIC struct {
+	ID          uuid.UUID     `gorm:"type:char(36);primary_key"`
+	TemplateID  uuid.UUID     `gorm:"type:char(36);not null;index"`
+	RoleID      *uuid.UUID    `gorm:"type:char(36);index"` // predefined role, when the role has one
+	PICRole     *EventPICRole `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:RoleID"`
+	Role        string        `gorm:"type:varchar(100);not null"`
+	Description string        `gorm:"type:text"`
+	PersonID    *uuid.UUID    `gorm:"type:char(36);index"`
+	Person      *Person       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:PersonID"`
+	IsPrimary   bool          `gorm:"default:false;not null"`
+	StartOffset int           `gorm:"default:0;not null"` // days after the event's first date the assignment starts
+	EndOffset   *int          `gorm:""`                   // days after the first date it ends, null for ongoing
+
+	CanEdit           bool `gorm:"default:false;not null"`
+	CanDelete         bool `gorm:"default:false;not null"`
+	CanAssignPIC      bool `gorm:"default:false;not null"`
+	NotifyOnChanges   bool `gorm:"default:true;not null"`
+	NotifyOnReminders bool `gorm:"default:true;not null"`
+
+	TimestampHardDelete
+}
+
+// EventTemplateSetlistItem is one song of the setlist of the occurrence DayOffset days after the first date
+type EventTemplateSetlistItem struct {
+	ID           uuid.UUID  `gorm:"type:char(36);primary_key"`
+	TemplateID   uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_event_template_setlist_position"`
+	DayOffset    int        `gorm:"not null;uniqueIndex:idx_event_template_setlist_position"`
+	Position     int        `gorm:"not null;uniqueIndex:idx_event_template_setlist_position"`
+	LaguID       uuid.UUID  `gorm:"type:char(36);not null;index"`
+	Lagu         Lagu       `gorm:"constraint:OnUpdate:CASCADE;foreignKey:LaguID"`
+	PerformedKey string     `gorm:"type:varchar(4)"`
+	SongLeaderID *uuid.UUID `gorm:"type:char(36);index"`
+	SongLeader   *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:SongLeaderID"`
+	Notes        string     `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+// EventTemplateRundownSegment is one run-sheet segment of the occurrence DayOffset days after the first
+// date, or of the event's run-sheet template when DayOffset is null
+type EventTemplateRundownSegment struct {
+	ID          uuid.UUID  `gorm:"type:char(36);primary_key"`
+	TemplateID  uuid.UUID  `gorm:"type:char(36);not null;index"`
+	DayOffset   *int       `gorm:""`
+	Position    int        `gorm:"not null"`
+	Title       string     `gorm:"type:varchar(255);not null"`
+	Type        string     `gorm:"type:varchar(50)"`
+	StartOffset int        `gorm:"not null;default:0"` // minutes after the occurrence starts
+	Duration    int        `gorm:"not null"`           // minutes
+	PersonID    *uuid.UUID `gorm:"type:char(36);index"`
+	Person      *Person    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;foreignKey:PersonID"`
+	Notes       string     `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+func (t *EventTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (p *EventTemplatePIC) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (i *EventTemplateSetlistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *EventTemplateRundownSegment) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
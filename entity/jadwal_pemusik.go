@@ -1,14 +1,56 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JadwalPemusik puts a musician on the roster of one occurrence of an event, playing one instrument
 type JadwalPemusik struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key"`
+	PersonID       uuid.UUID  `gorm:"type:char(36);not null;index"`
+	Person         Person     `gorm:"foreignKey:PersonID"`
+	EventId        uuid.UUID  `gorm:"type:char(36);not null;index:idx_jadwal_pemusik_occurrence"`
+	Event          Event      `gorm:"foreignKey:EventId"`
+	OccurrenceDate time.Time  `gorm:"type:date;not null;index:idx_jadwal_pemusik_occurrence"` // in the event's timezone
+	AlatMusik      string     `gorm:"type:varchar(255);not null"`
+	Status         string     `gorm:"type:varchar(16);not null;default:'draft'"` // draft, published
+	IsManual       bool       `gorm:"default:false"`                             // placed or changed by an admin; generation keeps it
+	Notes          string     `gorm:"type:text"`
+	PublishedAt    *time.Time `gorm:""`
+
+	TimestampHardDelete
+}
+
+// JadwalPemusik statuses
+const (
+	JadwalPemusikStatusDraft     string = "draft"
+	JadwalPemusikStatusPublished string = "published"
+)
+
+// KebutuhanPemusik is how many players of an instrument every occurrence of an event needs
+type KebutuhanPemusik struct {
 	ID        uuid.UUID `gorm:"type:char(36);primary_key"`
-	PersonID  uuid.UUID `gorm:"type:char(36);not null"`
-	Person    Person    `gorm:"foreignKey:PersonID"`
-	EventId   uuid.UUID `gorm:"type:char(36);not null"`
-	Event     Event     `gorm:"foreignKey:EventId"`
-	AlatMusik string    `gorm:"type:varchar(255);not null"`
+	EventID   uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_kebutuhan_pemusik_alat"`
+	Event     Event     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:EventID"`
+	AlatMusik string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_kebutuhan_pemusik_alat"`
+	Jumlah    int       `gorm:"not null;default:1"`
+
+	TimestampHardDelete
+}
+
+func (j *JadwalPemusik) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
 
-	Timestamp
+func (k *KebutuhanPemusik) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
 }
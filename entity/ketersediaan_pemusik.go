@@ -1,15 +1,38 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KetersediaanPemusik is whether a musician can play at one occurrence of an event, and on what
 type KetersediaanPemusik struct {
-	ID           uuid.UUID `gorm:"type:char(36);primary_key"`
-	PersonID     uuid.UUID `gorm:"type:char(36);not null"`
-	Person       Person    `gorm:"foreignKey:PersonID"`
-	Ketersediaan string    `gorm:"type:varchar(255);not null"`
-	IsTerjadwal  bool      `gorm:"type:boolean;default:false"`
-	EventId      uuid.UUID `gorm:"type:char(36);not null"`
-	Event        Event     `gorm:"foreignKey:EventId"`
-
-	Timestamp
+	ID             uuid.UUID `gorm:"type:char(36);primary_key"`
+	PersonID       uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_ketersediaan_pemusik_occurrence"`
+	Person         Person    `gorm:"foreignKey:PersonID"`
+	Ketersediaan   string    `gorm:"type:varchar(255);not null"` // tersedia, mungkin, tidak_tersedia
+	AlatMusik      string    `gorm:"type:text"`                  // JSON string: ["gitar","keyboard"], what the musician can play that day
+	IsTerjadwal    bool      `gorm:"type:boolean;default:false"` // true while the musician is on the roster of the occurrence
+	EventId        uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_ketersediaan_pemusik_occurrence"`
+	Event          Event     `gorm:"foreignKey:EventId"`
+	OccurrenceDate time.Time `gorm:"type:date;not null;index;uniqueIndex:idx_ketersediaan_pemusik_occurrence"` // in the event's timezone
+	Notes          string    `gorm:"type:text"`
+
+	TimestampHardDelete
+}
+
+// Ketersediaan values
+const (
+	KetersediaanTersedia      string = "tersedia"
+	KetersediaanMungkin       string = "mungkin" // only put on the roster when nobody else is available
+	KetersediaanTidakTersedia string = "tidak_tersedia"
+)
+
+func (k *KetersediaanPemusik) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
 }
@@ -1,6 +1,11 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type Lagu struct {
 	ID          uuid.UUID `gorm:"type:char(36);primary_key"`
@@ -10,8 +15,43 @@ type Lagu struct {
 	Genre       string    `gorm:"type:varchar(100);"` // Genre lagu
 	Lirik       string    `gorm:"type:text;not null"` // Lirik lagu
 	TagLagu     []TagLagu `gorm:"many2many:lagu_tag_lagu;"`
-	NadaDasar   string    `gorm:"type:char(2);"`
-	TahunRilis  int       `gorm:"type:int;"` // Tahun rilis lagu
+	NadaDasar   string    `gorm:"type:varchar(4);"` // key of Chart, e.g. "G", "Bb" or "F#m"
+	TahunRilis  int       `gorm:"type:int;"`        // Tahun rilis lagu
+
+	// ChordPro chart with the chords, in NadaDasar; Lirik holds the lyrics without them
+	Chart string `gorm:"type:mediumtext"`
+
+	// Judul, artis, genre, tag names and lirik folded to lowercase without accents, for search
+	SearchText string `gorm:"type:mediumtext"`
 
 	Timestamp
 }
+
+// EventLagu is the event_lagu join table behind Event.Lagu. Setlists are planned per occurrence
+// with EventSetlistItem; the event-wide rows are copied onto the first occurrence on migration.
+type EventLagu struct {
+	EventID  uuid.UUID `gorm:"type:char(36);primaryKey"`
+	LaguID   uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Position int       `gorm:"default:0;not null"`
+}
+
+func (EventLagu) TableName() string {
+	return "event_lagu"
+}
+
+// SearchableText joins the fields song search looks at, tags included when loaded
+func (l *Lagu) SearchableText() string {
+	parts := []string{l.Judul, l.Artis, l.Genre}
+	for _, tag := range l.TagLagu {
+		parts = append(parts, tag.Nama)
+	}
+	parts = append(parts, l.Lirik)
+	return strings.Join(parts, " ")
+}
+
+func (l *Lagu) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
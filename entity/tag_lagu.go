@@ -1,6 +1,9 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type TagLagu struct {
 	ID   uuid.UUID `gorm:"type:char(36);primary_key"`
@@ -8,3 +11,10 @@ type TagLagu struct {
 
 	Timestamp
 }
+
+func (t *TagLagu) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
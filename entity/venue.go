@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Venue is a hall or room of a church that events can be booked into
+type Venue struct {
+	ID          uuid.UUID `gorm:"type:char(36);primary_key"`
+	ChurchID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Church      Church    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:ChurchID"`
+	Name        string    `gorm:"type:varchar(255);not null"`
+	Description string    `gorm:"type:text"`
+	Capacity    int       `gorm:"default:0"` // 0 means unknown
+	IsActive    bool      `gorm:"default:true"`
+
+	Timestamp
+}
+
+func (v *Venue) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/zemetia/en-indo-be/middleware"
 	"github.com/zemetia/en-indo-be/provider"
 	"github.com/zemetia/en-indo-be/routes"
+	"github.com/zemetia/en-indo-be/scheduler"
 
 	"github.com/common-nighthawk/go-figure"
 	"github.com/gin-gonic/gin"
@@ -67,5 +68,10 @@ func main() {
 	// routes
 	routes.RegisterRoutes(server, injector)
 
+	// background jobs
+	scheduler.StartEventReminders(injector)
+	scheduler.StartOccurrenceIndexRefresh(injector)
+	scheduler.StartPICRotationFill(injector)
+
 	run(server)
 }
@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/service"
+	"github.com/zemetia/en-indo-be/utils"
+	"gorm.io/gorm"
+)
+
+// EventIDResolver finds the event a request acts on
+type EventIDResolver func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error)
+
+// EventFromParam reads the event ID straight from the :id parameter
+func EventFromParam(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+	return id, nil
+}
+
+// EventFromPIC reads the :id parameter as an EventPIC ID and resolves its event
+func EventFromPIC(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfPIC(id)
+	}
+}
+
+// EventFromOccurrencePIC reads the :id parameter as an occurrence PIC ID and resolves its event
+func EventFromOccurrencePIC(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfOccurrencePIC(id)
+	}
+}
+
+// EventFromRotation reads the :id parameter as a PIC rotation ID and resolves its event
+func EventFromRotation(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfRotation(id)
+	}
+}
+
+// EventFromRegistration reads the :id parameter as a registration ID and resolves its event
+func EventFromRegistration(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfRegistration(id)
+	}
+}
+
+// EventFromAttendance reads the :id parameter as an attendance ID and resolves its event
+func EventFromAttendance(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfAttendance(id)
+	}
+}
+
+// EventFromJadwal reads the :id parameter as a musician roster place ID and resolves its event
+func EventFromJadwal(authService service.EventAuthorizationService) EventIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (uuid.UUID, error) {
+		return authService.EventIDOfJadwal(id)
+	}
+}
+
+// ChurchIDResolver finds the church whose admins may act on the record named by the :id parameter.
+// A nil church lets the admins of any church act on it.
+type ChurchIDResolver func(ctx *gin.Context, id uuid.UUID) (*uuid.UUID, error)
+
+// ChurchOfTemplate reads the :id parameter as an event template ID and resolves its church
+func ChurchOfTemplate(authService service.EventAuthorizationService) ChurchIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (*uuid.UUID, error) {
+		return authService.ChurchIDOfTemplate(id)
+	}
+}
+
+// ChurchOfVenue reads the :id parameter as a venue ID and resolves its church
+func ChurchOfVenue(authService service.EventAuthorizationService) ChurchIDResolver {
+	return func(ctx *gin.Context, id uuid.UUID) (*uuid.UUID, error) {
+		return authService.ChurchIDOfVenue(id)
+	}
+}
+
+// RequireEventPermission middleware ensures the caller may perform action on the event the request acts on.
+// It must run after Authenticate. Active PICs of the event with the matching permission (CanEdit, CanDelete
+// or CanAssignPIC) and admins of the event's church are allowed; everyone else gets 403.
+func RequireEventPermission(authService service.EventAuthorizationService, action string, resolve EventIDResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		personID, ok := eventActor(ctx)
+		if !ok {
+			return
+		}
+
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			denyEventAccess(ctx, http.StatusBadRequest, "Invalid ID format")
+			return
+		}
+
+		eventID, err := resolve(ctx, id)
+		if err == nil {
+			var allowed bool
+			allowed, err = authService.CanPerform(ctx, eventID, personID, action)
+			if err == nil && !allowed {
+				denyEventAccess(ctx, http.StatusForbidden, eventActionDeniedMessage(action))
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				denyEventAccess(ctx, http.StatusNotFound, "Event not found")
+				return
+			}
+			denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+			return
+		}
+
+		ctx.Set("event_id", eventID)
+		ctx.Next()
+	}
+}
+
+// RequireEventAccess middleware ensures the caller may see the event the request reads: it is public,
+// belongs to one of the caller's churches or the caller is one of its PICs. It must run after Authenticate.
+func RequireEventAccess(authService service.EventAuthorizationService, resolve EventIDResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		personID, ok := eventActor(ctx)
+		if !ok {
+			return
+		}
+
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			denyEventAccess(ctx, http.StatusBadRequest, "Invalid ID format")
+			return
+		}
+
+		eventID, err := resolve(ctx, id)
+		if err == nil {
+			var allowed bool
+			allowed, err = authService.CanView(ctx, eventID, personID)
+			if err == nil && !allowed {
+				denyEventAccess(ctx, http.StatusForbidden, "You are not allowed to see this event.")
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				denyEventAccess(ctx, http.StatusNotFound, "Event not found")
+				return
+			}
+			denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequirePersonAccess middleware ensures the caller may see the event records of the person in the
+// :personId parameter: their own, or as an admin of that person's church. It must run after Authenticate.
+func RequirePersonAccess(authService service.EventAuthorizationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		viewerID, ok := eventActor(ctx)
+		if !ok {
+			return
+		}
+
+		personID, err := uuid.Parse(ctx.Param("personId"))
+		if err != nil {
+			denyEventAccess(ctx, http.StatusBadRequest, "Invalid person ID format")
+			return
+		}
+
+		allowed, err := authService.CanViewPerson(ctx, viewerID, personID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				denyEventAccess(ctx, http.StatusNotFound, "Person not found")
+				return
+			}
+			denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+			return
+		}
+		if !allowed {
+			denyEventAccess(ctx, http.StatusForbidden, "Only the person themselves or admins of their church can see this.")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireChurchAdmin middleware ensures the caller is an admin of the church owning the record named by
+// the :id parameter. Without a resolver the route names no record and an admin of any church may use it.
+// It must run after Authenticate.
+func RequireChurchAdmin(authService service.EventAuthorizationService, resolve ChurchIDResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		personID, ok := eventActor(ctx)
+		if !ok {
+			return
+		}
+
+		var churchID *uuid.UUID
+		if resolve != nil {
+			id, err := uuid.Parse(ctx.Param("id"))
+			if err != nil {
+				denyEventAccess(ctx, http.StatusBadRequest, "Invalid ID format")
+				return
+			}
+			churchID, err = resolve(ctx, id)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					denyEventAccess(ctx, http.StatusNotFound, "Record not found")
+					return
+				}
+				denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+				return
+			}
+		}
+
+		allowed, err := authService.IsChurchAdmin(ctx, personID, churchID)
+		if err != nil {
+			denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+			return
+		}
+		if !allowed {
+			denyEventAccess(ctx, http.StatusForbidden, "Only church admins can perform this action.")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireChurchMember middleware ensures the caller belongs to or administers the church owning the
+// record named by the :id parameter. A record of no church is open to every signed-in member. It must
+// run after Authenticate.
+func RequireChurchMember(authService service.EventAuthorizationService, resolve ChurchIDResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		personID, ok := eventActor(ctx)
+		if !ok {
+			return
+		}
+
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			denyEventAccess(ctx, http.StatusBadRequest, "Invalid ID format")
+			return
+		}
+
+		churchID, err := resolve(ctx, id)
+		if err == nil && churchID != nil {
+			var allowed bool
+			allowed, err = authService.CanUseChurch(ctx, personID, *churchID)
+			if err == nil && !allowed {
+				denyEventAccess(ctx, http.StatusForbidden, "Only members and admins of the church can use this.")
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				denyEventAccess(ctx, http.StatusNotFound, "Record not found")
+				return
+			}
+			denyEventAccess(ctx, http.StatusInternalServerError, "Failed to check permissions")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// eventActor reads the person Authenticate signed in, aborting with 403 when there is none
+func eventActor(ctx *gin.Context) (uuid.UUID, bool) {
+	personIDInterface, exists := ctx.Get("person_id")
+	if !exists {
+		denyEventAccess(ctx, http.StatusForbidden, "Your account is not linked to a person")
+		return uuid.Nil, false
+	}
+
+	personIDStr, ok := personIDInterface.(string)
+	if !ok {
+		denyEventAccess(ctx, http.StatusForbidden, "Invalid person ID type")
+		return uuid.Nil, false
+	}
+
+	personID, err := uuid.Parse(personIDStr)
+	if err != nil {
+		denyEventAccess(ctx, http.StatusForbidden, "Invalid person ID format")
+		return uuid.Nil, false
+	}
+	return personID, true
+}
+
+// denyEventAccess aborts with the same body for every refused event request
+func denyEventAccess(ctx *gin.Context, status int, message string) {
+	response := utils.BuildResponseFailed("ACCESS_DENIED", message, nil)
+	ctx.AbortWithStatusJSON(status, response)
+}
+
+func eventActionDeniedMessage(action string) string {
+	switch action {
+	case service.EventActionDelete:
+		return "You are not authorized to delete this event. Only PICs with delete permission or church admins can perform this action."
+	case service.EventActionAssignPIC:
+		return "You are not authorized to assign PICs for this event. Only PICs with PIC assignment permission or church admins can perform this action."
+	default:
+		return "You are not authorized to edit this event. Only PICs with edit permission or church admins can perform this action."
+	}
+}
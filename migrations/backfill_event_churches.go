@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// BackfillEventChurches gives events created before churches owned them the church of their primary
+// PIC, or failing that the church of their venue. Only events without a church are touched, so it is
+// safe to run on every start.
+func BackfillEventChurches(db *gorm.DB) error {
+	primaryPICChurch := `SELECT people.church_id FROM event_pics
+		JOIN people ON people.id = event_pics.person_id
+		WHERE event_pics.event_id = events.id AND event_pics.is_primary = ? AND event_pics.deleted_at IS NULL
+		ORDER BY event_pics.is_active DESC, event_pics.start_date DESC
+		LIMIT 1`
+	if err := db.Exec("UPDATE events SET church_id = ("+primaryPICChurch+") WHERE church_id IS NULL", true).Error; err != nil {
+		return fmt.Errorf("failed to backfill event churches from primary PICs: %v", err)
+	}
+
+	venueChurch := `SELECT venues.church_id FROM venues WHERE venues.id = events.venue_id`
+	if err := db.Exec("UPDATE events SET church_id = (" + venueChurch + ") WHERE church_id IS NULL AND venue_id IS NOT NULL").Error; err != nil {
+		return fmt.Errorf("failed to backfill event churches from venues: %v", err)
+	}
+
+	return nil
+}
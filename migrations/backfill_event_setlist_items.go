@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+)
+
+// BackfillEventSetlistItems copies the event_lagu setlists, which belong to a whole event, into
+// setlist items on the event's first occurrence. Events that already have setlist items are skipped,
+// so it is safe to run on every start.
+func BackfillEventSetlistItems(db *gorm.DB) error {
+	var rows []entity.EventLagu
+	if err := db.Where("event_id NOT IN (?)", db.Model(&entity.EventSetlistItem{}).Distinct("event_id")).
+		Order("event_id, position, lagu_id").
+		Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to load event setlists: %v", err)
+	}
+
+	byEvent := make(map[uuid.UUID][]entity.EventLagu)
+	var eventIDs []uuid.UUID
+	for _, row := range rows {
+		if _, ok := byEvent[row.EventID]; !ok {
+			eventIDs = append(eventIDs, row.EventID)
+		}
+		byEvent[row.EventID] = append(byEvent[row.EventID], row)
+	}
+
+	for _, eventID := range eventIDs {
+		var event entity.Event
+		if err := db.Unscoped().First(&event, "id = ?", eventID).Error; err != nil {
+			return fmt.Errorf("failed to load event %s for its setlist: %v", eventID, err)
+		}
+
+		// Start times are stored as wall-clock time in the event's timezone
+		start := event.StartDatetime
+		occurrenceDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+		items := make([]entity.EventSetlistItem, len(byEvent[eventID]))
+		for i, row := range byEvent[eventID] {
+			items[i] = entity.EventSetlistItem{
+				EventID:        eventID,
+				OccurrenceDate: occurrenceDate,
+				Position:       i + 1,
+				LaguID:         row.LaguID,
+			}
+		}
+		if err := db.Create(&items).Error; err != nil {
+			return fmt.Errorf("failed to backfill setlist of event %s: %v", eventID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/utils"
+	"gorm.io/gorm"
+)
+
+// BackfillLaguSearchText fills lagus.search_text for songs saved before song search existed
+func BackfillLaguSearchText(db *gorm.DB) error {
+	var songs []entity.Lagu
+	if err := db.Preload("TagLagu").
+		Where("search_text IS NULL OR search_text = ''").
+		Find(&songs).Error; err != nil {
+		return fmt.Errorf("failed to load songs for search text: %v", err)
+	}
+
+	for i := range songs {
+		searchText := utils.FoldSearchText(songs[i].SearchableText())
+		if err := db.Model(&songs[i]).UpdateColumn("search_text", searchText).Error; err != nil {
+			return fmt.Errorf("failed to backfill search text of song %s: %v", songs[i].ID, err)
+		}
+	}
+
+	return nil
+}
@@ -22,12 +22,34 @@ func Migrate(db *gorm.DB) error {
 		&entity.Pelayanan{},
 		&entity.RecurrenceRule{},
 		&entity.RecurrenceException{},
+		&entity.Venue{},
 		&entity.Event{},
 		&entity.EventPIC{},
 		&entity.EventPICRole{},
 		&entity.EventPICHistory{},
+		&entity.EventPICRotation{},
+		&entity.EventPICRotationMember{},
+		&entity.EventOccurrencePIC{},
+		&entity.EventRegistration{},
+		&entity.EventAttendance{},
+		&entity.EventHeadcount{},
+		&entity.EventCheckInToken{},
+		&entity.EventReminderLog{},
+		&entity.EventOccurrence{},
 		&entity.DiscipleshipJourney{},
+		&entity.TagLagu{},
 		&entity.Lagu{},
+		&entity.EventLagu{},
+		&entity.EventSetlistItem{},
+		&entity.EventRundownSegment{},
+		&entity.EventRundownTemplateSegment{},
+		&entity.EventTemplate{},
+		&entity.EventTemplatePIC{},
+		&entity.EventTemplateSetlistItem{},
+		&entity.EventTemplateRundownSegment{},
+		&entity.KebutuhanPemusik{},
+		&entity.KetersediaanPemusik{},
+		&entity.JadwalPemusik{},
 		&entity.Visitor{},
 		&entity.VisitorInformation{},
 	); err != nil {
@@ -65,5 +87,20 @@ func Migrate(db *gorm.DB) error {
 		return err
 	}
 
+	// Fill the search text of songs created before it existed
+	if err := BackfillLaguSearchText(db); err != nil {
+		return err
+	}
+
+	// Move event-wide setlists onto the occurrence they were planned for
+	if err := BackfillEventSetlistItems(db); err != nil {
+		return err
+	}
+
+	// Give events created before church scoping the church of their primary PIC
+	if err := BackfillEventChurches(db); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventAttendanceRepository interface {
+	// Individual check-ins
+	Create(attendance *entity.EventAttendance) error
+	GetByID(id uuid.UUID) (*entity.EventAttendance, error)
+	Delete(id uuid.UUID) error
+	List(filters EventAttendanceFilters) ([]entity.EventAttendance, int64, error)
+	GetByPersonID(personID uuid.UUID) ([]entity.EventAttendance, error)
+	FindCheckIn(eventID uuid.UUID, occurrenceDate time.Time, personID, visitorID *uuid.UUID) (*entity.EventAttendance, error)
+	CountByAgeGroup(eventID uuid.UUID, startDate, endDate time.Time) ([]AttendanceCount, error)
+
+	// Anonymous headcounts
+	SaveHeadcount(headcount *entity.EventHeadcount) error
+	GetHeadcount(eventID uuid.UUID, occurrenceDate time.Time) (*entity.EventHeadcount, error)
+	GetHeadcountsInRange(eventID uuid.UUID, startDate, endDate time.Time) ([]entity.EventHeadcount, error)
+
+	// QR check-in tokens
+	CreateCheckInToken(token *entity.EventCheckInToken) error
+	GetCheckInToken(id uuid.UUID) (*entity.EventCheckInToken, error)
+	RedeemCheckInToken(tokenID uuid.UUID, attendance *entity.EventAttendance) error
+}
+
+type EventAttendanceFilters struct {
+	EventID        *uuid.UUID
+	OccurrenceDate *time.Time
+	AgeGroup       string
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+// AttendanceCount is the number of check-ins of one age group at one occurrence
+type AttendanceCount struct {
+	OccurrenceDate time.Time
+	AgeGroup       string
+	Total          int64
+}
+
+// ErrCheckInTokenUsed is returned when a check-in token is redeemed a second time
+var ErrCheckInTokenUsed = errors.New("check-in token has already been used")
+
+type eventAttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewEventAttendanceRepository(db *gorm.DB) EventAttendanceRepository {
+	return &eventAttendanceRepository{db: db}
+}
+
+func (r *eventAttendanceRepository) Create(attendance *entity.EventAttendance) error {
+	return r.db.Omit(clause.Associations).Create(attendance).Error
+}
+
+func (r *eventAttendanceRepository) GetByID(id uuid.UUID) (*entity.EventAttendance, error) {
+	var attendance entity.EventAttendance
+	err := r.db.Preload("Event").
+		Preload("Person").
+		Preload("Visitor").
+		First(&attendance, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+func (r *eventAttendanceRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&entity.EventAttendance{}, "id = ?", id).Error
+}
+
+func (r *eventAttendanceRepository) List(filters EventAttendanceFilters) ([]entity.EventAttendance, int64, error) {
+	var attendances []entity.EventAttendance
+	var count int64
+
+	query := r.db.Model(&entity.EventAttendance{}).
+		Preload("Person").
+		Preload("Visitor")
+
+	if filters.EventID != nil {
+		query = query.Where("event_attendances.event_id = ?", *filters.EventID)
+	}
+	if filters.OccurrenceDate != nil {
+		query = query.Where("event_attendances.occurrence_date = ?", filters.OccurrenceDate.Format("2006-01-02"))
+	}
+	if filters.AgeGroup != "" {
+		query = query.Where("event_attendances.age_group = ?", filters.AgeGroup)
+	}
+	if filters.Search != "" {
+		query = query.Joins("LEFT JOIN people ON event_attendances.person_id = people.id").
+			Joins("LEFT JOIN visitors ON event_attendances.visitor_id = visitors.id").
+			Where("people.nama LIKE ? OR people.email LIKE ? OR visitors.name LIKE ?",
+				"%"+filters.Search+"%", "%"+filters.Search+"%", "%"+filters.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Order("event_attendances.checked_in_at ASC, event_attendances.id ASC").
+		Find(&attendances).Error
+	return attendances, count, err
+}
+
+func (r *eventAttendanceRepository) GetByPersonID(personID uuid.UUID) ([]entity.EventAttendance, error) {
+	var attendances []entity.EventAttendance
+	err := r.db.Preload("Event").
+		Where("person_id = ?", personID).
+		Order("occurrence_date DESC, checked_in_at DESC").
+		Find(&attendances).Error
+	return attendances, err
+}
+
+func (r *eventAttendanceRepository) FindCheckIn(eventID uuid.UUID, occurrenceDate time.Time, personID, visitorID *uuid.UUID) (*entity.EventAttendance, error) {
+	var attendance entity.EventAttendance
+	query := r.db.Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02"))
+	if personID != nil {
+		query = query.Where("person_id = ?", *personID)
+	} else {
+		query = query.Where("visitor_id = ?", visitorID)
+	}
+
+	err := query.First(&attendance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// CountByAgeGroup counts check-ins per occurrence and age group between two dates (inclusive)
+func (r *eventAttendanceRepository) CountByAgeGroup(eventID uuid.UUID, startDate, endDate time.Time) ([]AttendanceCount, error) {
+	var counts []AttendanceCount
+	err := r.db.Model(&entity.EventAttendance{}).
+		Select("occurrence_date, age_group, COUNT(*) AS total").
+		Where("event_id = ? AND occurrence_date BETWEEN ? AND ?",
+			eventID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Group("occurrence_date, age_group").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// SaveHeadcount creates or replaces the headcount of an occurrence
+func (r *eventAttendanceRepository) SaveHeadcount(headcount *entity.EventHeadcount) error {
+	return r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"adults", "youth", "kids", "notes", "recorded_by_id", "updated_at"}),
+		}).
+		Create(headcount).Error
+}
+
+func (r *eventAttendanceRepository) GetHeadcount(eventID uuid.UUID, occurrenceDate time.Time) (*entity.EventHeadcount, error) {
+	var headcount entity.EventHeadcount
+	err := r.db.Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+		First(&headcount).Error
+	if err != nil {
+		return nil, err
+	}
+	return &headcount, nil
+}
+
+func (r *eventAttendanceRepository) GetHeadcountsInRange(eventID uuid.UUID, startDate, endDate time.Time) ([]entity.EventHeadcount, error) {
+	var headcounts []entity.EventHeadcount
+	err := r.db.Where("event_id = ? AND occurrence_date BETWEEN ? AND ?",
+		eventID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Order("occurrence_date ASC").
+		Find(&headcounts).Error
+	return headcounts, err
+}
+
+func (r *eventAttendanceRepository) CreateCheckInToken(token *entity.EventCheckInToken) error {
+	return r.db.Omit(clause.Associations).Create(token).Error
+}
+
+func (r *eventAttendanceRepository) GetCheckInToken(id uuid.UUID) (*entity.EventCheckInToken, error) {
+	var token entity.EventCheckInToken
+	if err := r.db.First(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RedeemCheckInToken marks the token as used and records the attendance in one transaction.
+// The conditional update makes concurrent scans of the same token fail with ErrCheckInTokenUsed.
+func (r *eventAttendanceRepository) RedeemCheckInToken(tokenID uuid.UUID, attendance *entity.EventAttendance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.EventCheckInToken{}).
+			Where("id = ? AND used_at IS NULL", tokenID).
+			Update("used_at", attendance.CheckedInAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCheckInTokenUsed
+		}
+
+		if err := tx.Omit(clause.Associations).Create(attendance).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.EventCheckInToken{}).
+			Where("id = ?", tokenID).
+			Update("attendance_id", attendance.ID).Error
+	})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventOccurrenceRepository interface {
+	ReplaceForEvent(eventID uuid.UUID, occurrences []entity.EventOccurrence) error
+	DeleteByEventID(eventID uuid.UUID) error
+	List(filters EventOccurrenceFilters) ([]entity.EventOccurrence, int64, error)
+	ListEventIDs() ([]uuid.UUID, error)
+	DeleteOrphans() error
+}
+
+// EventOccurrenceFilters selects indexed occurrences. Use either the absolute StartsFrom/StartsTo
+// range or the calendar DateFrom/DateTo range, which is read in each event's own timezone.
+type EventOccurrenceFilters struct {
+	EventID    *uuid.UUID
+	StartsFrom *time.Time
+	StartsTo   *time.Time
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	Type       string
+	IsPublic   *bool
+	Search     string
+	Limit      int
+	Offset     int
+
+	ChurchID    *uuid.UUID // owned by or shared with the church
+	KabupatenID *uint      // region of the owning church
+	ProvinsiID  *uint
+	Viewer      *dto.EventViewer
+}
+
+type eventOccurrenceRepository struct {
+	db *gorm.DB
+}
+
+func NewEventOccurrenceRepository(db *gorm.DB) EventOccurrenceRepository {
+	return &eventOccurrenceRepository{db: db}
+}
+
+// ReplaceForEvent swaps the indexed occurrences of an event for a freshly generated set
+func (r *eventOccurrenceRepository) ReplaceForEvent(eventID uuid.UUID, occurrences []entity.EventOccurrence) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", eventID).Delete(&entity.EventOccurrence{}).Error; err != nil {
+			return err
+		}
+		if len(occurrences) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(occurrences, 500).Error
+	})
+}
+
+func (r *eventOccurrenceRepository) DeleteByEventID(eventID uuid.UUID) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&entity.EventOccurrence{}).Error
+}
+
+func (r *eventOccurrenceRepository) List(filters EventOccurrenceFilters) ([]entity.EventOccurrence, int64, error) {
+	var occurrences []entity.EventOccurrence
+	var count int64
+
+	query := r.db.Model(&entity.EventOccurrence{}).
+		Joins("JOIN events ON events.id = event_occurrences.event_id AND events.deleted_at IS NULL")
+
+	if filters.EventID != nil {
+		query = query.Where("event_occurrences.event_id = ?", *filters.EventID)
+	}
+	if filters.StartsFrom != nil {
+		query = query.Where("event_occurrences.starts_at >= ?", *filters.StartsFrom)
+	}
+	if filters.StartsTo != nil {
+		query = query.Where("event_occurrences.starts_at <= ?", *filters.StartsTo)
+	}
+	if filters.DateFrom != nil {
+		query = query.Where("event_occurrences.occurrence_date >= ?", filters.DateFrom.Format("2006-01-02"))
+	}
+	if filters.DateTo != nil {
+		query = query.Where("event_occurrences.occurrence_date <= ?", filters.DateTo.Format("2006-01-02"))
+	}
+	if filters.Type != "" {
+		query = query.Where("events.type = ?", filters.Type)
+	}
+	if filters.IsPublic != nil {
+		query = query.Where("events.is_public = ?", *filters.IsPublic)
+	}
+	if filters.ChurchID != nil {
+		query = query.Where(eventOfChurch, *filters.ChurchID, *filters.ChurchID)
+	}
+	if filters.Viewer != nil {
+		query = query.Where(eventVisibleTo, eventViewerArgs(filters.Viewer)...)
+	}
+	if filters.KabupatenID != nil || filters.ProvinsiID != nil {
+		// Events without an owning church are placed by their venue's church
+		query = query.Joins("LEFT JOIN venues ON venues.id = events.venue_id").
+			Joins("JOIN churches ON churches.id = COALESCE(events.church_id, venues.church_id) AND churches.deleted_at IS NULL")
+		if filters.KabupatenID != nil {
+			query = query.Where("churches.kabupaten_id = ?", *filters.KabupatenID)
+		}
+		if filters.ProvinsiID != nil {
+			query = query.Joins("JOIN kabupatens ON kabupatens.id = churches.kabupaten_id").
+				Where("kabupatens.provinsi_id = ?", *filters.ProvinsiID)
+		}
+	}
+	if filters.Search != "" {
+		query = query.Where(
+			"events.title LIKE ? OR events.description LIKE ? OR events.event_location LIKE ?",
+			"%"+filters.Search+"%", "%"+filters.Search+"%", "%"+filters.Search+"%",
+		)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Preload("Event").
+		Preload("Event.RecurrenceRule").
+		Preload("Event.Lagu").
+		Preload("Event.DiscipleshipJourney").
+		Preload("Event.Venue").
+		Preload("Event.Venue.Church.Kabupaten.Provinsi").
+		Preload("Event.Church.Kabupaten.Provinsi").
+		Preload("Event.Churches.Kabupaten.Provinsi").
+		Preload("Event.EventPICs").
+		Preload("Event.EventPICs.Person").
+		Order("event_occurrences.starts_at ASC, event_occurrences.id ASC").
+		Find(&occurrences).Error
+	return occurrences, count, err
+}
+
+// ListEventIDs returns the IDs of all events that are not deleted, for rebuilding the index
+func (r *eventOccurrenceRepository) ListEventIDs() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&entity.Event{}).Order("event_date ASC").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// DeleteOrphans removes occurrences left behind by deleted events
+func (r *eventOccurrenceRepository) DeleteOrphans() error {
+	return r.db.Where("event_id NOT IN (?)", r.db.Model(&entity.Event{}).Select("id")).
+		Delete(&entity.EventOccurrence{}).Error
+}
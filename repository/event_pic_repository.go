@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
 	"github.com/zemetia/en-indo-be/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EventPICRepository interface {
@@ -23,6 +25,7 @@ type EventPICRepository interface {
 	// Person-specific PIC operations
 	GetPICsByPersonID(personID uuid.UUID) ([]entity.EventPIC, error)
 	GetActivePICsByPersonID(personID uuid.UUID) ([]entity.EventPIC, error)
+	GetActivePICsByPersonIDInRange(personID uuid.UUID, from, to time.Time) ([]entity.EventPIC, error)
 	
 	// Validation and business logic support
 	HasPersonPICRoleForEvent(eventID, personID uuid.UUID) (bool, error)
@@ -33,7 +36,7 @@ type EventPICRepository interface {
 	// Advanced queries
 	List(filters EventPICFilters) ([]entity.EventPIC, int64, error)
 	GetPICsWithPermission(eventID uuid.UUID, permission string) ([]entity.EventPIC, error)
-	GetExpiringPICs(days int) ([]entity.EventPIC, error)
+	GetExpiringPICs(days int, viewer *dto.EventViewer) ([]entity.EventPIC, error)
 	
 	// Bulk operations
 	CreateMultiple(eventPICs []entity.EventPIC) error
@@ -41,6 +44,23 @@ type EventPICRepository interface {
 	DeactivateByEventID(eventID uuid.UUID) error
 	TransferPICRole(fromPersonID, toPersonID, eventID uuid.UUID, transferType string) error
 	
+	// Occurrence-level assignments
+	SaveOccurrencePIC(pic *entity.EventOccurrencePIC) error
+	GetOccurrencePICByID(id uuid.UUID) (*entity.EventOccurrencePIC, error)
+	GetOccurrencePICs(eventID uuid.UUID, from, to time.Time) ([]entity.EventOccurrencePIC, error)
+	DeleteOccurrencePIC(id uuid.UUID) error
+	SplitOccurrencePICs(fromEventID, toEventID uuid.UUID, fromDate time.Time) error
+	
+	// Rotations
+	CreateRotation(rotation *entity.EventPICRotation) error
+	GetRotationByID(id uuid.UUID) (*entity.EventPICRotation, error)
+	GetRotationsByEventID(eventID uuid.UUID) ([]entity.EventPICRotation, error)
+	GetRotationByEventAndRole(eventID uuid.UUID, role string) (*entity.EventPICRotation, error)
+	GetActiveRotations() ([]entity.EventPICRotation, error)
+	UpdateRotation(rotation *entity.EventPICRotation) error
+	ReplaceRotationMembers(rotationID uuid.UUID, members []entity.EventPICRotationMember) error
+	DeleteRotation(id uuid.UUID) error
+	
 	// PIC Role management
 	CreateRole(role *entity.EventPICRole) error
 	GetRoleByID(id uuid.UUID) (*entity.EventPICRole, error)
@@ -65,6 +85,8 @@ type EventPICFilters struct {
 	Search    string
 	StartDate *time.Time
 	EndDate   *time.Time
+	ChurchID  *uuid.UUID // PICs of events owned by or shared with the church
+	Viewer    *dto.EventViewer
 	Limit     int
 	Offset    int
 }
@@ -169,6 +191,21 @@ func (r *eventPICRepository) GetActivePICsByPersonID(personID uuid.UUID) ([]enti
 	return pics, err
 }
 
+// GetActivePICsByPersonIDInRange returns the person's active assignments whose period overlaps the
+// dates from..to, with the events and their recurrence rules loaded for expanding occurrences
+func (r *eventPICRepository) GetActivePICsByPersonIDInRange(personID uuid.UUID, from, to time.Time) ([]entity.EventPIC, error) {
+	var pics []entity.EventPIC
+	err := r.db.Preload("Event").
+		Preload("Event.RecurrenceRule").
+		Joins("JOIN events ON events.id = event_pics.event_id AND events.deleted_at IS NULL").
+		Where("event_pics.person_id = ? AND event_pics.is_active = ?", personID, true).
+		Where("event_pics.start_date <= ?", to.Format("2006-01-02")).
+		Where("event_pics.end_date IS NULL OR event_pics.end_date >= ?", from.Format("2006-01-02")).
+		Order("event_pics.start_date ASC").
+		Find(&pics).Error
+	return pics, err
+}
+
 // Validation and business logic support
 func (r *eventPICRepository) HasPersonPICRoleForEvent(eventID, personID uuid.UUID) (bool, error) {
 	var count int64
@@ -243,6 +280,14 @@ func (r *eventPICRepository) List(filters EventPICFilters) ([]entity.EventPIC, i
 	if filters.EndDate != nil {
 		query = query.Where("end_date IS NULL OR end_date <= ?", filters.EndDate.Format("2006-01-02"))
 	}
+	if filters.ChurchID != nil {
+		query = query.Where("event_pics.event_id IN (?)",
+			r.db.Model(&entity.Event{}).Select("id").Where(eventOfChurch, *filters.ChurchID, *filters.ChurchID))
+	}
+	if filters.Viewer != nil {
+		query = query.Where("event_pics.event_id IN (?)",
+			r.db.Model(&entity.Event{}).Select("id").Where(eventVisibleTo, eventViewerArgs(filters.Viewer)...))
+	}
 
 	// Count total records
 	if err := query.Count(&count).Error; err != nil {
@@ -265,6 +310,7 @@ func (r *eventPICRepository) GetPICsWithPermission(eventID uuid.UUID, permission
 	var pics []entity.EventPIC
 	query := r.db.Preload("Person").
 		Where("event_id = ? AND is_active = ?", eventID, true).
+		Where("start_date <= ?", time.Now().Format("2006-01-02")).
 		Where("end_date IS NULL OR end_date >= ?", time.Now().Format("2006-01-02"))
 
 	switch permission {
@@ -280,13 +326,17 @@ func (r *eventPICRepository) GetPICsWithPermission(eventID uuid.UUID, permission
 	return pics, err
 }
 
-func (r *eventPICRepository) GetExpiringPICs(days int) ([]entity.EventPIC, error) {
+func (r *eventPICRepository) GetExpiringPICs(days int, viewer *dto.EventViewer) ([]entity.EventPIC, error) {
 	var pics []entity.EventPIC
 	cutoffDate := time.Now().AddDate(0, 0, days)
 	
-	err := r.db.Preload("Event").Preload("Person").
-		Where("is_active = ? AND end_date IS NOT NULL AND end_date <= ?", true, cutoffDate.Format("2006-01-02")).
-		Find(&pics).Error
+	query := r.db.Preload("Event").Preload("Person").
+		Where("is_active = ? AND end_date IS NOT NULL AND end_date <= ?", true, cutoffDate.Format("2006-01-02"))
+	if viewer != nil {
+		query = query.Where("event_pics.event_id IN (?)",
+			r.db.Model(&entity.Event{}).Select("id").Where(eventVisibleTo, eventViewerArgs(viewer)...))
+	}
+	err := query.Find(&pics).Error
 	return pics, err
 }
 
@@ -379,6 +429,161 @@ func (r *eventPICRepository) TransferPICRole(fromPersonID, toPersonID, eventID u
 	})
 }
 
+// Occurrence-level assignments
+
+// SaveOccurrencePIC assigns the role on the occurrence, replacing whoever held it
+func (r *eventPICRepository) SaveOccurrencePIC(pic *entity.EventOccurrencePIC) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND occurrence_date = ? AND role = ?",
+			pic.EventID, pic.OccurrenceDate.Format("2006-01-02"), pic.Role).
+			Delete(&entity.EventOccurrencePIC{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(pic).Error
+	})
+}
+
+func (r *eventPICRepository) GetOccurrencePICByID(id uuid.UUID) (*entity.EventOccurrencePIC, error) {
+	var pic entity.EventOccurrencePIC
+	err := r.db.Preload("Person").First(&pic, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pic, nil
+}
+
+// GetOccurrencePICs returns the occurrence assignments of an event on the dates from..to
+func (r *eventPICRepository) GetOccurrencePICs(eventID uuid.UUID, from, to time.Time) ([]entity.EventOccurrencePIC, error) {
+	var pics []entity.EventOccurrencePIC
+	err := r.db.Preload("Person").
+		Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("occurrence_date ASC, role ASC").
+		Find(&pics).Error
+	return pics, err
+}
+
+func (r *eventPICRepository) DeleteOccurrencePIC(id uuid.UUID) error {
+	return r.db.Delete(&entity.EventOccurrencePIC{}, "id = ?", id).Error
+}
+
+// SplitOccurrencePICs follows a series split: the rotations of the old series are copied to the new one
+// and the occurrence assignments from fromDate on move across, pointing at the copied rotations
+func (r *eventPICRepository) SplitOccurrencePICs(fromEventID, toEventID uuid.UUID, fromDate time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var rotations []entity.EventPICRotation
+		if err := tx.Preload("Members").Where("event_id = ?", fromEventID).Find(&rotations).Error; err != nil {
+			return err
+		}
+
+		for _, rotation := range rotations {
+			copied := entity.EventPICRotation{
+				EventID:      toEventID,
+				Role:         rotation.Role,
+				Strategy:     rotation.Strategy,
+				FillAhead:    rotation.FillAhead,
+				NextPosition: rotation.NextPosition,
+				IsActive:     rotation.IsActive,
+			}
+			for _, member := range rotation.Members {
+				copied.Members = append(copied.Members, entity.EventPICRotationMember{
+					PersonID: member.PersonID,
+					Position: member.Position,
+				})
+			}
+			if err := tx.Omit("Event").Create(&copied).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&entity.EventOccurrencePIC{}).
+				Where("event_id = ? AND occurrence_date >= ? AND rotation_id = ?", fromEventID, fromDate.Format("2006-01-02"), rotation.ID).
+				Updates(map[string]interface{}{"event_id": toEventID, "rotation_id": copied.ID}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.EventOccurrencePIC{}).
+			Where("event_id = ? AND occurrence_date >= ?", fromEventID, fromDate.Format("2006-01-02")).
+			Update("event_id", toEventID).Error
+	})
+}
+
+// Rotations
+func (r *eventPICRepository) CreateRotation(rotation *entity.EventPICRotation) error {
+	return r.db.Omit("Event").Create(rotation).Error
+}
+
+func (r *eventPICRepository) GetRotationByID(id uuid.UUID) (*entity.EventPICRotation, error) {
+	var rotation entity.EventPICRotation
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Members.Person").First(&rotation, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rotation, nil
+}
+
+func (r *eventPICRepository) GetRotationsByEventID(eventID uuid.UUID) ([]entity.EventPICRotation, error) {
+	var rotations []entity.EventPICRotation
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Members.Person").
+		Where("event_id = ?", eventID).
+		Order("role ASC").
+		Find(&rotations).Error
+	return rotations, err
+}
+
+func (r *eventPICRepository) GetRotationByEventAndRole(eventID uuid.UUID, role string) (*entity.EventPICRotation, error) {
+	var rotation entity.EventPICRotation
+	err := r.db.Where("event_id = ? AND role = ?", eventID, role).First(&rotation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rotation, nil
+}
+
+// GetActiveRotations returns the active rotations of events that are not deleted, for the periodic fill
+func (r *eventPICRepository) GetActiveRotations() ([]entity.EventPICRotation, error) {
+	var rotations []entity.EventPICRotation
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).
+		Joins("JOIN events ON events.id = event_pic_rotations.event_id AND events.deleted_at IS NULL").
+		Where("event_pic_rotations.is_active = ?", true).
+		Find(&rotations).Error
+	return rotations, err
+}
+
+func (r *eventPICRepository) UpdateRotation(rotation *entity.EventPICRotation) error {
+	return r.db.Omit(clause.Associations).Save(rotation).Error
+}
+
+// ReplaceRotationMembers swaps the pool of a rotation for a new ordered list of people
+func (r *eventPICRepository) ReplaceRotationMembers(rotationID uuid.UUID, members []entity.EventPICRotationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rotation_id = ?", rotationID).Delete(&entity.EventPICRotationMember{}).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		for i := range members {
+			members[i].RotationID = rotationID
+		}
+		return tx.Omit(clause.Associations).Create(&members).Error
+	})
+}
+
+func (r *eventPICRepository) DeleteRotation(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rotation_id = ?", id).Delete(&entity.EventPICRotationMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.EventPICRotation{}, "id = ?", id).Error
+	})
+}
+
 // PIC Role management
 func (r *eventPICRepository) CreateRole(role *entity.EventPICRole) error {
 	return r.db.Create(role).Error
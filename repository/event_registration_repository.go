@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventRegistrationRepository interface {
+	// Capacity-aware operations; each runs in a transaction that locks the event row
+	Register(registration *entity.EventRegistration, capacity int) error
+	Cancel(id uuid.UUID) (*entity.EventRegistration, *entity.EventRegistration, error)
+	Promote(id uuid.UUID, capacity int) (*entity.EventRegistration, error)
+	FillFromWaitlist(eventID uuid.UUID, occurrenceDate time.Time, capacity int) ([]entity.EventRegistration, error)
+
+	GetByID(id uuid.UUID) (*entity.EventRegistration, error)
+	Update(registration *entity.EventRegistration) error
+	List(filters EventRegistrationFilters) ([]entity.EventRegistration, int64, error)
+	GetByPersonID(personID uuid.UUID) ([]entity.EventRegistration, error)
+	FindActive(eventID uuid.UUID, occurrenceDate time.Time, personID, visitorID *uuid.UUID) (*entity.EventRegistration, error)
+	CountByStatus(eventID uuid.UUID, occurrenceDate time.Time) (map[string]int64, error)
+	WaitlistPosition(registration *entity.EventRegistration) (int, error)
+}
+
+type EventRegistrationFilters struct {
+	EventID        *uuid.UUID
+	OccurrenceDate *time.Time
+	Status         string
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+type eventRegistrationRepository struct {
+	db *gorm.DB
+}
+
+// ErrOccurrenceFull is returned when a waitlisted registration cannot be promoted
+var ErrOccurrenceFull = errors.New("occurrence is full")
+
+// ErrAlreadyRegistered is returned when the registrant already holds a seat or a waitlist place
+var ErrAlreadyRegistered = errors.New("already registered for this occurrence")
+
+// ErrNotWaitlisted is returned when promoting a registration that is not on the waitlist
+var ErrNotWaitlisted = errors.New("only waitlisted registrations can be promoted")
+
+func NewEventRegistrationRepository(db *gorm.DB) EventRegistrationRepository {
+	return &eventRegistrationRepository{db: db}
+}
+
+// Register stores the registration as registered while seats are left, otherwise as waitlisted. The
+// duplicate check runs under the event lock so that two concurrent requests cannot both register.
+func (r *eventRegistrationRepository) Register(registration *entity.EventRegistration, capacity int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockEvent(tx, registration.EventID); err != nil {
+			return err
+		}
+
+		_, err := r.findActive(tx, registration.EventID, registration.OccurrenceDate, registration.PersonID, registration.VisitorID)
+		if err == nil {
+			return ErrAlreadyRegistered
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		registered, err := r.countRegistered(tx, registration.EventID, registration.OccurrenceDate)
+		if err != nil {
+			return err
+		}
+
+		registration.Status = entity.EventRegistrationStatusRegistered
+		if capacity > 0 && registered >= int64(capacity) {
+			registration.Status = entity.EventRegistrationStatusWaitlisted
+		}
+
+		return tx.Create(registration).Error
+	})
+}
+
+// Cancel cancels the registration and, when it held a seat, promotes the first waitlisted registration.
+// It returns the cancelled registration and the promoted one (nil if nobody was waiting).
+func (r *eventRegistrationRepository) Cancel(id uuid.UUID) (*entity.EventRegistration, *entity.EventRegistration, error) {
+	var cancelled entity.EventRegistration
+	var promoted *entity.EventRegistration
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&cancelled, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := r.lockEvent(tx, cancelled.EventID); err != nil {
+			return err
+		}
+		// Re-read under the lock so a concurrent cancel is not processed twice
+		if err := tx.First(&cancelled, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if cancelled.Status == entity.EventRegistrationStatusCancelled {
+			return nil
+		}
+
+		heldSeat := cancelled.Status == entity.EventRegistrationStatusRegistered
+		now := time.Now()
+		cancelled.Status = entity.EventRegistrationStatusCancelled
+		cancelled.CancelledAt = &now
+		if err := tx.Save(&cancelled).Error; err != nil {
+			return err
+		}
+
+		if !heldSeat {
+			return nil
+		}
+
+		var next entity.EventRegistration
+		err := tx.Where("event_id = ? AND occurrence_date = ? AND status = ?",
+			cancelled.EventID, cancelled.OccurrenceDate.Format("2006-01-02"), entity.EventRegistrationStatusWaitlisted).
+			Order("created_at ASC, id ASC").
+			First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		next.Status = entity.EventRegistrationStatusRegistered
+		next.PromotedAt = &now
+		if err := tx.Save(&next).Error; err != nil {
+			return err
+		}
+		promoted = &next
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &cancelled, promoted, nil
+}
+
+// Promote moves a waitlisted registration to registered if a seat is free
+func (r *eventRegistrationRepository) Promote(id uuid.UUID, capacity int) (*entity.EventRegistration, error) {
+	var registration entity.EventRegistration
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&registration, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := r.lockEvent(tx, registration.EventID); err != nil {
+			return err
+		}
+		// Re-read under the lock so a registration cancelled or promoted meanwhile is not promoted
+		if err := tx.First(&registration, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if registration.Status != entity.EventRegistrationStatusWaitlisted {
+			return ErrNotWaitlisted
+		}
+
+		registered, err := r.countRegistered(tx, registration.EventID, registration.OccurrenceDate)
+		if err != nil {
+			return err
+		}
+		if capacity > 0 && registered >= int64(capacity) {
+			return ErrOccurrenceFull
+		}
+
+		now := time.Now()
+		registration.Status = entity.EventRegistrationStatusRegistered
+		registration.PromotedAt = &now
+		return tx.Save(&registration).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+// FillFromWaitlist promotes waitlisted registrations in order until the occurrence is full
+func (r *eventRegistrationRepository) FillFromWaitlist(eventID uuid.UUID, occurrenceDate time.Time, capacity int) ([]entity.EventRegistration, error) {
+	var promoted []entity.EventRegistration
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockEvent(tx, eventID); err != nil {
+			return err
+		}
+
+		registered, err := r.countRegistered(tx, eventID, occurrenceDate)
+		if err != nil {
+			return err
+		}
+
+		query := tx.Where("event_id = ? AND occurrence_date = ? AND status = ?",
+			eventID, occurrenceDate.Format("2006-01-02"), entity.EventRegistrationStatusWaitlisted).
+			Order("created_at ASC, id ASC")
+		if capacity > 0 {
+			free := int64(capacity) - registered
+			if free <= 0 {
+				return nil
+			}
+			query = query.Limit(int(free))
+		}
+
+		var waiting []entity.EventRegistration
+		if err := query.Find(&waiting).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range waiting {
+			waiting[i].Status = entity.EventRegistrationStatusRegistered
+			waiting[i].PromotedAt = &now
+			if err := tx.Save(&waiting[i]).Error; err != nil {
+				return err
+			}
+		}
+		promoted = waiting
+		return nil
+	})
+
+	return promoted, err
+}
+
+func (r *eventRegistrationRepository) GetByID(id uuid.UUID) (*entity.EventRegistration, error) {
+	var registration entity.EventRegistration
+	err := r.db.Preload("Event").
+		Preload("Person").
+		Preload("Visitor").
+		First(&registration, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+func (r *eventRegistrationRepository) Update(registration *entity.EventRegistration) error {
+	return r.db.Omit(clause.Associations).Save(registration).Error
+}
+
+func (r *eventRegistrationRepository) List(filters EventRegistrationFilters) ([]entity.EventRegistration, int64, error) {
+	var registrations []entity.EventRegistration
+	var count int64
+
+	query := r.db.Model(&entity.EventRegistration{}).
+		Preload("Person").
+		Preload("Visitor")
+
+	if filters.EventID != nil {
+		query = query.Where("event_registrations.event_id = ?", *filters.EventID)
+	}
+	if filters.OccurrenceDate != nil {
+		query = query.Where("event_registrations.occurrence_date = ?", filters.OccurrenceDate.Format("2006-01-02"))
+	}
+	if filters.Status != "" {
+		query = query.Where("event_registrations.status = ?", filters.Status)
+	}
+	if filters.Search != "" {
+		query = query.Joins("LEFT JOIN people ON event_registrations.person_id = people.id").
+			Joins("LEFT JOIN visitors ON event_registrations.visitor_id = visitors.id").
+			Where("people.nama LIKE ? OR people.email LIKE ? OR visitors.name LIKE ?",
+				"%"+filters.Search+"%", "%"+filters.Search+"%", "%"+filters.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	// Registered first, then the waitlist in the order it will be promoted
+	err := query.Order("FIELD(event_registrations.status, 'registered', 'waitlisted', 'cancelled')").
+		Order("event_registrations.created_at ASC, event_registrations.id ASC").
+		Find(&registrations).Error
+	return registrations, count, err
+}
+
+func (r *eventRegistrationRepository) GetByPersonID(personID uuid.UUID) ([]entity.EventRegistration, error) {
+	var registrations []entity.EventRegistration
+	err := r.db.Preload("Event").
+		Where("person_id = ?", personID).
+		Order("occurrence_date DESC, created_at DESC").
+		Find(&registrations).Error
+	return registrations, err
+}
+
+func (r *eventRegistrationRepository) FindActive(eventID uuid.UUID, occurrenceDate time.Time, personID, visitorID *uuid.UUID) (*entity.EventRegistration, error) {
+	return r.findActive(r.db, eventID, occurrenceDate, personID, visitorID)
+}
+
+func (r *eventRegistrationRepository) findActive(db *gorm.DB, eventID uuid.UUID, occurrenceDate time.Time, personID, visitorID *uuid.UUID) (*entity.EventRegistration, error) {
+	var registration entity.EventRegistration
+	query := db.Where("event_id = ? AND occurrence_date = ? AND status <> ?",
+		eventID, occurrenceDate.Format("2006-01-02"), entity.EventRegistrationStatusCancelled)
+	if personID != nil {
+		query = query.Where("person_id = ?", *personID)
+	} else {
+		query = query.Where("visitor_id = ?", visitorID)
+	}
+
+	err := query.First(&registration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+func (r *eventRegistrationRepository) CountByStatus(eventID uuid.UUID, occurrenceDate time.Time) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+	err := r.db.Model(&entity.EventRegistration{}).
+		Select("status, COUNT(*) AS total").
+		Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
+
+// WaitlistPosition returns the 1-based position on the waitlist, or 0 if the registration is not waitlisted
+func (r *eventRegistrationRepository) WaitlistPosition(registration *entity.EventRegistration) (int, error) {
+	if registration.Status != entity.EventRegistrationStatusWaitlisted {
+		return 0, nil
+	}
+
+	var ahead int64
+	err := r.db.Model(&entity.EventRegistration{}).
+		Where("event_id = ? AND occurrence_date = ? AND status = ?",
+			registration.EventID, registration.OccurrenceDate.Format("2006-01-02"), entity.EventRegistrationStatusWaitlisted).
+		Where("created_at < ? OR (created_at = ? AND id < ?)",
+			registration.CreatedAt, registration.CreatedAt, registration.ID).
+		Count(&ahead).Error
+	return int(ahead) + 1, err
+}
+
+// lockEvent serializes capacity checks for all occurrences of an event
+func (r *eventRegistrationRepository) lockEvent(tx *gorm.DB, eventID uuid.UUID) error {
+	var event entity.Event
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&event, "id = ?", eventID).Error
+}
+
+func (r *eventRegistrationRepository) countRegistered(tx *gorm.DB, eventID uuid.UUID, occurrenceDate time.Time) (int64, error) {
+	var count int64
+	err := tx.Model(&entity.EventRegistration{}).
+		Where("event_id = ? AND occurrence_date = ? AND status = ?",
+			eventID, occurrenceDate.Format("2006-01-02"), entity.EventRegistrationStatusRegistered).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventReminderRepository interface {
+	Claim(log *entity.EventReminderLog) (bool, error)
+	Update(log *entity.EventReminderLog) error
+}
+
+type eventReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewEventReminderRepository(db *gorm.DB) EventReminderRepository {
+	return &eventReminderRepository{db: db}
+}
+
+// Claim inserts the log row and reports whether this caller owns the reminder.
+// It returns false when the reminder was already claimed, by this or another instance.
+func (r *eventReminderRepository) Claim(log *entity.EventReminderLog) (bool, error) {
+	result := r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(log)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *eventReminderRepository) Update(log *entity.EventReminderLog) error {
+	return r.db.Omit(clause.Associations).Save(log).Error
+}
@@ -1,15 +1,18 @@
 package repository
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
 	"github.com/zemetia/en-indo-be/entity"
 	"gorm.io/gorm"
 )
 
 type EventRepository interface {
 	Create(event *entity.Event) error
+	CreateWithExceptions(event *entity.Event, churchIDs []uuid.UUID, exceptions []entity.RecurrenceException) error
 	GetByID(id uuid.UUID) (*entity.Event, error)
 	Update(event *entity.Event) error
 	Delete(id uuid.UUID) error
@@ -31,17 +34,44 @@ type EventRepository interface {
 	// Bulk operations for recurring events
 	DeleteFutureOccurrences(eventID uuid.UUID, fromDate time.Time) error
 	SetRecurrenceUntilDate(eventID uuid.UUID, untilDate time.Time) error
+	MoveSetlistItems(fromEventID, toEventID uuid.UUID, fromDate time.Time) error
+	MoveMusicianSchedules(fromEventID, toEventID uuid.UUID, fromDate time.Time) error
+	MoveRundowns(fromEventID, toEventID uuid.UUID, fromDate time.Time) error
 	GetEventsWithRecurrenceInRange(startDate, endDate time.Time) ([]entity.Event, error)
+
+	// Church scoping
+	ReplaceChurches(eventID uuid.UUID, churchIDs []uuid.UUID) error
+	CountChurches(churchIDs []uuid.UUID) (int64, error)
+
+	// Transaction runs fn with event and PIC repositories bound to one transaction, committed only
+	// when fn returns nil
+	Transaction(fn func(eventRepo EventRepository, eventPICRepo EventPICRepository) error) error
 }
 
 type EventFilters struct {
 	Type     string
 	IsPublic *bool
 	Search   string
+	ChurchID *uuid.UUID // owned by or shared with the church
+	Viewer   *dto.EventViewer
 	Limit    int
 	Offset   int
 }
 
+// eventOfChurch is the condition for events owned by or shared with a church, for queries on the
+// events table
+const eventOfChurch = "events.church_id = ? OR events.id IN (SELECT event_id FROM event_churches WHERE church_id = ?)"
+
+// eventVisibleTo is the condition for events a viewer may see, for queries on the events table; its
+// arguments come from eventViewerArgs
+const eventVisibleTo = "events.is_public = ? OR events.church_id IN ? OR " +
+	"events.id IN (SELECT event_id FROM event_churches WHERE church_id IN ?) OR " +
+	"events.id IN (SELECT event_id FROM event_pics WHERE person_id = ? AND deleted_at IS NULL)"
+
+func eventViewerArgs(viewer *dto.EventViewer) []interface{} {
+	return []interface{}{true, viewer.ChurchIDs, viewer.ChurchIDs, viewer.PersonID}
+}
+
 type eventRepository struct {
 	db *gorm.DB
 }
@@ -52,26 +82,68 @@ func NewEventRepository(db *gorm.DB) EventRepository {
 
 func (r *eventRepository) Create(event *entity.Event) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Create recurrence rule first if exists
-		if event.RecurrenceRule != nil {
-			event.RecurrenceRule.ID = uuid.New()
-			if err := tx.Create(event.RecurrenceRule).Error; err != nil {
+		return createEvent(tx, event)
+	})
+}
+
+// CreateWithExceptions creates an event, shares it with churchIDs and stores its recurrence exceptions
+// in one transaction, so a series is never saved without the occurrences it skips or moves
+func (r *eventRepository) CreateWithExceptions(event *entity.Event, churchIDs []uuid.UUID, exceptions []entity.RecurrenceException) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createEvent(tx, event); err != nil {
+			return err
+		}
+
+		if len(churchIDs) > 0 {
+			churches := make([]entity.Church, len(churchIDs))
+			for i, id := range churchIDs {
+				churches[i] = entity.Church{ID: id}
+			}
+			if err := tx.Model(event).Omit("Churches.*").Association("Churches").Replace(churches); err != nil {
+				return err
+			}
+		}
+
+		for i := range exceptions {
+			exceptions[i].ID = uuid.New()
+			exceptions[i].EventID = event.ID
+			if err := tx.Create(&exceptions[i]).Error; err != nil {
 				return err
 			}
-			event.RecurrenceRuleID = &event.RecurrenceRule.ID
 		}
+		return nil
+	})
+}
 
-		// Create the event
-		event.ID = uuid.New()
-		return tx.Create(event).Error
+func (r *eventRepository) Transaction(fn func(eventRepo EventRepository, eventPICRepo EventPICRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewEventRepository(tx), NewEventPICRepository(tx))
 	})
 }
 
+func createEvent(tx *gorm.DB, event *entity.Event) error {
+	// Create recurrence rule first if exists
+	if event.RecurrenceRule != nil {
+		event.RecurrenceRule.ID = uuid.New()
+		if err := tx.Create(event.RecurrenceRule).Error; err != nil {
+			return err
+		}
+		event.RecurrenceRuleID = &event.RecurrenceRule.ID
+	}
+
+	// Create the event
+	event.ID = uuid.New()
+	return tx.Create(event).Error
+}
+
 func (r *eventRepository) GetByID(id uuid.UUID) (*entity.Event, error) {
 	var event entity.Event
 	err := r.db.Preload("RecurrenceRule").
 		Preload("Lagu").
 		Preload("DiscipleshipJourney").
+		Preload("Venue").
+		Preload("Church").
+		Preload("Churches").
 		Preload("EventPICs").
 		Preload("EventPICs.Person").
 		First(&event, id).Error
@@ -107,8 +179,8 @@ func (r *eventRepository) Update(event *entity.Event) error {
 			event.RecurrenceRuleID = nil
 		}
 
-		// Update the event
-		return tx.Save(event).Error
+		// Shared churches are replaced through ReplaceChurches, not upserted with the event
+		return tx.Omit("Churches").Save(event).Error
 	})
 }
 
@@ -145,6 +217,9 @@ func (r *eventRepository) List(filters EventFilters) ([]entity.Event, int64, err
 		Preload("RecurrenceRule").
 		Preload("Lagu").
 		Preload("DiscipleshipJourney").
+		Preload("Venue").
+		Preload("Church").
+		Preload("Churches").
 		Preload("EventPICs").
 		Preload("EventPICs.Person")
 
@@ -155,6 +230,12 @@ func (r *eventRepository) List(filters EventFilters) ([]entity.Event, int64, err
 	if filters.IsPublic != nil {
 		query = query.Where("is_public = ?", *filters.IsPublic)
 	}
+	if filters.ChurchID != nil {
+		query = query.Where(eventOfChurch, *filters.ChurchID, *filters.ChurchID)
+	}
+	if filters.Viewer != nil {
+		query = query.Where(eventVisibleTo, eventViewerArgs(filters.Viewer)...)
+	}
 	if filters.Search != "" {
 		query = query.Where(
 			"title ILIKE ? OR description ILIKE ? OR event_location ILIKE ?",
@@ -186,6 +267,9 @@ func (r *eventRepository) GetByDateRange(startDate, endDate time.Time, filters E
 		Preload("RecurrenceRule").
 		Preload("Lagu").
 		Preload("DiscipleshipJourney").
+		Preload("Venue").
+		Preload("Church").
+		Preload("Churches").
 		Preload("EventPICs").
 		Preload("EventPICs.Person").
 		Where("event_date >= ? AND event_date <= ?", startDate, endDate)
@@ -197,6 +281,12 @@ func (r *eventRepository) GetByDateRange(startDate, endDate time.Time, filters E
 	if filters.IsPublic != nil {
 		query = query.Where("is_public = ?", *filters.IsPublic)
 	}
+	if filters.ChurchID != nil {
+		query = query.Where(eventOfChurch, *filters.ChurchID, *filters.ChurchID)
+	}
+	if filters.Viewer != nil {
+		query = query.Where(eventVisibleTo, eventViewerArgs(filters.Viewer)...)
+	}
 	if filters.Search != "" {
 		query = query.Where(
 			"title ILIKE ? OR description ILIKE ? OR event_location ILIKE ?",
@@ -266,9 +356,10 @@ func (r *eventRepository) DeleteFutureOccurrences(eventID uuid.UUID, fromDate ti
 			return nil // No recurrence to handle
 		}
 
-		// Update recurrence rule to end at the given date
+		// Update recurrence rule to end at the given date; UNTIL replaces COUNT
 		yesterday := fromDate.AddDate(0, 0, -1)
 		event.RecurrenceRule.Until = &yesterday
+		event.RecurrenceRule.Count = nil
 
 		return tx.Save(event.RecurrenceRule).Error
 	})
@@ -286,13 +377,97 @@ func (r *eventRepository) SetRecurrenceUntilDate(eventID uuid.UUID, untilDate ti
 			return nil // No recurrence to handle
 		}
 
-		// Update recurrence rule to end at the given date
+		// Update recurrence rule to end at the given date, dropping the extra dates after it. UNTIL
+		// replaces COUNT, which a rule cannot have alongside it.
 		event.RecurrenceRule.Until = &untilDate
+		event.RecurrenceRule.Count = nil
+		if event.RecurrenceRule.RDates != "" {
+			var rdates, kept []string
+			if err := json.Unmarshal([]byte(event.RecurrenceRule.RDates), &rdates); err != nil {
+				return err
+			}
+			for _, rdate := range rdates {
+				if len(rdate) >= 10 && rdate[:10] <= untilDate.Format("2006-01-02") {
+					kept = append(kept, rdate)
+				}
+			}
+			event.RecurrenceRule.RDates = ""
+			if len(kept) > 0 {
+				encoded, err := json.Marshal(kept)
+				if err != nil {
+					return err
+				}
+				event.RecurrenceRule.RDates = string(encoded)
+			}
+		}
 
 		return tx.Save(event.RecurrenceRule).Error
 	})
 }
 
+// MoveSetlistItems hands the setlists of the occurrences on or after fromDate to another series
+func (r *eventRepository) MoveSetlistItems(fromEventID, toEventID uuid.UUID, fromDate time.Time) error {
+	return r.db.Model(&entity.EventSetlistItem{}).
+		Where("event_id = ? AND occurrence_date >= ?", fromEventID, fromDate.Format("2006-01-02")).
+		Update("event_id", toEventID).Error
+}
+
+// MoveMusicianSchedules gives another series a copy of the instruments needed and hands it the
+// musician availability and rosters of the occurrences on or after fromDate
+func (r *eventRepository) MoveMusicianSchedules(fromEventID, toEventID uuid.UUID, fromDate time.Time) error {
+	date := fromDate.Format("2006-01-02")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var kebutuhan []entity.KebutuhanPemusik
+		if err := tx.Where("event_id = ?", fromEventID).Find(&kebutuhan).Error; err != nil {
+			return err
+		}
+		if len(kebutuhan) > 0 {
+			copies := make([]entity.KebutuhanPemusik, len(kebutuhan))
+			for i, k := range kebutuhan {
+				copies[i] = entity.KebutuhanPemusik{EventID: toEventID, AlatMusik: k.AlatMusik, Jumlah: k.Jumlah}
+			}
+			if err := tx.Create(&copies).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entity.KetersediaanPemusik{}).
+			Where("event_id = ? AND occurrence_date >= ?", fromEventID, date).
+			Update("event_id", toEventID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.JadwalPemusik{}).
+			Where("event_id = ? AND occurrence_date >= ?", fromEventID, date).
+			Update("event_id", toEventID).Error
+	})
+}
+
+// MoveRundowns gives another series a copy of the run-sheet template and hands it the run-sheets of
+// the occurrences on or after fromDate
+func (r *eventRepository) MoveRundowns(fromEventID, toEventID uuid.UUID, fromDate time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var template []entity.EventRundownTemplateSegment
+		if err := tx.Where("event_id = ?", fromEventID).Find(&template).Error; err != nil {
+			return err
+		}
+		if len(template) > 0 {
+			copies := make([]entity.EventRundownTemplateSegment, len(template))
+			for i, segment := range template {
+				segment.ID = uuid.Nil
+				segment.EventID = toEventID
+				copies[i] = segment
+			}
+			if err := tx.Create(&copies).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.EventRundownSegment{}).
+			Where("event_id = ? AND occurrence_date >= ?", fromEventID, fromDate.Format("2006-01-02")).
+			Update("event_id", toEventID).Error
+	})
+}
+
 func (r *eventRepository) GetEventsWithRecurrenceInRange(startDate, endDate time.Time) ([]entity.Event, error) {
 	var events []entity.Event
 
@@ -301,6 +476,9 @@ func (r *eventRepository) GetEventsWithRecurrenceInRange(startDate, endDate time
 	err := r.db.Preload("RecurrenceRule").
 		Preload("Lagu").
 		Preload("DiscipleshipJourney").
+		Preload("Venue").
+		Preload("Church").
+		Preload("Churches").
 		Preload("EventPICs").
 		Preload("EventPICs.Person").
 		Joins("LEFT JOIN recurrence_rules ON events.recurrence_rule_id = recurrence_rules.id").
@@ -312,3 +490,19 @@ func (r *eventRepository) GetEventsWithRecurrenceInRange(startDate, endDate time
 
 	return events, err
 }
+
+// ReplaceChurches makes churchIDs the churches the event is shared with, besides its owner
+func (r *eventRepository) ReplaceChurches(eventID uuid.UUID, churchIDs []uuid.UUID) error {
+	churches := make([]entity.Church, len(churchIDs))
+	for i, id := range churchIDs {
+		churches[i] = entity.Church{ID: id}
+	}
+	return r.db.Model(&entity.Event{ID: eventID}).Omit("Churches.*").Association("Churches").Replace(churches)
+}
+
+// CountChurches counts how many of churchIDs are existing churches
+func (r *eventRepository) CountChurches(churchIDs []uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Church{}).Where("id IN ?", churchIDs).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventRundownRepository interface {
+	// Occurrence run-sheets
+	GetOccurrenceSegments(eventID uuid.UUID, occurrenceDate time.Time) ([]entity.EventRundownSegment, error)
+	ReplaceOccurrenceSegments(eventID uuid.UUID, occurrenceDate time.Time, segments []entity.EventRundownSegment) error
+	GetPlannedOccurrenceDates(eventID uuid.UUID, from, to time.Time) ([]time.Time, error)
+
+	// Run-sheet template
+	GetTemplateSegments(eventID uuid.UUID) ([]entity.EventRundownTemplateSegment, error)
+	ReplaceTemplateSegments(eventID uuid.UUID, segments []entity.EventRundownTemplateSegment) error
+}
+
+type eventRundownRepository struct {
+	db *gorm.DB
+}
+
+func NewEventRundownRepository(db *gorm.DB) EventRundownRepository {
+	return &eventRundownRepository{db: db}
+}
+
+// Occurrence run-sheets
+
+// GetOccurrenceSegments returns the run-sheet of one occurrence in order
+func (r *eventRundownRepository) GetOccurrenceSegments(eventID uuid.UUID, occurrenceDate time.Time) ([]entity.EventRundownSegment, error) {
+	var segments []entity.EventRundownSegment
+	err := r.db.Preload("Person").
+		Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+		Order("position ASC").
+		Find(&segments).Error
+	return segments, err
+}
+
+// ReplaceOccurrenceSegments makes segments, numbered in order, the run-sheet of one occurrence
+func (r *eventRundownRepository) ReplaceOccurrenceSegments(eventID uuid.UUID, occurrenceDate time.Time, segments []entity.EventRundownSegment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+			Delete(&entity.EventRundownSegment{}).Error; err != nil {
+			return err
+		}
+		if len(segments) == 0 {
+			return nil
+		}
+
+		for i := range segments {
+			segments[i].EventID = eventID
+			segments[i].OccurrenceDate = occurrenceDate
+			segments[i].Position = i + 1
+		}
+		return tx.Omit(clause.Associations).Create(&segments).Error
+	})
+}
+
+// GetPlannedOccurrenceDates returns the dates between from and to whose occurrence has a run-sheet
+func (r *eventRundownRepository) GetPlannedOccurrenceDates(eventID uuid.UUID, from, to time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	err := r.db.Model(&entity.EventRundownSegment{}).
+		Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Distinct().
+		Pluck("occurrence_date", &dates).Error
+	return dates, err
+}
+
+// Run-sheet template
+func (r *eventRundownRepository) GetTemplateSegments(eventID uuid.UUID) ([]entity.EventRundownTemplateSegment, error) {
+	var segments []entity.EventRundownTemplateSegment
+	err := r.db.Preload("Person").
+		Where("event_id = ?", eventID).
+		Order("position ASC").
+		Find(&segments).Error
+	return segments, err
+}
+
+// ReplaceTemplateSegments makes segments, numbered in order, the run-sheet template of the event
+func (r *eventRundownRepository) ReplaceTemplateSegments(eventID uuid.UUID, segments []entity.EventRundownTemplateSegment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", eventID).Delete(&entity.EventRundownTemplateSegment{}).Error; err != nil {
+			return err
+		}
+		if len(segments) == 0 {
+			return nil
+		}
+
+		for i := range segments {
+			segments[i].EventID = eventID
+			segments[i].Position = i + 1
+		}
+		return tx.Omit(clause.Associations).Create(&segments).Error
+	})
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventTemplateRepository interface {
+	Create(template *entity.EventTemplate) error
+	GetByID(id uuid.UUID) (*entity.EventTemplate, error)
+	Update(template *entity.EventTemplate) error
+	ReplacePICs(templateID uuid.UUID, pics []entity.EventTemplatePIC) error
+	Delete(id uuid.UUID) error
+	List(filters EventTemplateFilters) ([]entity.EventTemplate, int64, error)
+
+	// What an event holds between two occurrence dates, to be saved into a template
+	GetSetlistItemsInRange(eventID uuid.UUID, from, to time.Time) ([]entity.EventSetlistItem, error)
+	GetRundownSegmentsInRange(eventID uuid.UUID, from, to time.Time) ([]entity.EventRundownSegment, error)
+
+	// Everything an event created from a template gets besides the event itself
+	CreateEventContent(content *EventTemplateContent) error
+}
+
+type EventTemplateFilters struct {
+	Type     string
+	Search   string
+	ChurchID *uuid.UUID
+	Viewer   *dto.EventViewer // templates of no church or of one of the viewer's churches
+	Limit    int
+	Offset   int
+}
+
+// EventTemplateContent is what is copied from a template into a newly created event
+type EventTemplateContent struct {
+	PICs            []entity.EventPIC
+	SetlistItems    []entity.EventSetlistItem
+	RundownSegments []entity.EventRundownSegment
+	RundownTemplate []entity.EventRundownTemplateSegment
+}
+
+type eventTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewEventTemplateRepository(db *gorm.DB) EventTemplateRepository {
+	return &eventTemplateRepository{db: db}
+}
+
+// Create saves a template together with its PICs, setlists and run-sheets
+func (r *eventTemplateRepository) Create(template *entity.EventTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(template).Error; err != nil {
+			return err
+		}
+		for i := range template.PICs {
+			template.PICs[i].TemplateID = template.ID
+		}
+		for i := range template.SetlistItems {
+			template.SetlistItems[i].TemplateID = template.ID
+		}
+		for i := range template.RundownSegments {
+			template.RundownSegments[i].TemplateID = template.ID
+		}
+
+		if len(template.PICs) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&template.PICs).Error; err != nil {
+				return err
+			}
+		}
+		if len(template.SetlistItems) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&template.SetlistItems).Error; err != nil {
+				return err
+			}
+		}
+		if len(template.RundownSegments) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&template.RundownSegments).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *eventTemplateRepository) GetByID(id uuid.UUID) (*entity.EventTemplate, error) {
+	var template entity.EventTemplate
+	err := r.db.Preload("Venue").
+		Preload("PICs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, role ASC")
+		}).
+		Preload("PICs.Person").
+		Preload("SetlistItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("day_offset ASC, position ASC")
+		}).
+		Preload("SetlistItems.Lagu").
+		Preload("SetlistItems.SongLeader").
+		Preload("RundownSegments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("day_offset IS NOT NULL, day_offset ASC, position ASC")
+		}).
+		Preload("RundownSegments.Person").
+		First(&template, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Update saves the template's own fields; PICs, setlists and run-sheets are left as they are
+func (r *eventTemplateRepository) Update(template *entity.EventTemplate) error {
+	return r.db.Omit(clause.Associations).Save(template).Error
+}
+
+func (r *eventTemplateRepository) ReplacePICs(templateID uuid.UUID, pics []entity.EventTemplatePIC) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", templateID).Delete(&entity.EventTemplatePIC{}).Error; err != nil {
+			return err
+		}
+		if len(pics) == 0 {
+			return nil
+		}
+		for i := range pics {
+			pics[i].TemplateID = templateID
+		}
+		return tx.Omit(clause.Associations).Create(&pics).Error
+	})
+}
+
+func (r *eventTemplateRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&entity.EventTemplate{}, "id = ?", id).Error
+}
+
+func (r *eventTemplateRepository) List(filters EventTemplateFilters) ([]entity.EventTemplate, int64, error) {
+	var templates []entity.EventTemplate
+	var count int64
+
+	query := r.db.Model(&entity.EventTemplate{})
+
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+	if filters.Search != "" {
+		query = query.Where("name LIKE ? OR title LIKE ?", "%"+filters.Search+"%", "%"+filters.Search+"%")
+	}
+	if filters.ChurchID != nil {
+		query = query.Where("church_id = ?", *filters.ChurchID)
+	}
+	if filters.Viewer != nil {
+		query = query.Where("church_id IS NULL OR church_id IN ?", filters.Viewer.ChurchIDs)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Order("name ASC").Find(&templates).Error
+	return templates, count, err
+}
+
+func (r *eventTemplateRepository) GetSetlistItemsInRange(eventID uuid.UUID, from, to time.Time) ([]entity.EventSetlistItem, error) {
+	var items []entity.EventSetlistItem
+	err := r.db.Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("occurrence_date ASC, position ASC").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *eventTemplateRepository) GetRundownSegmentsInRange(eventID uuid.UUID, from, to time.Time) ([]entity.EventRundownSegment, error) {
+	var segments []entity.EventRundownSegment
+	err := r.db.Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("occurrence_date ASC, position ASC").
+		Find(&segments).Error
+	return segments, err
+}
+
+func (r *eventTemplateRepository) CreateEventContent(content *EventTemplateContent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(content.PICs) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&content.PICs).Error; err != nil {
+				return err
+			}
+		}
+		if len(content.SetlistItems) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&content.SetlistItems).Error; err != nil {
+				return err
+			}
+		}
+		if len(content.RundownSegments) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&content.RundownSegments).Error; err != nil {
+				return err
+			}
+		}
+		if len(content.RundownTemplate) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&content.RundownTemplate).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
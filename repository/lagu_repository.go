@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LaguRepository interface {
+	// Songs
+	Create(lagu *entity.Lagu) error
+	GetByID(id uuid.UUID) (*entity.Lagu, error)
+	GetByIDs(ids []uuid.UUID) ([]entity.Lagu, error)
+	Update(lagu *entity.Lagu) error
+	ReplaceTags(lagu *entity.Lagu, tags []entity.TagLagu) error
+	UpdateSearchText(id uuid.UUID, searchText string) error
+	Delete(id uuid.UUID) error
+	List(filters LaguFilters) ([]entity.Lagu, int64, error)
+	GetByTagID(tagID uuid.UUID) ([]entity.Lagu, error)
+
+	// Tags
+	CreateTag(tag *entity.TagLagu) error
+	GetTagByID(id uuid.UUID) (*entity.TagLagu, error)
+	GetTagsByIDs(ids []uuid.UUID) ([]entity.TagLagu, error)
+	GetTagByName(nama string) (*entity.TagLagu, error)
+	UpdateTag(tag *entity.TagLagu) error
+	DeleteTag(id uuid.UUID) error
+	ListTags(filters TagLaguFilters) ([]entity.TagLagu, int64, error)
+
+	// Occurrence setlists
+	GetOccurrenceSetlist(eventID uuid.UUID, occurrenceDate time.Time) ([]entity.EventSetlistItem, error)
+	ReplaceOccurrenceSetlist(eventID uuid.UUID, occurrenceDate time.Time, items []entity.EventSetlistItem) error
+	GetSongHistory(laguID uuid.UUID, filters SetlistHistoryFilters) ([]entity.EventSetlistItem, int64, error)
+	GetSongUsage(filters SongUsageFilters) ([]SongUsage, error)
+	GetSongUsageOccurrences(filters SongUsageFilters) ([]SongUsageOccurrence, error)
+}
+
+// LaguFilters selects songs. Search must already be folded with utils.FoldSearchText;
+// every word in it has to appear somewhere in the song.
+type LaguFilters struct {
+	Search string
+	Genre  string
+	TagID  *uuid.UUID
+	Limit  int
+	Offset int
+}
+
+type TagLaguFilters struct {
+	Search string
+	Limit  int
+	Offset int
+}
+
+// SetlistHistoryFilters selects the setlist items of a song sung between From and To, both inclusive
+// dates. ChurchID keeps events owned by or shared with the church.
+type SetlistHistoryFilters struct {
+	ChurchID *uuid.UUID
+	From     *time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// SongUsageFilters selects the setlists sung between From and To, both inclusive dates
+type SongUsageFilters struct {
+	ChurchID *uuid.UUID
+	From     time.Time
+	To       time.Time
+}
+
+// SongUsage is how often a song was sung in the setlists selected by SongUsageFilters
+type SongUsage struct {
+	LaguID    uuid.UUID
+	Judul     string
+	Artis     string
+	TimesUsed int
+	LastUsed  time.Time
+}
+
+// SongUsageOccurrence is one occurrence a song was sung at, with the church it was held at: the
+// church selected by SongUsageFilters, or else the church owning the event. ChurchID is nil for
+// events of no church.
+type SongUsageOccurrence struct {
+	LaguID         uuid.UUID
+	Judul          string
+	Artis          string
+	ChurchID       *uuid.UUID
+	ChurchName     string
+	EventID        uuid.UUID
+	EventTitle     string
+	OccurrenceDate time.Time
+}
+
+type laguRepository struct {
+	db *gorm.DB
+}
+
+func NewLaguRepository(db *gorm.DB) LaguRepository {
+	return &laguRepository{db: db}
+}
+
+// Songs
+func (r *laguRepository) Create(lagu *entity.Lagu) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(lagu).Error; err != nil {
+			return err
+		}
+		if len(lagu.TagLagu) == 0 {
+			return nil
+		}
+		return tx.Model(lagu).Association("TagLagu").Append(lagu.TagLagu)
+	})
+}
+
+func (r *laguRepository) GetByID(id uuid.UUID) (*entity.Lagu, error) {
+	var lagu entity.Lagu
+	err := r.db.Preload("TagLagu").First(&lagu, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &lagu, nil
+}
+
+func (r *laguRepository) GetByIDs(ids []uuid.UUID) ([]entity.Lagu, error) {
+	var songs []entity.Lagu
+	err := r.db.Where("id IN ?", ids).Find(&songs).Error
+	return songs, err
+}
+
+func (r *laguRepository) Update(lagu *entity.Lagu) error {
+	return r.db.Omit(clause.Associations).Save(lagu).Error
+}
+
+func (r *laguRepository) ReplaceTags(lagu *entity.Lagu, tags []entity.TagLagu) error {
+	return r.db.Model(lagu).Association("TagLagu").Replace(tags)
+}
+
+func (r *laguRepository) UpdateSearchText(id uuid.UUID, searchText string) error {
+	return r.db.Model(&entity.Lagu{}).Where("id = ?", id).UpdateColumn("search_text", searchText).Error
+}
+
+func (r *laguRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&entity.Lagu{}, "id = ?", id).Error
+}
+
+func (r *laguRepository) List(filters LaguFilters) ([]entity.Lagu, int64, error) {
+	var songs []entity.Lagu
+	var count int64
+
+	query := r.db.Model(&entity.Lagu{})
+
+	for _, word := range strings.Fields(filters.Search) {
+		query = query.Where("lagus.search_text LIKE ?", "%"+word+"%")
+	}
+	if filters.Genre != "" {
+		query = query.Where("LOWER(lagus.genre) = LOWER(?)", filters.Genre)
+	}
+	if filters.TagID != nil {
+		query = query.Where("lagus.id IN (?)",
+			r.db.Table("lagu_tag_lagu").Select("lagu_id").Where("tag_lagu_id = ?", *filters.TagID))
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Preload("TagLagu").Order("lagus.judul ASC").Find(&songs).Error
+	return songs, count, err
+}
+
+// GetByTagID returns the songs carrying a tag, with all their tags, for refreshing their search text
+func (r *laguRepository) GetByTagID(tagID uuid.UUID) ([]entity.Lagu, error) {
+	var songs []entity.Lagu
+	err := r.db.Preload("TagLagu").
+		Where("id IN (?)", r.db.Table("lagu_tag_lagu").Select("lagu_id").Where("tag_lagu_id = ?", tagID)).
+		Find(&songs).Error
+	return songs, err
+}
+
+// Tags
+func (r *laguRepository) CreateTag(tag *entity.TagLagu) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *laguRepository) GetTagByID(id uuid.UUID) (*entity.TagLagu, error) {
+	var tag entity.TagLagu
+	err := r.db.First(&tag, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *laguRepository) GetTagsByIDs(ids []uuid.UUID) ([]entity.TagLagu, error) {
+	var tags []entity.TagLagu
+	err := r.db.Where("id IN ?", ids).Find(&tags).Error
+	return tags, err
+}
+
+func (r *laguRepository) GetTagByName(nama string) (*entity.TagLagu, error) {
+	var tag entity.TagLagu
+	err := r.db.Where("LOWER(nama) = LOWER(?)", nama).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *laguRepository) UpdateTag(tag *entity.TagLagu) error {
+	return r.db.Save(tag).Error
+}
+
+// DeleteTag removes the tag from every song before deleting it
+func (r *laguRepository) DeleteTag(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("lagu_tag_lagu").Where("tag_lagu_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.TagLagu{}, "id = ?", id).Error
+	})
+}
+
+func (r *laguRepository) ListTags(filters TagLaguFilters) ([]entity.TagLagu, int64, error) {
+	var tags []entity.TagLagu
+	var count int64
+
+	query := r.db.Model(&entity.TagLagu{})
+	if filters.Search != "" {
+		query = query.Where("nama LIKE ?", "%"+filters.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Order("nama ASC").Find(&tags).Error
+	return tags, count, err
+}
+
+// Occurrence setlists
+
+// GetOccurrenceSetlist returns the setlist of one occurrence in the order it is sung
+func (r *laguRepository) GetOccurrenceSetlist(eventID uuid.UUID, occurrenceDate time.Time) ([]entity.EventSetlistItem, error) {
+	var items []entity.EventSetlistItem
+	err := r.db.Preload("Lagu", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("SongLeader").
+		Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+		Order("position ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// ReplaceOccurrenceSetlist makes items, numbered in order, the setlist of one occurrence
+func (r *laguRepository) ReplaceOccurrenceSetlist(eventID uuid.UUID, occurrenceDate time.Time, items []entity.EventSetlistItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND occurrence_date = ?", eventID, occurrenceDate.Format("2006-01-02")).
+			Delete(&entity.EventSetlistItem{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		for i := range items {
+			items[i].EventID = eventID
+			items[i].OccurrenceDate = occurrenceDate
+			items[i].Position = i + 1
+		}
+		return tx.Omit(clause.Associations).Create(&items).Error
+	})
+}
+
+// GetSongHistory returns the times a song was sung, most recent first
+func (r *laguRepository) GetSongHistory(laguID uuid.UUID, filters SetlistHistoryFilters) ([]entity.EventSetlistItem, int64, error) {
+	var items []entity.EventSetlistItem
+	var count int64
+
+	query := r.setlistScope(filters.ChurchID).
+		Where("event_setlist_items.lagu_id = ?", laguID).
+		Where("event_setlist_items.occurrence_date <= ?", filters.To.Format("2006-01-02"))
+	if filters.From != nil {
+		query = query.Where("event_setlist_items.occurrence_date >= ?", filters.From.Format("2006-01-02"))
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Preload("Event").Preload("SongLeader").
+		Order("event_setlist_items.occurrence_date DESC, event_setlist_items.position ASC").
+		Find(&items).Error
+	return items, count, err
+}
+
+// GetSongUsage counts the occurrences each song was sung at in the selected setlists, most used first.
+// A reprise in the same occurrence is counted once.
+func (r *laguRepository) GetSongUsage(filters SongUsageFilters) ([]SongUsage, error) {
+	var usage []SongUsage
+	err := r.setlistScope(filters.ChurchID).
+		Joins("JOIN lagus ON lagus.id = event_setlist_items.lagu_id").
+		Where("event_setlist_items.occurrence_date BETWEEN ? AND ?", filters.From.Format("2006-01-02"), filters.To.Format("2006-01-02")).
+		Select("lagus.id AS lagu_id, lagus.judul, lagus.artis, " +
+			"COUNT(DISTINCT event_setlist_items.event_id, event_setlist_items.occurrence_date) AS times_used, " +
+			"MAX(event_setlist_items.occurrence_date) AS last_used").
+		Group("lagus.id, lagus.judul, lagus.artis").
+		Order("times_used DESC, last_used DESC, lagus.judul ASC").
+		Scan(&usage).Error
+	return usage, err
+}
+
+// GetSongUsageOccurrences returns every occurrence each song was sung at in the selected setlists,
+// once per occurrence, ordered by date
+func (r *laguRepository) GetSongUsageOccurrences(filters SongUsageFilters) ([]SongUsageOccurrence, error) {
+	var usage []SongUsageOccurrence
+	query := r.setlistScope(filters.ChurchID).
+		Joins("JOIN lagus ON lagus.id = event_setlist_items.lagu_id").
+		Where("event_setlist_items.occurrence_date BETWEEN ? AND ?", filters.From.Format("2006-01-02"), filters.To.Format("2006-01-02"))
+	// Events shared with the selected church count as held there rather than at their owner
+	if filters.ChurchID != nil {
+		query = query.Joins("JOIN churches ON churches.id = ?", *filters.ChurchID)
+	} else {
+		query = query.Joins("LEFT JOIN churches ON churches.id = events.church_id")
+	}
+	err := query.
+		Select("lagus.id AS lagu_id, lagus.judul, lagus.artis, churches.id AS church_id, churches.name AS church_name, " +
+			"events.id AS event_id, events.title AS event_title, event_setlist_items.occurrence_date").
+		Group("lagus.id, lagus.judul, lagus.artis, churches.id, churches.name, " +
+			"events.id, events.title, event_setlist_items.occurrence_date").
+		Order("event_setlist_items.occurrence_date ASC, events.title ASC, lagus.judul ASC").
+		Scan(&usage).Error
+	return usage, err
+}
+
+// setlistScope selects the setlist items of events that are not deleted, owned by or shared with the
+// church if given.
+// Items of cancelled occurrences are left out.
+func (r *laguRepository) setlistScope(churchID *uuid.UUID) *gorm.DB {
+	cancelled := r.db.Model(&entity.RecurrenceException{}).Select("1").
+		Where("recurrence_exceptions.event_id = event_setlist_items.event_id").
+		Where("recurrence_exceptions.exception_date = event_setlist_items.occurrence_date").
+		Where("recurrence_exceptions.is_skipped = ?", true)
+	query := r.db.Model(&entity.EventSetlistItem{}).
+		Joins("JOIN events ON events.id = event_setlist_items.event_id AND events.deleted_at IS NULL").
+		Where("NOT EXISTS (?)", cancelled)
+	if churchID != nil {
+		query = query.Where(eventOfChurch, *churchID, *churchID)
+	}
+	return query
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PemusikRepository interface {
+	// Instruments every occurrence needs
+	GetKebutuhan(eventID uuid.UUID) ([]entity.KebutuhanPemusik, error)
+	ReplaceKebutuhan(eventID uuid.UUID, kebutuhan []entity.KebutuhanPemusik) error
+
+	// Availability
+	SaveKetersediaan(ketersediaan *entity.KetersediaanPemusik) error
+	GetKetersediaanByID(id uuid.UUID) (*entity.KetersediaanPemusik, error)
+	GetKetersediaan(eventID uuid.UUID, from, to time.Time) ([]entity.KetersediaanPemusik, error)
+	DeleteKetersediaan(id uuid.UUID) error
+
+	// Roster
+	CreateJadwal(jadwal *entity.JadwalPemusik) error
+	GetJadwalByID(id uuid.UUID) (*entity.JadwalPemusik, error)
+	GetJadwal(eventID uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error)
+	GetJadwalByPersonIDs(personIDs []uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error)
+	UpdateJadwal(jadwal *entity.JadwalPemusik) error
+	DeleteJadwal(id uuid.UUID) error
+	ReplaceGeneratedJadwal(eventID uuid.UUID, occurrenceDate time.Time, jadwal []entity.JadwalPemusik) error
+	PublishJadwal(ids []uuid.UUID, publishedAt time.Time) error
+	SyncTerjadwal(eventID uuid.UUID, occurrenceDate time.Time) error
+}
+
+type pemusikRepository struct {
+	db *gorm.DB
+}
+
+func NewPemusikRepository(db *gorm.DB) PemusikRepository {
+	return &pemusikRepository{db: db}
+}
+
+// Instruments every occurrence needs
+func (r *pemusikRepository) GetKebutuhan(eventID uuid.UUID) ([]entity.KebutuhanPemusik, error) {
+	var kebutuhan []entity.KebutuhanPemusik
+	err := r.db.Where("event_id = ?", eventID).Order("alat_musik ASC").Find(&kebutuhan).Error
+	return kebutuhan, err
+}
+
+func (r *pemusikRepository) ReplaceKebutuhan(eventID uuid.UUID, kebutuhan []entity.KebutuhanPemusik) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", eventID).Delete(&entity.KebutuhanPemusik{}).Error; err != nil {
+			return err
+		}
+		if len(kebutuhan) == 0 {
+			return nil
+		}
+		for i := range kebutuhan {
+			kebutuhan[i].EventID = eventID
+		}
+		return tx.Omit(clause.Associations).Create(&kebutuhan).Error
+	})
+}
+
+// Availability
+
+// SaveKetersediaan replaces what the musician said earlier about the same occurrence
+func (r *pemusikRepository) SaveKetersediaan(ketersediaan *entity.KetersediaanPemusik) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND occurrence_date = ? AND person_id = ?",
+			ketersediaan.EventId, ketersediaan.OccurrenceDate.Format("2006-01-02"), ketersediaan.PersonID).
+			Delete(&entity.KetersediaanPemusik{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(ketersediaan).Error
+	})
+}
+
+func (r *pemusikRepository) GetKetersediaanByID(id uuid.UUID) (*entity.KetersediaanPemusik, error) {
+	var ketersediaan entity.KetersediaanPemusik
+	err := r.db.Preload("Person").First(&ketersediaan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ketersediaan, nil
+}
+
+func (r *pemusikRepository) GetKetersediaan(eventID uuid.UUID, from, to time.Time) ([]entity.KetersediaanPemusik, error) {
+	var ketersediaan []entity.KetersediaanPemusik
+	err := r.db.Preload("Person").
+		Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("occurrence_date ASC, created_at ASC").
+		Find(&ketersediaan).Error
+	return ketersediaan, err
+}
+
+func (r *pemusikRepository) DeleteKetersediaan(id uuid.UUID) error {
+	return r.db.Delete(&entity.KetersediaanPemusik{}, "id = ?", id).Error
+}
+
+// Roster
+func (r *pemusikRepository) CreateJadwal(jadwal *entity.JadwalPemusik) error {
+	return r.db.Omit(clause.Associations).Create(jadwal).Error
+}
+
+func (r *pemusikRepository) GetJadwalByID(id uuid.UUID) (*entity.JadwalPemusik, error) {
+	var jadwal entity.JadwalPemusik
+	err := r.db.Preload("Person").Preload("Event").First(&jadwal, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &jadwal, nil
+}
+
+func (r *pemusikRepository) GetJadwal(eventID uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error) {
+	var jadwal []entity.JadwalPemusik
+	err := r.db.Preload("Person").
+		Where("event_id = ? AND occurrence_date BETWEEN ? AND ?", eventID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("occurrence_date ASC, alat_musik ASC, created_at ASC").
+		Find(&jadwal).Error
+	return jadwal, err
+}
+
+// GetJadwalByPersonIDs returns the roster places of the musicians at any event, for balancing their load
+func (r *pemusikRepository) GetJadwalByPersonIDs(personIDs []uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error) {
+	var jadwal []entity.JadwalPemusik
+	if len(personIDs) == 0 {
+		return jadwal, nil
+	}
+	err := r.db.Where("person_id IN ? AND occurrence_date BETWEEN ? AND ?", personIDs, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Find(&jadwal).Error
+	return jadwal, err
+}
+
+func (r *pemusikRepository) UpdateJadwal(jadwal *entity.JadwalPemusik) error {
+	return r.db.Omit(clause.Associations).Save(jadwal).Error
+}
+
+func (r *pemusikRepository) DeleteJadwal(id uuid.UUID) error {
+	return r.db.Delete(&entity.JadwalPemusik{}, "id = ?", id).Error
+}
+
+// ReplaceGeneratedJadwal swaps the generated draft places of an occurrence for new ones; places set
+// by hand and published places stay
+func (r *pemusikRepository) ReplaceGeneratedJadwal(eventID uuid.UUID, occurrenceDate time.Time, jadwal []entity.JadwalPemusik) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND occurrence_date = ? AND is_manual = ? AND status = ?",
+			eventID, occurrenceDate.Format("2006-01-02"), false, entity.JadwalPemusikStatusDraft).
+			Delete(&entity.JadwalPemusik{}).Error; err != nil {
+			return err
+		}
+		if len(jadwal) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&jadwal).Error
+	})
+}
+
+func (r *pemusikRepository) PublishJadwal(ids []uuid.UUID, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&entity.JadwalPemusik{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":       entity.JadwalPemusikStatusPublished,
+			"published_at": publishedAt,
+		}).Error
+}
+
+// SyncTerjadwal marks the availability of the musicians on the roster of an occurrence as scheduled
+func (r *pemusikRepository) SyncTerjadwal(eventID uuid.UUID, occurrenceDate time.Time) error {
+	date := occurrenceDate.Format("2006-01-02")
+	rostered := r.db.Model(&entity.JadwalPemusik{}).Select("person_id").
+		Where("event_id = ? AND occurrence_date = ?", eventID, date)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.KetersediaanPemusik{}).
+			Where("event_id = ? AND occurrence_date = ?", eventID, date).
+			Update("is_terjadwal", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.KetersediaanPemusik{}).
+			Where("event_id = ? AND occurrence_date = ? AND person_id IN (?)", eventID, date, rostered).
+			Update("is_terjadwal", true).Error
+	})
+}
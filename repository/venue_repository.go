@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"gorm.io/gorm"
+)
+
+type VenueRepository interface {
+	Create(venue *entity.Venue) error
+	GetByID(id uuid.UUID) (*entity.Venue, error)
+	Update(venue *entity.Venue) error
+	Delete(id uuid.UUID) error
+	List(filters VenueFilters) ([]entity.Venue, int64, error)
+	GetByChurchAndName(churchID uuid.UUID, name string) (*entity.Venue, error)
+	CountEvents(venueID uuid.UUID) (int64, error)
+
+	// Events booked into a venue that may have occurrences between from and to
+	GetEventsByVenueID(venueID uuid.UUID, from, to time.Time) ([]entity.Event, error)
+}
+
+type VenueFilters struct {
+	ChurchID *uuid.UUID
+	IsActive *bool
+	Search   string
+	Limit    int
+	Offset   int
+}
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+func (r *venueRepository) Create(venue *entity.Venue) error {
+	return r.db.Omit("Church").Create(venue).Error
+}
+
+func (r *venueRepository) GetByID(id uuid.UUID) (*entity.Venue, error) {
+	var venue entity.Venue
+	if err := r.db.Preload("Church").First(&venue, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *venueRepository) Update(venue *entity.Venue) error {
+	return r.db.Omit("Church").Save(venue).Error
+}
+
+func (r *venueRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&entity.Venue{}, "id = ?", id).Error
+}
+
+func (r *venueRepository) List(filters VenueFilters) ([]entity.Venue, int64, error) {
+	var venues []entity.Venue
+	var count int64
+
+	query := r.db.Model(&entity.Venue{}).Preload("Church")
+
+	if filters.ChurchID != nil {
+		query = query.Where("church_id = ?", *filters.ChurchID)
+	}
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+	if filters.Search != "" {
+		query = query.Where("name LIKE ? OR description LIKE ?", "%"+filters.Search+"%", "%"+filters.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Order("name ASC").Find(&venues).Error
+	return venues, count, err
+}
+
+func (r *venueRepository) GetByChurchAndName(churchID uuid.UUID, name string) (*entity.Venue, error) {
+	var venue entity.Venue
+	if err := r.db.Where("church_id = ? AND name = ?", churchID, name).First(&venue).Error; err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *venueRepository) CountEvents(venueID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Event{}).Where("venue_id = ?", venueID).Count(&count).Error
+	return count, err
+}
+
+func (r *venueRepository) GetEventsByVenueID(venueID uuid.UUID, from, to time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := r.db.Preload("RecurrenceRule").
+		Joins("LEFT JOIN recurrence_rules ON events.recurrence_rule_id = recurrence_rules.id").
+		Where("events.venue_id = ? AND events.event_date <= ?", venueID, to).
+		Where(`
+			(events.recurrence_rule_id IS NULL AND events.event_date >= ?) OR
+			(events.recurrence_rule_id IS NOT NULL AND (recurrence_rules.until IS NULL OR recurrence_rules.until >= ?))
+		`, from, from).
+		Order("events.event_date ASC").
+		Find(&events).Error
+	return events, err
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/samber/do"
 	"github.com/zemetia/en-indo-be/constants"
 	"github.com/zemetia/en-indo-be/controller"
+	"github.com/zemetia/en-indo-be/middleware"
 	"github.com/zemetia/en-indo-be/repository"
 	"github.com/zemetia/en-indo-be/service"
 	"gorm.io/gorm"
@@ -13,67 +14,226 @@ import (
 func EventRoutes(router *gin.RouterGroup, injector *do.Injector) {
 	// Get dependencies from injector
 	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvokeNamed[service.UserService](injector, constants.UserService)
 
 	// Create repositories and services
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventRegistrationRepo := repository.NewEventRegistrationRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	visitorRepo := repository.NewVisitorRepository(db)
+	eventAttendanceRepo := repository.NewEventAttendanceRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	eventOccurrenceRepo := repository.NewEventOccurrenceRepository(db)
+	venueRepo := repository.NewVenueRepository(db)
+	rundownRepo := repository.NewEventRundownRepository(db)
+	templateRepo := repository.NewEventTemplateRepository(db)
+	checkInTokenService := service.NewCheckInTokenService()
+	eventChangeNotifier := service.NewEventChangeNotifier(eventPICRepo, userRepo, notificationRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, eventOccurrenceRepo, venueRepo, eventChangeNotifier)
 	eventPICService := service.NewEventPICService(eventPICRepo, eventRepo)
+	eventAuthService := newEventAuthorizationService(db)
+	eventICalService := service.NewEventICalService(eventRepo, eventService)
+	publicEventService := service.NewPublicEventService(eventOccurrenceRepo, eventService)
+	venueService := service.NewVenueService(venueRepo)
+	eventRegistrationService := service.NewEventRegistrationService(eventRegistrationRepo, eventRepo, personRepo, visitorRepo)
+	eventAttendanceService := service.NewEventAttendanceService(eventAttendanceRepo, eventRegistrationRepo, eventRepo, personRepo, visitorRepo, eventService, checkInTokenService)
+	eventRundownService := service.NewEventRundownService(rundownRepo, eventRepo, personRepo)
+	eventTemplateService := service.NewEventTemplateService(templateRepo, eventRepo, eventPICRepo, rundownRepo, personRepo, venueRepo, eventService)
 	
 	// Create controllers
-	eventController := controller.NewEventController(eventService)
-	eventPICController := controller.NewEventPICController(eventPICService)
+	eventController := controller.NewEventController(eventService, eventAuthService)
+	eventPICController := controller.NewEventPICController(eventPICService, eventAuthService)
 	eventPICRoleController := controller.NewEventPICRoleController(eventPICService)
+	eventPICRotationController := controller.NewEventPICRotationController(eventPICService)
+	eventICalController := controller.NewEventICalController(eventICalService, eventAuthService)
+	eventPublicController := controller.NewEventPublicController(publicEventService)
+	venueController := controller.NewVenueController(venueService, eventAuthService)
+	eventRegistrationController := controller.NewEventRegistrationController(eventRegistrationService, eventAuthService)
+	eventAttendanceController := controller.NewEventAttendanceController(eventAttendanceService, eventAuthService)
+	eventRundownController := controller.NewEventRundownController(eventRundownService)
+	eventTemplateController := controller.NewEventTemplateController(eventTemplateService, eventAuthService)
+
+	// The public website feeds are the only event routes open without signing in
+	router.GET("/events/public", eventPublicController.ListPublicEvents)
+	router.GET("/events/public/ical", eventICalController.ExportPublicEvents)
+
+	events := router.Group("", middleware.Authenticate(jwtService, userService))
+
+	// Edit, delete and PIC assignment need the matching EventPIC permission or church admin status
+	canEdit := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromParam)
+	canDelete := middleware.RequireEventPermission(eventAuthService, service.EventActionDelete, middleware.EventFromParam)
+	canAssignPIC := middleware.RequireEventPermission(eventAuthService, service.EventActionAssignPIC, middleware.EventFromParam)
+	canAssignPICOf := middleware.RequireEventPermission(eventAuthService, service.EventActionAssignPIC, middleware.EventFromPIC(eventAuthService))
+	canAssignOccurrencePICOf := middleware.RequireEventPermission(eventAuthService, service.EventActionAssignPIC, middleware.EventFromOccurrencePIC(eventAuthService))
+	canAssignRotationOf := middleware.RequireEventPermission(eventAuthService, service.EventActionAssignPIC, middleware.EventFromRotation(eventAuthService))
+	canEditRegistrationOf := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromRegistration(eventAuthService))
+	canEditAttendanceOf := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromAttendance(eventAuthService))
+
+	// Reading an event, its occurrences, PICs, attendance or run-sheets needs access to one of its churches
+	canView := middleware.RequireEventAccess(eventAuthService, middleware.EventFromParam)
+	canViewPICOf := middleware.RequireEventAccess(eventAuthService, middleware.EventFromPIC(eventAuthService))
+	canViewRotationOf := middleware.RequireEventAccess(eventAuthService, middleware.EventFromRotation(eventAuthService))
+	canViewAttendanceOf := middleware.RequireEventAccess(eventAuthService, middleware.EventFromAttendance(eventAuthService))
+	canViewPerson := middleware.RequirePersonAccess(eventAuthService)
+
+	// Templates, venues and PIC roles belong to churches rather than events and are managed by church admins
+	isChurchAdmin := middleware.RequireChurchAdmin(eventAuthService, nil)
+	isTemplateChurchAdmin := middleware.RequireChurchAdmin(eventAuthService, middleware.ChurchOfTemplate(eventAuthService))
+	isVenueChurchAdmin := middleware.RequireChurchAdmin(eventAuthService, middleware.ChurchOfVenue(eventAuthService))
+
+	// Templates are used by the members and admins of their church; the controller lists only those
+	canUseTemplate := middleware.RequireChurchMember(eventAuthService, middleware.ChurchOfTemplate(eventAuthService))
+
+	// Venues events can be booked into; the controller checks the church of a new venue
+	events.POST("/venues", venueController.CreateVenue)
+	events.GET("/venues", venueController.ListVenues)
+	events.GET("/venues/:id", venueController.GetVenue)
+	events.PUT("/venues/:id", isVenueChurchAdmin, venueController.UpdateVenue)
+	events.DELETE("/venues/:id", isVenueChurchAdmin, venueController.DeleteVenue)
 
 	// Event CRUD routes - keep simple ones here
-	router.POST("/events", eventController.CreateEvent)
-	router.GET("/events", eventController.ListEvents)
+	events.POST("/events", eventController.CreateEvent)
+	events.GET("/events", eventController.ListEvents)
 	
 	// Validation and utility routes - no params, put before parameterized routes
-	router.POST("/events/validate-recurrence", eventController.ValidateRecurrenceRule)
-	router.GET("/events/occurrences", eventController.GetOccurrencesInRange)
+	events.POST("/events/validate-recurrence", eventController.ValidateRecurrenceRule)
+	events.GET("/events/occurrences", eventController.GetOccurrencesInRange)
+	events.GET("/events/occurrences/list", eventController.ListOccurrences)
+	events.GET("/events/occurrences/week", eventController.GetWeekOccurrences)
+
+	// iCalendar feeds
+	events.GET("/events/ical", eventICalController.ExportEvents)
+	events.POST("/events/import", eventICalController.ImportEvents)
 
 	// Event PIC management routes - put more specific paths first
-	router.POST("/events/:id/pics/bulk", eventPICController.BulkAssignEventPICs)
-	router.POST("/events/:id/pics/transfer", eventPICController.TransferPICRole)
-	router.GET("/events/:id/pics/active", eventPICController.GetActivePICsForEvent)
-	router.GET("/events/:id/pics/primary", eventPICController.GetPrimaryPICForEvent)
-	router.GET("/events/:id/pics/history", eventPICController.GetEventPICHistory)
-	router.GET("/events/:id/pics/validate/:personId", eventPICController.ValidatePICPermissions)
-	router.POST("/events/:id/pics", eventPICController.CreateEventPIC)
-	router.GET("/events/:id/pics", eventPICController.GetEventPICs)
+	events.POST("/events/:id/pics/bulk", canAssignPIC, eventPICController.BulkAssignEventPICs)
+	events.POST("/events/:id/pics/transfer", canAssignPIC, eventPICController.TransferPICRole)
+	events.GET("/events/:id/pics/active", canView, eventPICController.GetActivePICsForEvent)
+	events.GET("/events/:id/pics/primary", canView, eventPICController.GetPrimaryPICForEvent)
+	events.GET("/events/:id/pics/history", canView, eventPICController.GetEventPICHistory)
+	events.GET("/events/:id/pics/validate/:personId", canView, eventPICController.ValidatePICPermissions)
+	events.POST("/events/:id/pics", canAssignPIC, eventPICController.CreateEventPIC)
+	events.GET("/events/:id/pics", canView, eventPICController.GetEventPICs)
+
+	// Per-occurrence PICs and the rotations that fill them
+	events.POST("/events/:id/occurrence-pics", canAssignPIC, eventPICRotationController.AssignOccurrencePIC)
+	events.GET("/events/:id/occurrence-pics", canView, eventPICRotationController.GetOccurrencePICs)
+	events.POST("/events/:id/pic-rotations", canAssignPIC, eventPICRotationController.CreatePICRotation)
+	events.GET("/events/:id/pic-rotations", canView, eventPICRotationController.GetPICRotations)
+
+	// Event registration (RSVP) routes; registrants' contact details are only shown to the event's editors.
+	// The controller lets members register themselves and anyone else only for the event's editors.
+	events.GET("/events/:id/registrations/capacity", canView, eventRegistrationController.GetOccurrenceCapacity)
+	events.GET("/events/:id/registrations/export", canEdit, eventRegistrationController.ExportRegistrations)
+	events.POST("/events/:id/registrations/promote", canEdit, eventRegistrationController.FillFromWaitlist)
+	events.POST("/events/:id/registrations", eventRegistrationController.Register)
+	events.GET("/events/:id/registrations", canEdit, eventRegistrationController.ListRegistrations)
+
+	// Event attendance routes; check-ins are recorded by ushers and PICs with edit permission, and the
+	// controller issues check-in tokens to members for themselves and to the event's editors for anyone
+	events.POST("/events/:id/attendance/check-in", canEdit, eventAttendanceController.CheckIn)
+	events.POST("/events/:id/attendance/scan", canEdit, eventAttendanceController.CheckInWithToken)
+	events.GET("/events/:id/attendance/tokens/qr", eventAttendanceController.GetCheckInQRCode)
+	events.POST("/events/:id/attendance/tokens", eventAttendanceController.IssueCheckInToken)
+	events.PUT("/events/:id/attendance/headcount", canEdit, eventAttendanceController.RecordHeadcount)
+	events.GET("/events/:id/attendance/headcount", canView, eventAttendanceController.GetHeadcount)
+	events.GET("/events/:id/attendance/summary", canView, eventAttendanceController.GetOccurrenceSummary)
+	events.GET("/events/:id/attendance/report", canView, eventAttendanceController.GetAttendanceReport)
+	events.GET("/events/:id/attendance", canView, eventAttendanceController.ListAttendances)
+
+	// Event run-sheet routes
+	events.GET("/events/:id/rundown/export", canView, eventRundownController.ExportRundown)
+	events.GET("/events/:id/rundown/template", canView, eventRundownController.GetRundownTemplate)
+	events.PUT("/events/:id/rundown/template", canEdit, eventRundownController.SetRundownTemplate)
+	events.POST("/events/:id/rundown/template/from-occurrence", canEdit, eventRundownController.SaveRundownTemplate)
+	events.POST("/events/:id/rundown/template/apply", canEdit, eventRundownController.ApplyRundownTemplate)
+	events.GET("/events/:id/rundown", canView, eventRundownController.GetRundown)
+	events.PUT("/events/:id/rundown", canEdit, eventRundownController.SetRundown)
+
+	// Saving an event as a template and copying it to new dates
+	events.POST("/events/:id/template", canEdit, eventTemplateController.SaveEventAsTemplate)
+	events.POST("/events/:id/clone", canEdit, eventTemplateController.CloneEvent)
 
 	// Event occurrences routes - specific paths first
-	router.GET("/events/:id/occurrences", eventController.GetEventOccurrences)
-	router.GET("/events/:id/next", eventController.GetNextOccurrence)
+	events.GET("/events/:id/occurrences", canView, eventController.GetEventOccurrences)
+	events.GET("/events/:id/next", canView, eventController.GetNextOccurrence)
+	events.GET("/events/:id/ical", canView, eventICalController.ExportEvent)
 	
 	// Recurring event management routes - three-tier modifications
-	router.PUT("/events/:id/series", eventController.UpdateRecurringEvent)       // Update entire series
-	router.PUT("/events/:id/occurrence", eventController.UpdateSingleOccurrence) // Update single occurrence
-	router.PUT("/events/:id/future", eventController.UpdateFutureOccurrences)    // Update this and future occurrences
-	router.DELETE("/events/:id/occurrence", eventController.DeleteOccurrence)
+	events.PUT("/events/:id/series", canEdit, eventController.UpdateRecurringEvent)       // Update entire series
+	events.PUT("/events/:id/occurrence", canEdit, eventController.UpdateSingleOccurrence) // Update single occurrence
+	events.PUT("/events/:id/future", canEdit, eventController.UpdateFutureOccurrences)    // Update this and future occurrences
+	events.DELETE("/events/:id/occurrence", canDelete, eventController.DeleteOccurrence)
 	
 	// Basic CRUD routes with :id param - put at end to avoid conflicts
-	router.GET("/events/:id", eventController.GetEvent)
-	router.PUT("/events/:id", eventController.UpdateEvent)
-	router.DELETE("/events/:id", eventController.DeleteEvent)
+	events.GET("/events/:id", canView, eventController.GetEvent)
+	events.PUT("/events/:id", canEdit, eventController.UpdateEvent)
+	events.DELETE("/events/:id", canDelete, eventController.DeleteEvent)
 	
+	// Event templates
+	events.POST("/event-templates", isChurchAdmin, eventTemplateController.CreateEventTemplate)
+	events.GET("/event-templates", eventTemplateController.ListEventTemplates)
+	events.GET("/event-templates/:id", canUseTemplate, eventTemplateController.GetEventTemplate)
+	events.PUT("/event-templates/:id", isTemplateChurchAdmin, eventTemplateController.UpdateEventTemplate)
+	events.DELETE("/event-templates/:id", isTemplateChurchAdmin, eventTemplateController.DeleteEventTemplate)
+	events.POST("/event-templates/:id/instantiate", canUseTemplate, eventTemplateController.InstantiateEventTemplate)
+
 	// Individual EventPIC operations
-	router.GET("/event-pics/:id", eventPICController.GetEventPIC)
-	router.PUT("/event-pics/:id", eventPICController.UpdateEventPIC)
-	router.DELETE("/event-pics/:id", eventPICController.DeleteEventPIC)
-	router.GET("/event-pics", eventPICController.ListEventPICs)
-	router.GET("/event-pics/expiring", eventPICController.GetExpiringPICs)
+	events.GET("/event-pics/:id", canViewPICOf, eventPICController.GetEventPIC)
+	events.PUT("/event-pics/:id", canAssignPICOf, eventPICController.UpdateEventPIC)
+	events.DELETE("/event-pics/:id", canAssignPICOf, eventPICController.DeleteEventPIC)
+	events.GET("/event-pics", eventPICController.ListEventPICs)
+	events.GET("/event-pics/expiring", eventPICController.GetExpiringPICs)
+
+	// Individual occurrence PIC and rotation operations
+	events.DELETE("/event-occurrence-pics/:id", canAssignOccurrencePICOf, eventPICRotationController.DeleteOccurrencePIC)
+	events.GET("/event-pic-rotations/:id", canViewRotationOf, eventPICRotationController.GetPICRotation)
+	events.PUT("/event-pic-rotations/:id", canAssignRotationOf, eventPICRotationController.UpdatePICRotation)
+	events.DELETE("/event-pic-rotations/:id", canAssignRotationOf, eventPICRotationController.DeletePICRotation)
+	events.POST("/event-pic-rotations/:id/fill", canAssignRotationOf, eventPICRotationController.FillPICRotation)
 	
+	// Individual registration operations; the controller lets registrants read and cancel their own
+	events.GET("/event-registrations/:id", eventRegistrationController.GetRegistration)
+	events.PUT("/event-registrations/:id", canEditRegistrationOf, eventRegistrationController.UpdateRegistration)
+	events.POST("/event-registrations/:id/cancel", eventRegistrationController.CancelRegistration)
+
+	// Individual attendance operations
+	events.GET("/event-attendances/:id", canViewAttendanceOf, eventAttendanceController.GetAttendance)
+	events.DELETE("/event-attendances/:id", canEditAttendanceOf, eventAttendanceController.DeleteAttendance)
+
 	// Person-centric PIC routes
-	router.GET("/persons/:personId/event-pics", eventPICController.GetPersonPICs)
-	router.GET("/persons/:personId/event-pics/active", eventPICController.GetActivePersonPICs)
-	router.GET("/persons/:personId/event-pics/history", eventPICController.GetPersonPICHistory)
+	events.GET("/persons/:personId/event-pics", canViewPerson, eventPICController.GetPersonPICs)
+	events.GET("/persons/:personId/event-pics/active", canViewPerson, eventPICController.GetActivePersonPICs)
+	events.GET("/persons/:personId/event-pics/history", canViewPerson, eventPICController.GetPersonPICHistory)
+	events.GET("/persons/:personId/event-pics/conflicts", canViewPerson, eventPICController.GetPersonPICConflicts)
+	events.GET("/persons/:personId/event-registrations", canViewPerson, eventRegistrationController.GetPersonRegistrations)
+	events.GET("/persons/:personId/event-attendance", canViewPerson, eventAttendanceController.GetPersonAttendance)
 	
 	// Event PIC Role management routes
-	router.POST("/event-pic-roles", eventPICRoleController.CreateEventPICRole)
-	router.GET("/event-pic-roles", eventPICRoleController.ListEventPICRoles)
-	router.GET("/event-pic-roles/:id", eventPICRoleController.GetEventPICRole)
-	router.PUT("/event-pic-roles/:id", eventPICRoleController.UpdateEventPICRole)
-	router.DELETE("/event-pic-roles/:id", eventPICRoleController.DeleteEventPICRole)
+	events.POST("/event-pic-roles", isChurchAdmin, eventPICRoleController.CreateEventPICRole)
+	events.GET("/event-pic-roles", eventPICRoleController.ListEventPICRoles)
+	events.GET("/event-pic-roles/:id", eventPICRoleController.GetEventPICRole)
+	events.PUT("/event-pic-roles/:id", isChurchAdmin, eventPICRoleController.UpdateEventPICRole)
+	events.DELETE("/event-pic-roles/:id", isChurchAdmin, eventPICRoleController.DeleteEventPICRole)
+}
+
+// newEventAuthorizationService builds the permission checks shared by the event, setlist and musician routes
+func newEventAuthorizationService(db *gorm.DB) service.EventAuthorizationService {
+	eventRepo := repository.NewEventRepository(db)
+	eventPICRepo := repository.NewEventPICRepository(db)
+	return service.NewEventAuthorizationService(
+		eventRepo,
+		eventPICRepo,
+		repository.NewPelayananRepository(db),
+		repository.NewPersonRepository(db),
+		repository.NewEventRegistrationRepository(db),
+		repository.NewEventAttendanceRepository(db),
+		repository.NewEventTemplateRepository(db),
+		repository.NewVenueRepository(db),
+		repository.NewPemusikRepository(db),
+		service.NewEventPICService(eventPICRepo, eventRepo),
+	)
 }
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+	"github.com/zemetia/en-indo-be/constants"
+	"github.com/zemetia/en-indo-be/controller"
+	"github.com/zemetia/en-indo-be/middleware"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+func LaguRoutes(router *gin.RouterGroup, injector *do.Injector) {
+	// Get dependencies from injector
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvokeNamed[service.UserService](injector, constants.UserService)
+
+	// Create repositories and services
+	laguRepo := repository.NewLaguRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	laguService := service.NewLaguService(laguRepo, eventRepo, personRepo)
+	eventAuthService := newEventAuthorizationService(db)
+
+	// Create controllers
+	laguController := controller.NewLaguController(laguService)
+
+	// Songs, tags and setlists are only available to signed-in members
+	routes := router.Group("", middleware.Authenticate(jwtService, userService))
+
+	// Song library
+	routes.POST("/lagu", laguController.CreateLagu)
+	routes.GET("/lagu", laguController.ListLagu)
+	routes.GET("/lagu/recent", laguController.GetRecentLagu)
+	routes.GET("/lagu/usage-report", laguController.GetSongUsageReport)
+	routes.GET("/lagu/usage-report/export", laguController.ExportSongUsageReport)
+	routes.POST("/lagu/import", laguController.ImportChordPro)
+	routes.GET("/lagu/:id", laguController.GetLagu)
+	routes.PUT("/lagu/:id", laguController.UpdateLagu)
+	routes.DELETE("/lagu/:id", laguController.DeleteLagu)
+	routes.GET("/lagu/:id/history", laguController.GetLaguHistory)
+	routes.GET("/lagu/:id/chart", laguController.GetLaguChart)
+	routes.GET("/lagu/:id/chordpro", laguController.ExportChordPro)
+	routes.GET("/lagu/:id/lyrics", laguController.GetLaguLyrics)
+
+	// Song tags
+	routes.POST("/tag-lagu", laguController.CreateTagLagu)
+	routes.GET("/tag-lagu", laguController.ListTagLagu)
+	routes.GET("/tag-lagu/:id", laguController.GetTagLagu)
+	routes.PUT("/tag-lagu/:id", laguController.UpdateTagLagu)
+	routes.DELETE("/tag-lagu/:id", laguController.DeleteTagLagu)
+
+	// Setlists of event occurrences; changing one needs the event's edit permission
+	canEdit := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromParam)
+	routes.GET("/events/:id/setlist", laguController.GetEventSetlist)
+	routes.PUT("/events/:id/setlist", canEdit, laguController.SetEventSetlist)
+	routes.GET("/events/:id/setlist/export", laguController.ExportEventSetlist)
+}
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+	"github.com/zemetia/en-indo-be/constants"
+	"github.com/zemetia/en-indo-be/controller"
+	"github.com/zemetia/en-indo-be/middleware"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+func PemusikRoutes(router *gin.RouterGroup, injector *do.Injector) {
+	// Get dependencies from injector
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvokeNamed[service.UserService](injector, constants.UserService)
+
+	// Create repositories and services
+	pemusikRepo := repository.NewPemusikRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	pemusikService := service.NewPemusikService(pemusikRepo, eventRepo, personRepo, userRepo, notificationRepo)
+	eventAuthService := newEventAuthorizationService(db)
+
+	// Create controllers
+	pemusikController := controller.NewPemusikController(pemusikService, eventAuthService)
+
+	events := router.Group("", middleware.Authenticate(jwtService, userService))
+
+	// Planning the music of an event needs its edit permission
+	canEdit := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromParam)
+	canEditJadwalOf := middleware.RequireEventPermission(eventAuthService, service.EventActionEdit, middleware.EventFromJadwal(eventAuthService))
+
+	// Instruments every occurrence of an event needs
+	events.GET("/events/:id/pemusik/kebutuhan", pemusikController.GetKebutuhan)
+	events.PUT("/events/:id/pemusik/kebutuhan", canEdit, pemusikController.SetKebutuhan)
+
+	// Musician availability per occurrence; musicians manage their own, the event's editors anyone's
+	events.POST("/events/:id/pemusik/ketersediaan", pemusikController.SubmitKetersediaan)
+	events.GET("/events/:id/pemusik/ketersediaan", pemusikController.GetKetersediaan)
+	events.DELETE("/ketersediaan-pemusik/:id", pemusikController.DeleteKetersediaan)
+
+	// Musician roster - specific paths first
+	events.POST("/events/:id/pemusik/jadwal/generate", canEdit, pemusikController.GenerateJadwal)
+	events.POST("/events/:id/pemusik/jadwal/publish", canEdit, pemusikController.PublishJadwal)
+	events.GET("/events/:id/pemusik/jadwal", pemusikController.GetJadwal)
+	events.POST("/events/:id/pemusik/jadwal", canEdit, pemusikController.AddJadwal)
+	events.PUT("/jadwal-pemusik/:id", canEditJadwalOf, pemusikController.UpdateJadwal)
+	events.DELETE("/jadwal-pemusik/:id", canEditJadwalOf, pemusikController.DeleteJadwal)
+}
@@ -22,4 +22,6 @@ func RegisterRoutes(server *gin.Engine, injector *do.Injector) {
 
 	// Register event routes with /api prefix
 	EventRoutes(api, injector)
+	LaguRoutes(api, injector)
+	PemusikRoutes(api, injector)
 }
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/zemetia/en-indo-be/constants"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+const defaultReminderInterval = 5 * time.Minute
+
+// StartEventReminders runs the event reminder job in the background.
+//
+// Configuration:
+//   - EVENT_REMINDERS_ENABLED: set to "false" to disable the job
+//   - EVENT_REMINDER_OFFSETS: comma separated durations before each occurrence, e.g. "24h,2h" (the default)
+//   - EVENT_REMINDER_INTERVAL: how often due reminders are looked for, e.g. "5m" (the default)
+func StartEventReminders(injector *do.Injector) {
+	// Resolving the database also loads .env
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+
+	if os.Getenv("EVENT_REMINDERS_ENABLED") == "false" {
+		log.Println("event reminders: disabled")
+		return
+	}
+
+	offsets, err := parseDurations(os.Getenv("EVENT_REMINDER_OFFSETS"))
+	if err != nil {
+		log.Fatalf("event reminders: invalid EVENT_REMINDER_OFFSETS: %v", err)
+	}
+
+	interval := defaultReminderInterval
+	if value := os.Getenv("EVENT_REMINDER_INTERVAL"); value != "" {
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Fatalf("event reminders: invalid EVENT_REMINDER_INTERVAL: %q", value)
+		}
+	}
+
+	reminderService := service.NewEventReminderService(
+		repository.NewEventReminderRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewNotificationRepository(db),
+		offsets,
+	)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			sent, err := reminderService.SendDueReminders(time.Now())
+			if err != nil {
+				log.Printf("event reminders: %v", err)
+			} else if sent > 0 {
+				log.Printf("event reminders: sent %d reminder(s)", sent)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func parseDurations(value string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("offset must be positive: %s", part)
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
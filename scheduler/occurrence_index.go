@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/zemetia/en-indo-be/constants"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+const defaultOccurrenceIndexRefreshInterval = 24 * time.Hour
+
+// StartOccurrenceIndexRefresh rebuilds the occurrence index at startup and then periodically, which
+// rolls the indexed horizon of recurring events forward and repairs rows a failed reindex left stale.
+//
+// Configuration:
+//   - EVENT_OCCURRENCE_INDEX_REFRESH_ENABLED: set to "false" to disable the job
+//   - EVENT_OCCURRENCE_INDEX_REFRESH_INTERVAL: how often the index is rebuilt, e.g. "24h" (the default)
+func StartOccurrenceIndexRefresh(injector *do.Injector) {
+	// Resolving the database also loads .env
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+
+	if os.Getenv("EVENT_OCCURRENCE_INDEX_REFRESH_ENABLED") == "false" {
+		log.Println("occurrence index: refresh disabled")
+		return
+	}
+
+	interval := defaultOccurrenceIndexRefreshInterval
+	if value := os.Getenv("EVENT_OCCURRENCE_INDEX_REFRESH_INTERVAL"); value != "" {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Fatalf("occurrence index: invalid EVENT_OCCURRENCE_INDEX_REFRESH_INTERVAL: %q", value)
+		}
+	}
+
+	eventService := service.NewEventService(
+		repository.NewEventRepository(db),
+		repository.NewEventPICRepository(db),
+		repository.NewEventOccurrenceRepository(db),
+		repository.NewVenueRepository(db),
+		nil,
+	)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			indexed, err := eventService.RebuildOccurrenceIndex()
+			if err != nil {
+				log.Printf("occurrence index: %v", err)
+			} else {
+				log.Printf("occurrence index: rebuilt for %d event(s)", indexed)
+			}
+			<-ticker.C
+		}
+	}()
+}
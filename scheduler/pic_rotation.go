@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/zemetia/en-indo-be/constants"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+const defaultPICRotationFillInterval = 24 * time.Hour
+
+// StartPICRotationFill keeps every active PIC rotation filled ahead, at startup and then periodically,
+// so the rotation moves on to new occurrences as earlier ones pass.
+//
+// Configuration:
+//   - EVENT_PIC_ROTATION_FILL_ENABLED: set to "false" to disable the job
+//   - EVENT_PIC_ROTATION_FILL_INTERVAL: how often rotations are topped up, e.g. "24h" (the default)
+func StartPICRotationFill(injector *do.Injector) {
+	// Resolving the database also loads .env
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+
+	if os.Getenv("EVENT_PIC_ROTATION_FILL_ENABLED") == "false" {
+		log.Println("PIC rotation: fill disabled")
+		return
+	}
+
+	interval := defaultPICRotationFillInterval
+	if value := os.Getenv("EVENT_PIC_ROTATION_FILL_INTERVAL"); value != "" {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Fatalf("PIC rotation: invalid EVENT_PIC_ROTATION_FILL_INTERVAL: %q", value)
+		}
+	}
+
+	eventPICService := service.NewEventPICService(
+		repository.NewEventPICRepository(db),
+		repository.NewEventRepository(db),
+	)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			assigned, err := eventPICService.FillActiveRotations()
+			if err != nil {
+				log.Printf("PIC rotation: %v", err)
+			} else if assigned > 0 {
+				log.Printf("PIC rotation: assigned %d occurrence(s)", assigned)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// CheckInTokenService signs and verifies the tokens printed in check-in QR codes.
+// Tokens are signed with a key derived from the JWT secret, so they cannot be used as login tokens.
+type CheckInTokenService interface {
+	GenerateToken(claims CheckInClaims) (string, error)
+	ValidateToken(token string) (*CheckInClaims, error)
+}
+
+// CheckInClaims identifies the person and the occurrence a check-in token is valid for
+type CheckInClaims struct {
+	TokenID        uuid.UUID
+	EventID        uuid.UUID
+	OccurrenceDate string // YYYY-MM-DD in the event's timezone
+	PersonID       uuid.UUID
+	ExpiresAt      time.Time
+}
+
+var (
+	ErrCheckInTokenInvalid = errors.New("invalid check-in token")
+	ErrCheckInTokenExpired = errors.New("check-in token has expired")
+)
+
+const (
+	checkInTokenAudience = "event-check-in"
+	checkInTokenIssuer   = "en-indo-be"
+)
+
+type checkInTokenClaim struct {
+	EventID        string `json:"event_id"`
+	OccurrenceDate string `json:"occurrence_date"`
+	jwt.RegisteredClaims
+}
+
+type checkInTokenService struct {
+	signingKey []byte
+	issuer     string
+}
+
+func NewCheckInTokenService() CheckInTokenService {
+	mac := hmac.New(sha256.New, []byte(getSecretKey()))
+	mac.Write([]byte(checkInTokenAudience))
+
+	return &checkInTokenService{
+		signingKey: mac.Sum(nil),
+		issuer:     checkInTokenIssuer,
+	}
+}
+
+func (s *checkInTokenService) GenerateToken(claims CheckInClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, checkInTokenClaim{
+		claims.EventID.String(),
+		claims.OccurrenceDate,
+		jwt.RegisteredClaims{
+			ID:        claims.TokenID.String(),
+			Subject:   claims.PersonID.String(),
+			Audience:  jwt.ClaimStrings{checkInTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+
+	signed, err := token.SignedString(s.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign check-in token: %w", err)
+	}
+	return signed, nil
+}
+
+func (s *checkInTokenService) ValidateToken(token string) (*CheckInClaims, error) {
+	var claim checkInTokenClaim
+	_, err := jwt.ParseWithClaims(token, &claim, func(t_ *jwt.Token) (any, error) {
+		if _, ok := t_.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t_.Header["alg"])
+		}
+		return s.signingKey, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrCheckInTokenExpired
+		}
+		return nil, ErrCheckInTokenInvalid
+	}
+	if !claim.VerifyAudience(checkInTokenAudience, true) || !claim.VerifyIssuer(s.issuer, true) || claim.ExpiresAt == nil {
+		return nil, ErrCheckInTokenInvalid
+	}
+
+	tokenID, err := uuid.Parse(claim.ID)
+	if err != nil {
+		return nil, ErrCheckInTokenInvalid
+	}
+	eventID, err := uuid.Parse(claim.EventID)
+	if err != nil {
+		return nil, ErrCheckInTokenInvalid
+	}
+	personID, err := uuid.Parse(claim.Subject)
+	if err != nil {
+		return nil, ErrCheckInTokenInvalid
+	}
+
+	return &CheckInClaims{
+		TokenID:        tokenID,
+		EventID:        eventID,
+		OccurrenceDate: claim.OccurrenceDate,
+		PersonID:       personID,
+		ExpiresAt:      claim.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,648 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"gorm.io/gorm"
+)
+
+type EventAttendanceService interface {
+	// Individual check-ins
+	CheckIn(eventID uuid.UUID, req *dto.CheckInRequest, checkedInBy *uuid.UUID) (*dto.EventAttendanceResponse, error)
+	GetAttendance(id uuid.UUID) (*dto.EventAttendanceResponse, error)
+	DeleteAttendance(id uuid.UUID) error
+	ListAttendances(eventID uuid.UUID, req *dto.EventAttendanceFilterRequest) (*dto.EventAttendanceListResponse, error)
+	GetPersonAttendance(personID uuid.UUID) ([]dto.EventAttendanceResponse, error)
+
+	// QR code check-in
+	IssueCheckInToken(eventID uuid.UUID, req *dto.IssueCheckInTokenRequest) (*dto.CheckInTokenResponse, error)
+	RenderCheckInQRCode(eventID uuid.UUID, req *dto.IssueCheckInTokenRequest) ([]byte, error)
+	CheckInWithToken(eventID uuid.UUID, req *dto.TokenCheckInRequest, scannedBy *uuid.UUID) (*dto.EventAttendanceResponse, error)
+
+	// Anonymous headcounts
+	RecordHeadcount(eventID uuid.UUID, req *dto.RecordHeadcountRequest, recordedBy *uuid.UUID) (*dto.EventHeadcountResponse, error)
+	GetHeadcount(eventID uuid.UUID, occurrenceDate string) (*dto.EventHeadcountResponse, error)
+
+	// Expected vs actual
+	GetOccurrenceSummary(eventID uuid.UUID, occurrenceDate string) (*dto.OccurrenceAttendanceSummary, error)
+	GetAttendanceReport(eventID uuid.UUID, req *dto.AttendanceReportRequest) (*dto.AttendanceReportResponse, error)
+}
+
+type eventAttendanceService struct {
+	attendanceRepo      repository.EventAttendanceRepository
+	registrationRepo    repository.EventRegistrationRepository
+	eventRepo           repository.EventRepository
+	personRepo          repository.PersonRepository
+	visitorRepo         repository.VisitorRepository
+	eventService        EventService
+	tokenService        CheckInTokenService
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+// CheckInTokenGracePeriod is how long after an occurrence ends its check-in tokens are still accepted
+const CheckInTokenGracePeriod = 2 * time.Hour
+
+func NewEventAttendanceService(
+	attendanceRepo repository.EventAttendanceRepository,
+	registrationRepo repository.EventRegistrationRepository,
+	eventRepo repository.EventRepository,
+	personRepo repository.PersonRepository,
+	visitorRepo repository.VisitorRepository,
+	eventService EventService,
+	tokenService CheckInTokenService,
+) EventAttendanceService {
+	return &eventAttendanceService{
+		attendanceRepo:      attendanceRepo,
+		registrationRepo:    registrationRepo,
+		eventRepo:           eventRepo,
+		personRepo:          personRepo,
+		visitorRepo:         visitorRepo,
+		eventService:        eventService,
+		tokenService:        tokenService,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+func (s *eventAttendanceService) CheckIn(eventID uuid.UUID, req *dto.CheckInRequest, checkedInBy *uuid.UUID) (*dto.EventAttendanceResponse, error) {
+	if (req.PersonID == nil) == (req.VisitorID == nil) {
+		return nil, fmt.Errorf("exactly one of personId or visitorId is required")
+	}
+
+	attendance, err := s.newAttendance(eventID, req.OccurrenceDate, req.PersonID, req.VisitorID, req.AgeGroup, entity.CheckInMethodManual, checkedInBy)
+	if err != nil {
+		return nil, err
+	}
+	attendance.Notes = req.Notes
+
+	if err := s.attendanceRepo.Create(attendance); err != nil {
+		return nil, fmt.Errorf("failed to create check-in: %w", err)
+	}
+
+	return s.GetAttendance(attendance.ID)
+}
+
+// IssueCheckInToken signs a single-use token that checks the person in to one occurrence
+func (s *eventAttendanceService) IssueCheckInToken(eventID uuid.UUID, req *dto.IssueCheckInTokenRequest) (*dto.CheckInTokenResponse, error) {
+	personID, err := uuid.Parse(req.PersonID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid person ID format: %w", err)
+	}
+
+	event, occurrenceDate, start, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.personRepo.GetByID(context.Background(), personID); err != nil {
+		return nil, fmt.Errorf("person not found: %w", err)
+	}
+
+	// Tokens stay valid for a while after the occurrence ends so late arrivals can still be scanned
+	loc := recurrenceLocation(event.Timezone)
+	end := wallClockIn(wallClockOf(start).Add(event.EndDatetime.Sub(event.StartDatetime)), loc)
+	expiresAt := end.Add(CheckInTokenGracePeriod)
+	if !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("occurrence has already ended")
+	}
+
+	record := &entity.EventCheckInToken{
+		ID:             uuid.New(),
+		EventID:        eventID,
+		OccurrenceDate: occurrenceDate,
+		PersonID:       personID,
+		ExpiresAt:      expiresAt,
+	}
+	token, err := s.tokenService.GenerateToken(CheckInClaims{
+		TokenID:        record.ID,
+		EventID:        eventID,
+		OccurrenceDate: req.OccurrenceDate,
+		PersonID:       personID,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attendanceRepo.CreateCheckInToken(record); err != nil {
+		return nil, fmt.Errorf("failed to create check-in token: %w", err)
+	}
+
+	return &dto.CheckInTokenResponse{
+		Token:          token,
+		EventID:        eventID,
+		OccurrenceDate: occurrenceDate,
+		PersonID:       personID,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+// RenderCheckInQRCode issues a check-in token and renders it as a PNG QR code
+func (s *eventAttendanceService) RenderCheckInQRCode(eventID uuid.UUID, req *dto.IssueCheckInTokenRequest) ([]byte, error) {
+	size := req.Size
+	if size == 0 {
+		size = 256
+	}
+	if size < 128 || size > 1024 {
+		return nil, fmt.Errorf("size must be between 128 and 1024 pixels")
+	}
+
+	token, err := s.IssueCheckInToken(eventID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(token.Token, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+	return png, nil
+}
+
+// CheckInWithToken verifies a scanned token and records the attendance it stands for
+func (s *eventAttendanceService) CheckInWithToken(eventID uuid.UUID, req *dto.TokenCheckInRequest, scannedBy *uuid.UUID) (*dto.EventAttendanceResponse, error) {
+	claims, err := s.tokenService.ValidateToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.EventID != eventID || claims.OccurrenceDate != req.OccurrenceDate {
+		return nil, fmt.Errorf("check-in token is for a different occurrence")
+	}
+
+	record, err := s.attendanceRepo.GetCheckInToken(claims.TokenID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCheckInTokenInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in token: %w", err)
+	}
+	if record.EventID != claims.EventID || record.PersonID != claims.PersonID {
+		return nil, ErrCheckInTokenInvalid
+	}
+	if record.UsedAt != nil {
+		return nil, repository.ErrCheckInTokenUsed
+	}
+
+	attendance, err := s.newAttendance(eventID, req.OccurrenceDate, &claims.PersonID, nil, "", entity.CheckInMethodQRCode, scannedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attendanceRepo.RedeemCheckInToken(record.ID, attendance); err != nil {
+		if errors.Is(err, repository.ErrCheckInTokenUsed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create check-in: %w", err)
+	}
+
+	return s.GetAttendance(attendance.ID)
+}
+
+func (s *eventAttendanceService) GetAttendance(id uuid.UUID) (*dto.EventAttendanceResponse, error) {
+	attendance, err := s.attendanceRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+
+	return s.entityToResponse(attendance), nil
+}
+
+func (s *eventAttendanceService) DeleteAttendance(id uuid.UUID) error {
+	if _, err := s.attendanceRepo.GetByID(id); err != nil {
+		return fmt.Errorf("failed to get attendance: %w", err)
+	}
+
+	if err := s.attendanceRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete attendance: %w", err)
+	}
+
+	return nil
+}
+
+func (s *eventAttendanceService) ListAttendances(eventID uuid.UUID, req *dto.EventAttendanceFilterRequest) (*dto.EventAttendanceListResponse, error) {
+	_, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	attendances, total, err := s.attendanceRepo.List(repository.EventAttendanceFilters{
+		EventID:        &eventID,
+		OccurrenceDate: &occurrenceDate,
+		AgeGroup:       req.AgeGroup,
+		Search:         req.Search,
+		Limit:          req.Limit,
+		Offset:         (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attendances: %w", err)
+	}
+
+	responses := make([]dto.EventAttendanceResponse, len(attendances))
+	for i := range attendances {
+		responses[i] = *s.entityToResponse(&attendances[i])
+	}
+
+	return &dto.EventAttendanceListResponse{
+		Attendances: responses,
+		TotalCount:  int(total),
+		Page:        req.Page,
+		Limit:       req.Limit,
+	}, nil
+}
+
+func (s *eventAttendanceService) GetPersonAttendance(personID uuid.UUID) ([]dto.EventAttendanceResponse, error) {
+	attendances, err := s.attendanceRepo.GetByPersonID(personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person attendance: %w", err)
+	}
+
+	responses := make([]dto.EventAttendanceResponse, len(attendances))
+	for i := range attendances {
+		responses[i] = *s.entityToResponse(&attendances[i])
+	}
+	return responses, nil
+}
+
+// RecordHeadcount stores the anonymous headcount of an occurrence, replacing an earlier count
+func (s *eventAttendanceService) RecordHeadcount(eventID uuid.UUID, req *dto.RecordHeadcountRequest, recordedBy *uuid.UUID) (*dto.EventHeadcountResponse, error) {
+	if req.Adults < 0 || req.Youth < 0 || req.Kids < 0 {
+		return nil, fmt.Errorf("headcounts cannot be negative")
+	}
+
+	_, occurrenceDate, _, err := s.resolveOpenOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	headcount := &entity.EventHeadcount{
+		EventID:        eventID,
+		OccurrenceDate: occurrenceDate,
+		Adults:         req.Adults,
+		Youth:          req.Youth,
+		Kids:           req.Kids,
+		Notes:          req.Notes,
+		RecordedByID:   recordedBy,
+	}
+	if err := s.attendanceRepo.SaveHeadcount(headcount); err != nil {
+		return nil, fmt.Errorf("failed to save headcount: %w", err)
+	}
+
+	return s.GetHeadcount(eventID, req.OccurrenceDate)
+}
+
+func (s *eventAttendanceService) GetHeadcount(eventID uuid.UUID, occurrenceDate string) (*dto.EventHeadcountResponse, error) {
+	_, date, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	headcount, err := s.attendanceRepo.GetHeadcount(eventID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get headcount: %w", err)
+	}
+
+	return headcountToResponse(headcount), nil
+}
+
+func (s *eventAttendanceService) GetOccurrenceSummary(eventID uuid.UUID, occurrenceDate string) (*dto.OccurrenceAttendanceSummary, error) {
+	event, date, start, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.attendanceRepo.CountByAgeGroup(eventID, date, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+
+	var headcounts []entity.EventHeadcount
+	headcount, err := s.attendanceRepo.GetHeadcount(eventID, date)
+	if err == nil {
+		headcounts = append(headcounts, *headcount)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get headcount: %w", err)
+	}
+
+	summaries := buildAttendanceSummaries(event, []occurrenceStart{{date: date, start: start}}, counts, headcounts)
+	return &summaries[0], nil
+}
+
+// GetAttendanceReport compares expected and actual attendance for every occurrence between two dates
+func (s *eventAttendanceService) GetAttendanceReport(eventID uuid.UUID, req *dto.AttendanceReportRequest) (*dto.AttendanceReportResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	occurrences, err := s.eventService.GetEventOccurrences(eventID, &dto.GetEventOccurrencesRequest{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	starts := make([]occurrenceStart, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		date := occurrence.OccurrenceDate
+		starts = append(starts, occurrenceStart{
+			date:  time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			start: occurrence.StartDatetime,
+		})
+	}
+
+	counts, err := s.attendanceRepo.CountByAgeGroup(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+	headcounts, err := s.attendanceRepo.GetHeadcountsInRange(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get headcounts: %w", err)
+	}
+
+	report := &dto.AttendanceReportResponse{
+		EventID:     event.ID,
+		EventTitle:  event.Title,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Occurrences: buildAttendanceSummaries(event, starts, counts, headcounts),
+	}
+	for _, summary := range report.Occurrences {
+		report.TotalExpected = addAttendanceCounts(report.TotalExpected, summary.Expected)
+		report.TotalActual = addAttendanceCounts(report.TotalActual, summary.Actual)
+	}
+	if len(report.Occurrences) > 0 {
+		report.AverageAttendance = float64(report.TotalActual.Total) / float64(len(report.Occurrences))
+	}
+	report.AttendanceRate = attendanceRate(report.TotalActual.Total, report.TotalExpected.Total)
+
+	return report, nil
+}
+
+// Helper methods
+
+// newAttendance validates a check-in and prepares the attendance record without saving it
+func (s *eventAttendanceService) newAttendance(eventID uuid.UUID, occurrenceDate string, personID, visitorID *uuid.UUID, ageGroup, method string, checkedInBy *uuid.UUID) (*entity.EventAttendance, error) {
+	event, date, _, err := s.resolveOpenOccurrence(eventID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if personID != nil {
+		person, err := s.personRepo.GetByID(context.Background(), *personID)
+		if err != nil {
+			return nil, fmt.Errorf("person not found: %w", err)
+		}
+		if ageGroup == "" {
+			ageGroup = ageGroupOn(person.TanggalLahir, date)
+		}
+	} else {
+		if _, err := s.visitorRepo.GetByID(context.Background(), *visitorID); err != nil {
+			return nil, fmt.Errorf("visitor not found: %w", err)
+		}
+	}
+	if ageGroup == "" {
+		ageGroup = entity.AgeGroupAdult
+	}
+	if !isValidAgeGroup(ageGroup) {
+		return nil, fmt.Errorf("invalid age group: %s", ageGroup)
+	}
+
+	if _, err := s.attendanceRepo.FindCheckIn(eventID, date, personID, visitorID); err == nil {
+		return nil, fmt.Errorf("already checked in for this occurrence")
+	}
+
+	attendance := &entity.EventAttendance{
+		ID:             uuid.New(),
+		EventID:        event.ID,
+		OccurrenceDate: date,
+		PersonID:       personID,
+		VisitorID:      visitorID,
+		AgeGroup:       ageGroup,
+		Method:         method,
+		CheckedInAt:    time.Now(),
+		CheckedInByID:  checkedInBy,
+	}
+
+	// Link the check-in to the attendee's RSVP so no-shows can be told apart from walk-ins
+	if registration, err := s.registrationRepo.FindActive(eventID, date, personID, visitorID); err == nil {
+		attendance.RegistrationID = &registration.ID
+	}
+
+	return attendance, nil
+}
+
+type occurrenceStart struct {
+	date  time.Time
+	start time.Time
+}
+
+// resolveOpenOccurrence resolves an occurrence that check-ins can be recorded for: today's or a past one
+func (s *eventAttendanceService) resolveOpenOccurrence(eventID uuid.UUID, occurrenceDate string) (*entity.Event, time.Time, time.Time, error) {
+	event, date, start, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, occurrenceDate)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	today := time.Now().In(recurrenceLocation(event.Timezone)).Format("2006-01-02")
+	if date.Format("2006-01-02") > today {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("check-in is not open yet: occurrence is on %s", occurrenceDate)
+	}
+
+	return event, date, start, nil
+}
+
+func buildAttendanceSummaries(event *entity.Event, occurrences []occurrenceStart, counts []repository.AttendanceCount, headcounts []entity.EventHeadcount) []dto.OccurrenceAttendanceSummary {
+	checkedIn := make(map[string]dto.AttendanceCounts)
+	for _, count := range counts {
+		key := count.OccurrenceDate.Format("2006-01-02")
+		checkedIn[key] = addAgeGroupCount(checkedIn[key], count.AgeGroup, int(count.Total))
+	}
+
+	counted := make(map[string]dto.AttendanceCounts)
+	for _, headcount := range headcounts {
+		counted[headcount.OccurrenceDate.Format("2006-01-02")] = dto.AttendanceCounts{
+			Total:  headcount.Adults + headcount.Youth + headcount.Kids,
+			Adults: headcount.Adults,
+			Youth:  headcount.Youth,
+			Kids:   headcount.Kids,
+		}
+	}
+
+	expected := expectedAttendance(event)
+	summaries := make([]dto.OccurrenceAttendanceSummary, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		key := occurrence.date.Format("2006-01-02")
+		actual := addAttendanceCounts(checkedIn[key], counted[key])
+		summaries = append(summaries, dto.OccurrenceAttendanceSummary{
+			EventID:        event.ID,
+			EventTitle:     event.Title,
+			OccurrenceDate: occurrence.date,
+			StartDatetime:  occurrence.start,
+			Expected:       expected,
+			CheckedIn:      checkedIn[key],
+			Headcount:      counted[key],
+			Actual:         actual,
+			Variance: dto.AttendanceCounts{
+				Total:  actual.Total - expected.Total,
+				Adults: actual.Adults - expected.Adults,
+				Youth:  actual.Youth - expected.Youth,
+				Kids:   actual.Kids - expected.Kids,
+			},
+			AttendanceRate: attendanceRate(actual.Total, expected.Total),
+		})
+	}
+	return summaries
+}
+
+// expectedAttendance falls back to the sum of the age groups when no overall expectation is set
+func expectedAttendance(event *entity.Event) dto.AttendanceCounts {
+	expected := dto.AttendanceCounts{
+		Total:  event.ExpectedParticipants,
+		Adults: event.ExpectedAdults,
+		Youth:  event.ExpectedYouth,
+		Kids:   event.ExpectedKids,
+	}
+	if expected.Total == 0 {
+		expected.Total = expected.Adults + expected.Youth + expected.Kids
+	}
+	return expected
+}
+
+func addAgeGroupCount(counts dto.AttendanceCounts, ageGroup string, n int) dto.AttendanceCounts {
+	switch ageGroup {
+	case entity.AgeGroupYouth:
+		counts.Youth += n
+	case entity.AgeGroupKid:
+		counts.Kids += n
+	default:
+		counts.Adults += n
+	}
+	counts.Total += n
+	return counts
+}
+
+func addAttendanceCounts(a, b dto.AttendanceCounts) dto.AttendanceCounts {
+	return dto.AttendanceCounts{
+		Total:  a.Total + b.Total,
+		Adults: a.Adults + b.Adults,
+		Youth:  a.Youth + b.Youth,
+		Kids:   a.Kids + b.Kids,
+	}
+}
+
+func attendanceRate(actual, expected int) float64 {
+	if expected == 0 {
+		return 0
+	}
+	return float64(actual) / float64(expected) * 100
+}
+
+// ageGroupOn derives the age group from a birth date; people without a birth date count as adults
+func ageGroupOn(birthDate, date time.Time) string {
+	if birthDate.IsZero() {
+		return entity.AgeGroupAdult
+	}
+
+	age := date.Year() - birthDate.Year()
+	if date.Month() < birthDate.Month() || (date.Month() == birthDate.Month() && date.Day() < birthDate.Day()) {
+		age--
+	}
+
+	switch {
+	case age < entity.YouthMinAge:
+		return entity.AgeGroupKid
+	case age < entity.AdultMinAge:
+		return entity.AgeGroupYouth
+	default:
+		return entity.AgeGroupAdult
+	}
+}
+
+func isValidAgeGroup(ageGroup string) bool {
+	switch ageGroup {
+	case entity.AgeGroupAdult, entity.AgeGroupYouth, entity.AgeGroupKid:
+		return true
+	}
+	return false
+}
+
+func headcountToResponse(headcount *entity.EventHeadcount) *dto.EventHeadcountResponse {
+	return &dto.EventHeadcountResponse{
+		EventID:        headcount.EventID,
+		OccurrenceDate: headcount.OccurrenceDate,
+		Adults:         headcount.Adults,
+		Youth:          headcount.Youth,
+		Kids:           headcount.Kids,
+		Total:          headcount.Adults + headcount.Youth + headcount.Kids,
+		Notes:          headcount.Notes,
+		RecordedByID:   headcount.RecordedByID,
+		UpdatedAt:      headcount.UpdatedAt,
+	}
+}
+
+func (s *eventAttendanceService) entityToResponse(attendance *entity.EventAttendance) *dto.EventAttendanceResponse {
+	response := &dto.EventAttendanceResponse{
+		ID:             attendance.ID,
+		EventID:        attendance.EventID,
+		EventTitle:     attendance.Event.Title,
+		OccurrenceDate: attendance.OccurrenceDate,
+		PersonID:       attendance.PersonID,
+		VisitorID:      attendance.VisitorID,
+		RegistrationID: attendance.RegistrationID,
+		AgeGroup:       attendance.AgeGroup,
+		Method:         attendance.Method,
+		CheckedInAt:    attendance.CheckedInAt,
+		CheckedInByID:  attendance.CheckedInByID,
+		Notes:          attendance.Notes,
+	}
+
+	if attendance.Person != nil {
+		response.Person = &dto.PersonSummary{
+			ID:           attendance.Person.ID,
+			Nama:         attendance.Person.Nama,
+			Email:        attendance.Person.Email,
+			NomorTelepon: attendance.Person.NomorTelepon,
+			ChurchID:     attendance.Person.ChurchID,
+		}
+	}
+	if attendance.Visitor != nil {
+		response.Visitor = &dto.VisitorSummary{
+			ID:   attendance.Visitor.ID,
+			Name: attendance.Visitor.Name,
+		}
+		if attendance.Visitor.PhoneNumber != nil {
+			response.Visitor.PhoneNumber = *attendance.Visitor.PhoneNumber
+		}
+		if attendance.Visitor.IGUsername != nil {
+			response.Visitor.IGUsername = *attendance.Visitor.IGUsername
+		}
+	}
+
+	return response
+}
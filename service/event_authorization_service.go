@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+// Event actions guarded by EventPIC permissions
+const (
+	EventActionEdit      = "edit"
+	EventActionDelete    = "delete"
+	EventActionAssignPIC = "assign_pic"
+)
+
+type EventAuthorizationService interface {
+	// CanPerform reports whether a person may perform an action on an event: as an active PIC with the
+	// permission for it, or as an admin of the event's church
+	CanPerform(ctx context.Context, eventID, personID uuid.UUID, action string) (bool, error)
+
+	// CanView reports whether a person may see an event: when it is public, owned by or shared with one
+	// of their churches, or they are one of its PICs
+	CanView(ctx context.Context, eventID, personID uuid.UUID) (bool, error)
+
+	// EventViewer returns the filter that limits event lists to what a person may see; their churches
+	// are the one they belong to and the ones they administer
+	EventViewer(ctx context.Context, personID uuid.UUID) (*dto.EventViewer, error)
+
+	// CanViewPerson reports whether a person may see another's PIC assignments, registrations and
+	// attendance: their own, or as an admin of the other person's church
+	CanViewPerson(ctx context.Context, viewerID, personID uuid.UUID) (bool, error)
+
+	// CanUseChurch reports whether a person may give an event to a church: as a member or an admin of it
+	CanUseChurch(ctx context.Context, personID, churchID uuid.UUID) (bool, error)
+
+	// IsChurchAdmin reports whether a person holds a PIC pelayanan in the church, or in any church when
+	// churchID is nil
+	IsChurchAdmin(ctx context.Context, personID uuid.UUID, churchID *uuid.UUID) (bool, error)
+
+	// CanOverrideVenueConflict reports whether a person may book a venue over its other bookings: as an
+	// admin of the church owning the venue
+	CanOverrideVenueConflict(ctx context.Context, personID, venueID uuid.UUID) (bool, error)
+
+	// Events that PIC assignments, occurrence PICs, rotations, registrations, attendances and musician
+	// roster places belong to
+	EventIDOfPIC(picID uuid.UUID) (uuid.UUID, error)
+	EventIDOfOccurrencePIC(id uuid.UUID) (uuid.UUID, error)
+	EventIDOfRotation(id uuid.UUID) (uuid.UUID, error)
+	EventIDOfRegistration(id uuid.UUID) (uuid.UUID, error)
+	EventIDOfAttendance(id uuid.UUID) (uuid.UUID, error)
+	EventIDOfJadwal(id uuid.UUID) (uuid.UUID, error)
+
+	// KetersediaanOwner returns the event and the musician a musician availability belongs to
+	KetersediaanOwner(id uuid.UUID) (eventID, personID uuid.UUID, err error)
+
+	// RegistrationOwner returns the event and the registrant of a registration; the person is nil for a
+	// visitor's registration
+	RegistrationOwner(id uuid.UUID) (eventID uuid.UUID, personID *uuid.UUID, err error)
+
+	// ChurchIDOfPerson returns the church a person belongs to
+	ChurchIDOfPerson(ctx context.Context, personID uuid.UUID) (uuid.UUID, error)
+
+	// Churches that events, templates and venues belong to; nil for an event or template of no church
+	ChurchIDOfEvent(id uuid.UUID) (*uuid.UUID, error)
+	ChurchIDOfTemplate(id uuid.UUID) (*uuid.UUID, error)
+	ChurchIDOfVenue(id uuid.UUID) (*uuid.UUID, error)
+
+	// Venues that events and templates book; nil when they book none
+	VenueIDOfEvent(id uuid.UUID) (*uuid.UUID, error)
+	VenueIDOfTemplate(id uuid.UUID) (*uuid.UUID, error)
+}
+
+type eventAuthorizationService struct {
+	eventRepo        repository.EventRepository
+	eventPICRepo     repository.EventPICRepository
+	pelayananRepo    repository.PelayananRepository
+	personRepo       repository.PersonRepository
+	registrationRepo repository.EventRegistrationRepository
+	attendanceRepo   repository.EventAttendanceRepository
+	templateRepo     repository.EventTemplateRepository
+	venueRepo        repository.VenueRepository
+	pemusikRepo      repository.PemusikRepository
+	eventPICService  EventPICService
+}
+
+func NewEventAuthorizationService(eventRepo repository.EventRepository, eventPICRepo repository.EventPICRepository, pelayananRepo repository.PelayananRepository, personRepo repository.PersonRepository, registrationRepo repository.EventRegistrationRepository, attendanceRepo repository.EventAttendanceRepository, templateRepo repository.EventTemplateRepository, venueRepo repository.VenueRepository, pemusikRepo repository.PemusikRepository, eventPICService EventPICService) EventAuthorizationService {
+	return &eventAuthorizationService{
+		eventRepo:        eventRepo,
+		eventPICRepo:     eventPICRepo,
+		pelayananRepo:    pelayananRepo,
+		personRepo:       personRepo,
+		registrationRepo: registrationRepo,
+		attendanceRepo:   attendanceRepo,
+		templateRepo:     templateRepo,
+		venueRepo:        venueRepo,
+		pemusikRepo:      pemusikRepo,
+		eventPICService:  eventPICService,
+	}
+}
+
+func (s *eventAuthorizationService) CanPerform(ctx context.Context, eventID, personID uuid.UUID, action string) (bool, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	allowed, err := s.eventPICService.ValidateEventPICPermissions(eventID, personID, action)
+	if err != nil {
+		return false, fmt.Errorf("failed to check PIC permissions: %w", err)
+	}
+	if allowed {
+		return true, nil
+	}
+
+	// Church admins hold a PIC pelayanan in the church owning the event, or for events without an
+	// owner the church of its venue. Admins of the churches an event is shared with may staff it but
+	// not change it. An event that belongs to no church is left to its PICs.
+	churches := make(map[uuid.UUID]bool)
+	if event.ChurchID != nil {
+		churches[*event.ChurchID] = true
+	} else if event.Venue != nil {
+		churches[event.Venue.ChurchID] = true
+	}
+	if action == EventActionAssignPIC {
+		for _, church := range event.Churches {
+			churches[church.ID] = true
+		}
+	}
+
+	if len(churches) == 0 {
+		return false, nil
+	}
+	adminOf, err := s.adminChurches(ctx, personID)
+	if err != nil {
+		return false, err
+	}
+	for churchID := range adminOf {
+		if churches[churchID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *eventAuthorizationService) CanView(ctx context.Context, eventID, personID uuid.UUID) (bool, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.IsPublic {
+		return true, nil
+	}
+
+	viewer, err := s.EventViewer(ctx, personID)
+	if err != nil {
+		return false, err
+	}
+	return eventVisibleTo(event, viewer), nil
+}
+
+func (s *eventAuthorizationService) EventViewer(ctx context.Context, personID uuid.UUID) (*dto.EventViewer, error) {
+	person, err := s.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	adminOf, err := s.adminChurches(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer := &dto.EventViewer{PersonID: personID, ChurchIDs: []uuid.UUID{person.ChurchID}}
+	for churchID := range adminOf {
+		if churchID != person.ChurchID {
+			viewer.ChurchIDs = append(viewer.ChurchIDs, churchID)
+		}
+	}
+	return viewer, nil
+}
+
+func (s *eventAuthorizationService) CanViewPerson(ctx context.Context, viewerID, personID uuid.UUID) (bool, error) {
+	if viewerID == personID {
+		return true, nil
+	}
+	person, err := s.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get person: %w", err)
+	}
+	return s.IsChurchAdmin(ctx, viewerID, &person.ChurchID)
+}
+
+func (s *eventAuthorizationService) CanUseChurch(ctx context.Context, personID, churchID uuid.UUID) (bool, error) {
+	person, err := s.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get person: %w", err)
+	}
+	if person.ChurchID == churchID {
+		return true, nil
+	}
+
+	adminOf, err := s.adminChurches(ctx, personID)
+	if err != nil {
+		return false, err
+	}
+	return adminOf[churchID], nil
+}
+
+func (s *eventAuthorizationService) IsChurchAdmin(ctx context.Context, personID uuid.UUID, churchID *uuid.UUID) (bool, error) {
+	adminOf, err := s.adminChurches(ctx, personID)
+	if err != nil {
+		return false, err
+	}
+	if churchID == nil {
+		return len(adminOf) > 0, nil
+	}
+	return adminOf[*churchID], nil
+}
+
+func (s *eventAuthorizationService) CanOverrideVenueConflict(ctx context.Context, personID, venueID uuid.UUID) (bool, error) {
+	churchID, err := s.ChurchIDOfVenue(venueID)
+	if err != nil {
+		return false, err
+	}
+	return s.IsChurchAdmin(ctx, personID, churchID)
+}
+
+// adminChurches returns the churches in which the person holds a PIC pelayanan
+func (s *eventAuthorizationService) adminChurches(ctx context.Context, personID uuid.UUID) (map[uuid.UUID]bool, error) {
+	assignments, err := s.pelayananRepo.GetPelayananByPersonID(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pelayanan: %w", err)
+	}
+
+	churches := make(map[uuid.UUID]bool)
+	for _, assignment := range assignments {
+		if assignment.Pelayanan.IsPic {
+			churches[assignment.ChurchID] = true
+		}
+	}
+	return churches, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfPIC(picID uuid.UUID) (uuid.UUID, error) {
+	pic, err := s.eventPICRepo.GetByID(picID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get event PIC: %w", err)
+	}
+	return pic.EventID, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfOccurrencePIC(id uuid.UUID) (uuid.UUID, error) {
+	pic, err := s.eventPICRepo.GetOccurrencePICByID(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get occurrence PIC: %w", err)
+	}
+	return pic.EventID, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfRotation(id uuid.UUID) (uuid.UUID, error) {
+	rotation, err := s.eventPICRepo.GetRotationByID(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get PIC rotation: %w", err)
+	}
+	return rotation.EventID, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfRegistration(id uuid.UUID) (uuid.UUID, error) {
+	registration, err := s.registrationRepo.GetByID(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+	return registration.EventID, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfAttendance(id uuid.UUID) (uuid.UUID, error) {
+	attendance, err := s.attendanceRepo.GetByID(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	return attendance.EventID, nil
+}
+
+func (s *eventAuthorizationService) EventIDOfJadwal(id uuid.UUID) (uuid.UUID, error) {
+	jadwal, err := s.pemusikRepo.GetJadwalByID(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get roster place: %w", err)
+	}
+	return jadwal.EventId, nil
+}
+
+func (s *eventAuthorizationService) KetersediaanOwner(id uuid.UUID) (uuid.UUID, uuid.UUID, error) {
+	ketersediaan, err := s.pemusikRepo.GetKetersediaanByID(id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	return ketersediaan.EventId, ketersediaan.PersonID, nil
+}
+
+func (s *eventAuthorizationService) RegistrationOwner(id uuid.UUID) (uuid.UUID, *uuid.UUID, error) {
+	registration, err := s.registrationRepo.GetByID(id)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+	return registration.EventID, registration.PersonID, nil
+}
+
+func (s *eventAuthorizationService) ChurchIDOfPerson(ctx context.Context, personID uuid.UUID) (uuid.UUID, error) {
+	person, err := s.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person.ChurchID, nil
+}
+
+func (s *eventAuthorizationService) ChurchIDOfEvent(id uuid.UUID) (*uuid.UUID, error) {
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event.ChurchID, nil
+}
+
+func (s *eventAuthorizationService) ChurchIDOfTemplate(id uuid.UUID) (*uuid.UUID, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return template.ChurchID, nil
+}
+
+func (s *eventAuthorizationService) ChurchIDOfVenue(id uuid.UUID) (*uuid.UUID, error) {
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return &venue.ChurchID, nil
+}
+
+func (s *eventAuthorizationService) VenueIDOfEvent(id uuid.UUID) (*uuid.UUID, error) {
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event.VenueID, nil
+}
+
+func (s *eventAuthorizationService) VenueIDOfTemplate(id uuid.UUID) (*uuid.UUID, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return template.VenueID, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// EventChangeNotifier tells PICs who opted in with NotifyOnChanges that their event was changed
+type EventChangeNotifier interface {
+	NotifyEventChanged(change *EventChange) error
+}
+
+// EventChange describes one edit of an event as a field-level diff
+type EventChange struct {
+	Event       *entity.Event // the event as it was before the change
+	Fields      []FieldChange
+	Occurrences []OccurrenceChange
+}
+
+// FieldChange is one changed event field, formatted for people
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// OccurrenceChange holds the old and new times of the affected occurrence(s), as wall clock in the event's zone
+type OccurrenceChange struct {
+	Date     time.Time
+	Future   bool // the change applies to Date and every later occurrence
+	OldStart time.Time
+	OldEnd   time.Time
+	NewStart *time.Time // nil when the occurrence(s) were cancelled
+	NewEnd   *time.Time
+}
+
+// IsEmpty reports whether nothing a PIC would notice has changed
+func (c *EventChange) IsEmpty() bool {
+	return len(c.Fields) == 0 && len(c.Occurrences) == 0
+}
+
+// IsCancellation reports whether the change only cancels occurrences
+func (c *EventChange) IsCancellation() bool {
+	if len(c.Fields) > 0 || len(c.Occurrences) == 0 {
+		return false
+	}
+	for _, occurrence := range c.Occurrences {
+		if occurrence.NewStart != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// AddField records a field change unless the value stayed the same
+func (c *EventChange) AddField(field, oldValue, newValue string) {
+	if oldValue != newValue {
+		c.Fields = append(c.Fields, FieldChange{Field: field, Old: oldValue, New: newValue})
+	}
+}
+
+// Lines renders the diff, one change per line
+func (c *EventChange) Lines() []string {
+	var lines []string
+	for _, field := range c.Fields {
+		lines = append(lines, fmt.Sprintf("%s: %s → %s", field.Field, displayValue(field.Old), displayValue(field.New)))
+	}
+
+	timezone := c.Event.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	for _, occurrence := range c.Occurrences {
+		scope := "Occurrence on"
+		if occurrence.Future {
+			scope = "Occurrences from"
+		}
+		newTimes := "cancelled"
+		if occurrence.NewStart != nil && occurrence.NewEnd != nil {
+			newTimes = formatOccurrenceTimes(*occurrence.NewStart, *occurrence.NewEnd)
+			if occurrence.NewStart.Format("2006-01-02") != occurrence.Date.Format("2006-01-02") {
+				newTimes = occurrence.NewStart.Format("Monday, 2 January 2006") + " " + newTimes
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s → %s (%s)",
+			scope, occurrence.Date.Format("Monday, 2 January 2006"),
+			formatOccurrenceTimes(occurrence.OldStart, occurrence.OldEnd), newTimes, timezone))
+	}
+	return lines
+}
+
+type eventChangeNotifier struct {
+	eventPICRepo     repository.EventPICRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	sendMail         func(to, subject, body string) error
+}
+
+func NewEventChangeNotifier(
+	eventPICRepo repository.EventPICRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+) EventChangeNotifier {
+	return &eventChangeNotifier{
+		eventPICRepo:     eventPICRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		sendMail:         utils.SendMail,
+	}
+}
+
+// NotifyEventChanged creates an in-app notification for every opted-in PIC with a user account
+// and emails those with an address. Emails are sent in the background so edits are not held up by SMTP.
+func (n *eventChangeNotifier) NotifyEventChanged(change *EventChange) error {
+	if change.IsEmpty() {
+		return nil
+	}
+
+	pics, err := n.eventPICRepo.GetActivePICsByEventID(change.Event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get active PICs: %w", err)
+	}
+
+	title := fmt.Sprintf("Event updated: %s", change.Event.Title)
+	notificationType := "info"
+	if change.IsCancellation() {
+		title = fmt.Sprintf("Event cancelled: %s", change.Event.Title)
+		notificationType = "warning"
+	}
+	lines := change.Lines()
+	message := strings.Join(lines, "\n")
+
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = "<li>" + html.EscapeString(line) + "</li>"
+	}
+
+	var problems []string
+	for _, pic := range pics {
+		if !pic.NotifyOnChanges || !picCoversChange(&pic, change) {
+			continue
+		}
+		person := pic.Person
+
+		if user, err := n.userRepo.GetByPersonID(context.Background(), person.ID); err == nil {
+			churchID := person.ChurchID
+			notification := &entity.Notification{
+				ID:       uuid.New(),
+				Title:    title,
+				Message:  message,
+				Type:     notificationType,
+				UserID:   user.ID,
+				ChurchID: &churchID,
+			}
+			if err := n.notificationRepo.Create(notification); err != nil {
+				problems = append(problems, fmt.Sprintf("notification for %s: %v", person.ID, err))
+			}
+		}
+
+		if person.Email != "" {
+			body := fmt.Sprintf("<p>Hi %s,</p><p>%s has changed:</p><ul>%s</ul><p>You are receiving this because you are a PIC of this event.</p>",
+				html.EscapeString(person.Nama), html.EscapeString(change.Event.Title), strings.Join(escaped, ""))
+			go func(to string) {
+				if err := n.sendMail(to, title, body); err != nil {
+					log.Printf("event change notifications: failed to email %s: %v", to, err)
+				}
+			}(person.Email)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("failed to notify PICs: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// picCoversChange skips PICs whose assignment ends before the first affected occurrence
+func picCoversChange(pic *entity.EventPIC, change *EventChange) bool {
+	if pic.EndDate == nil || len(change.Occurrences) == 0 {
+		return true
+	}
+	endDate := pic.EndDate.Format("2006-01-02")
+	for _, occurrence := range change.Occurrences {
+		if occurrence.Date.Format("2006-01-02") <= endDate {
+			return true
+		}
+	}
+	return false
+}
+
+func formatOccurrenceTimes(start, end time.Time) string {
+	return fmt.Sprintf("%s–%s", start.Format("15:04"), end.Format("15:04"))
+}
+
+func displayValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
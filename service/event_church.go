@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+// eventChurches validates the church owning an event and the churches it is shared with. Without an
+// owner the church of the booked venue owns the event. The owner is dropped from the shared churches.
+func (s *eventService) eventChurches(churchID *uuid.UUID, sharedChurchIDs []uuid.UUID, venueID *uuid.UUID) (*uuid.UUID, []uuid.UUID, error) {
+	if churchID != nil && *churchID == uuid.Nil {
+		churchID = nil
+	}
+	if churchID == nil && venueID != nil {
+		venue, err := s.venueRepo.GetByID(*venueID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("venue not found: %w", err)
+		}
+		churchID = &venue.ChurchID
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var churchIDs, shared []uuid.UUID
+	if churchID != nil {
+		seen[*churchID] = true
+		churchIDs = append(churchIDs, *churchID)
+	}
+	for _, id := range sharedChurchIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		churchIDs = append(churchIDs, id)
+		shared = append(shared, id)
+	}
+	if len(churchIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	count, err := s.eventRepo.CountChurches(churchIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check churches: %w", err)
+	}
+	if int(count) != len(churchIDs) {
+		return nil, nil, fmt.Errorf("church not found")
+	}
+	return churchID, shared, nil
+}
+
+// eventOfChurch reports whether an event is owned by or shared with a church
+func eventOfChurch(event *entity.Event, churchID uuid.UUID) bool {
+	if event.ChurchID != nil && *event.ChurchID == churchID {
+		return true
+	}
+	for _, church := range event.Churches {
+		if church.ID == churchID {
+			return true
+		}
+	}
+	return false
+}
+
+// eventVisibleTo reports whether a viewer may see an event, matching the repositories' viewer filter.
+// A nil viewer sees every event.
+func eventVisibleTo(event *entity.Event, viewer *dto.EventViewer) bool {
+	if viewer == nil || event.IsPublic {
+		return true
+	}
+	for _, churchID := range viewer.ChurchIDs {
+		if eventOfChurch(event, churchID) {
+			return true
+		}
+	}
+	for _, pic := range event.EventPICs {
+		if pic.PersonID == viewer.PersonID {
+			return true
+		}
+	}
+	return false
+}
+
+func churchIDsOf(churches []entity.Church) []uuid.UUID {
+	ids := make([]uuid.UUID, len(churches))
+	for i, church := range churches {
+		ids[i] = church.ID
+	}
+	return ids
+}
+
+// churchStubs stands in for the shared churches just saved, for building a response
+func churchStubs(ids []uuid.UUID) []entity.Church {
+	churches := make([]entity.Church, len(ids))
+	for i, id := range ids {
+		churches[i] = entity.Church{ID: id}
+	}
+	return churches
+}
@@ -0,0 +1,695 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+const (
+	ICalProductID = "-//Every Nation Indonesia//Events//EN"
+	ICalUIDDomain = "en-indo-be"
+)
+
+type EventICalService interface {
+	ExportEvent(id uuid.UUID) ([]byte, error)
+	ExportEvents(req *dto.EventFilterRequest) ([]byte, error)
+	ExportPublicEvents(req *dto.EventFilterRequest) ([]byte, error)
+	ImportCalendar(data []byte, req *dto.ImportEventsRequest) (*dto.ImportEventsResponse, error)
+}
+
+type eventICalService struct {
+	eventRepo           repository.EventRepository
+	eventService        EventService
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+func NewEventICalService(eventRepo repository.EventRepository, eventService EventService) EventICalService {
+	return &eventICalService{
+		eventRepo:           eventRepo,
+		eventService:        eventService,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+func (s *eventICalService) ExportEvent(id uuid.UUID) ([]byte, error) {
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return s.buildCalendar([]entity.Event{*event}, event.Title)
+}
+
+func (s *eventICalService) ExportEvents(req *dto.EventFilterRequest) ([]byte, error) {
+	events, err := s.filterEvents(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCalendar(events, "Events")
+}
+
+func (s *eventICalService) ExportPublicEvents(req *dto.EventFilterRequest) ([]byte, error) {
+	isPublic := true
+	publicReq := *req
+	publicReq.IsPublic = &isPublic
+
+	events, err := s.filterEvents(&publicReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCalendar(events, "Public Events")
+}
+
+// filterEvents applies the same filters as ListEvents without pagination, since a feed must contain every matching series
+func (s *eventICalService) filterEvents(req *dto.EventFilterRequest) ([]entity.Event, error) {
+	filters := repository.EventFilters{
+		Type:     req.Type,
+		IsPublic: req.IsPublic,
+		Search:   req.Search,
+		Viewer:   req.Viewer,
+	}
+
+	events, _, err := s.eventRepo.List(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var rangeStart, rangeEnd *time.Time
+	if req.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date format: %w", err)
+		}
+		rangeStart = &parsed
+	}
+	if req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date format: %w", err)
+		}
+		rangeEnd = &parsed
+	}
+
+	if rangeStart == nil && rangeEnd == nil {
+		return events, nil
+	}
+
+	var filtered []entity.Event
+	for _, event := range events {
+		// A series is kept when any part of it can fall inside the range
+		if rangeEnd != nil && event.EventDate.After(*rangeEnd) {
+			continue
+		}
+		if rangeStart != nil {
+			lastDate := event.EventDate
+			if event.RecurrenceRule != nil {
+				if event.RecurrenceRule.Until == nil {
+					lastDate = *rangeStart
+				} else {
+					lastDate = *event.RecurrenceRule.Until
+				}
+			}
+			if lastDate.Before(*rangeStart) {
+				continue
+			}
+		}
+		filtered = append(filtered, event)
+	}
+
+	return filtered, nil
+}
+
+func (s *eventICalService) buildCalendar(events []entity.Event, name string) ([]byte, error) {
+	w := utils.NewICalWriter()
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Line("PRODID", ICalProductID)
+	w.Line("CALSCALE", "GREGORIAN")
+	w.Line("METHOD", "PUBLISH")
+	w.Line("X-WR-CALNAME", utils.ICalEscapeText(name))
+
+	// Each zone used by the events needs one VTIMEZONE covering the span of its events
+	type zoneRange struct {
+		loc      *time.Location
+		from, to time.Time
+	}
+	zones := make(map[string]*zoneRange)
+	var zoneOrder []string
+	for i := range events {
+		loc, tzid := s.eventLocation(&events[i])
+		if tzid == "" {
+			continue
+		}
+		from, to := s.eventSpan(&events[i], loc)
+		zone, ok := zones[tzid]
+		if !ok {
+			zones[tzid] = &zoneRange{loc: loc, from: from, to: to}
+			zoneOrder = append(zoneOrder, tzid)
+			continue
+		}
+		if from.Before(zone.from) {
+			zone.from = from
+		}
+		if to.After(zone.to) {
+			zone.to = to
+		}
+	}
+	for _, tzid := range zoneOrder {
+		zone := zones[tzid]
+		utils.ICalWriteTimezone(w, zone.loc, zone.from, zone.to)
+	}
+
+	for i := range events {
+		exceptions, err := s.eventRepo.GetRecurrenceExceptions(events[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recurrence exceptions: %w", err)
+		}
+		s.writeEvent(w, &events[i], exceptions)
+	}
+
+	w.Line("END", "VCALENDAR")
+	return w.Bytes(), nil
+}
+
+func (s *eventICalService) writeEvent(w *utils.ICalWriter, event *entity.Event, exceptions []entity.RecurrenceException) {
+	loc, tzid := s.eventLocation(event)
+	duration := event.EndDatetime.Sub(event.StartDatetime)
+
+	w.Line("BEGIN", "VEVENT")
+	s.writeEventProperties(w, event)
+	s.writeDateTime(w, "DTSTART", event, tzid, event.StartDatetime)
+	s.writeEndDateTime(w, event, tzid, event.StartDatetime, event.EndDatetime)
+
+	if event.RecurrenceRule != nil {
+		w.Line("RRULE", s.recurrenceGenerator.FormatRRule(event.RecurrenceRule, loc))
+		for _, rdate := range s.recurrenceGenerator.RDateStarts(event, event.RecurrenceRule) {
+			s.writeDateTime(w, "RDATE", event, tzid, rdate)
+		}
+
+		for _, exception := range exceptions {
+			if exception.IsSkipped {
+				s.writeDateTime(w, "EXDATE", event, tzid, s.originalOccurrenceStart(event, &exception))
+			}
+		}
+	}
+	w.Line("END", "VEVENT")
+
+	if event.RecurrenceRule == nil {
+		return
+	}
+
+	// Overridden instances are separate VEVENTs sharing the UID, keyed by RECURRENCE-ID
+	for _, exception := range exceptions {
+		if exception.IsSkipped || (exception.OverrideStart == nil && exception.OverrideEnd == nil) {
+			continue
+		}
+
+		originalStart := s.originalOccurrenceStart(event, &exception)
+		start := originalStart
+		if exception.OverrideStart != nil {
+			start = *exception.OverrideStart
+		}
+		end := start.Add(duration)
+		if exception.OverrideEnd != nil {
+			end = *exception.OverrideEnd
+		}
+
+		w.Line("BEGIN", "VEVENT")
+		s.writeEventProperties(w, event)
+		s.writeDateTime(w, "RECURRENCE-ID", event, tzid, originalStart)
+		s.writeDateTime(w, "DTSTART", event, tzid, start)
+		s.writeEndDateTime(w, event, tzid, start, end)
+		if exception.Notes != "" {
+			w.Line("COMMENT", utils.ICalEscapeText(exception.Notes))
+		}
+		w.Line("END", "VEVENT")
+	}
+}
+
+func (s *eventICalService) writeEventProperties(w *utils.ICalWriter, event *entity.Event) {
+	w.Line("UID", fmt.Sprintf("%s@%s", event.ID, ICalUIDDomain))
+	stamp := event.UpdatedAt
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	w.Line("DTSTAMP", stamp.UTC().Format(utils.ICalUTCTimeFormat))
+	if !event.CreatedAt.IsZero() {
+		w.Line("CREATED", event.CreatedAt.UTC().Format(utils.ICalUTCTimeFormat))
+	}
+	if !event.UpdatedAt.IsZero() {
+		w.Line("LAST-MODIFIED", event.UpdatedAt.UTC().Format(utils.ICalUTCTimeFormat))
+	}
+	w.Line("SUMMARY", utils.ICalEscapeText(event.Title))
+	if event.Description != "" {
+		w.Line("DESCRIPTION", utils.ICalEscapeText(event.Description))
+	}
+	if event.EventLocation != "" {
+		w.Line("LOCATION", utils.ICalEscapeText(event.EventLocation))
+	}
+	if event.Type != "" {
+		w.Line("CATEGORIES", utils.ICalEscapeText(event.Type))
+	}
+	if event.IsPublic {
+		w.Line("CLASS", "PUBLIC")
+	} else {
+		w.Line("CLASS", "PRIVATE")
+	}
+}
+
+// writeDateTime writes a date-time property using the wall-clock fields stored on the event
+func (s *eventICalService) writeDateTime(w *utils.ICalWriter, name string, event *entity.Event, tzid string, value time.Time) {
+	switch {
+	case event.AllDay:
+		w.Line(name+";VALUE=DATE", value.Format(utils.ICalDateFormat))
+	case tzid == "":
+		w.Line(name, value.UTC().Format(utils.ICalUTCTimeFormat))
+	default:
+		w.Line(name+";TZID="+tzid, value.Format(utils.ICalLocalTimeFormat))
+	}
+}
+
+func (s *eventICalService) writeEndDateTime(w *utils.ICalWriter, event *entity.Event, tzid string, start, end time.Time) {
+	if event.AllDay {
+		// DTEND is exclusive for all-day events
+		endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+		if !endDate.After(start) {
+			endDate = time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+		}
+		w.Line("DTEND;VALUE=DATE", endDate.Format(utils.ICalDateFormat))
+		return
+	}
+	s.writeDateTime(w, "DTEND", event, tzid, end)
+}
+
+func (s *eventICalService) originalOccurrenceStart(event *entity.Event, exception *entity.RecurrenceException) time.Time {
+	if exception.OriginalStartTime != nil {
+		return *exception.OriginalStartTime
+	}
+	return s.recurrenceGenerator.ScheduledStartOn(event, exception.ExceptionDate)
+}
+
+// eventLocation resolves Event.Timezone; an empty tzid means times are written in UTC
+func (s *eventICalService) eventLocation(event *entity.Event) (*time.Location, string) {
+	if event.Timezone == "" || event.Timezone == "UTC" {
+		return time.UTC, ""
+	}
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return time.UTC, ""
+	}
+	return loc, loc.String()
+}
+
+// eventSpan returns the period a VTIMEZONE must cover for this event
+func (s *eventICalService) eventSpan(event *entity.Event, loc *time.Location) (time.Time, time.Time) {
+	from := time.Date(event.StartDatetime.Year(), event.StartDatetime.Month(), event.StartDatetime.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(event.EndDatetime.Year(), event.EndDatetime.Month(), event.EndDatetime.Day(), 23, 59, 59, 0, loc)
+
+	if event.RecurrenceRule != nil {
+		if event.RecurrenceRule.Until != nil {
+			until := event.RecurrenceRule.Until
+			to = time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, loc)
+		} else {
+			// Open-ended series: cover one year past whichever is later, the series start or today
+			horizon := time.Now().In(loc)
+			if to.After(horizon) {
+				horizon = to
+			}
+			to = horizon.AddDate(1, 0, 0)
+		}
+		// Extra dates can lie beyond that year
+		if rdates := s.recurrenceGenerator.RDateStarts(event, event.RecurrenceRule); len(rdates) > 0 {
+			last := rdates[len(rdates)-1]
+			if lastEnd := time.Date(last.Year(), last.Month(), last.Day(), 23, 59, 59, 0, loc); lastEnd.After(to) {
+				to = lastEnd
+			}
+		}
+	}
+
+	return from, to
+}
+
+// ImportCalendar turns every VEVENT in an .ics document into an event with its recurrence rule and exceptions.
+// Each VEVENT succeeds or fails on its own; in dry-run mode nothing is written.
+func (s *eventICalService) ImportCalendar(data []byte, req *dto.ImportEventsRequest) (*dto.ImportEventsResponse, error) {
+	calendar, err := utils.ParseICal(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iCalendar file: %w", err)
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = calendar.PropertyValue("X-WR-TIMEZONE")
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	defaultLoc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	// Overridden instances (RECURRENCE-ID) are attached to their series by UID
+	var masters []*utils.ICalComponent
+	overrides := make(map[string][]*utils.ICalComponent)
+	for _, vevent := range calendar.ComponentsNamed("VEVENT") {
+		if vevent.Property("RECURRENCE-ID") != nil {
+			uid := vevent.PropertyValue("UID")
+			overrides[uid] = append(overrides[uid], vevent)
+			continue
+		}
+		masters = append(masters, vevent)
+	}
+
+	response := &dto.ImportEventsResponse{
+		DryRun:  req.DryRun,
+		Results: []dto.ImportedEventResponse{},
+	}
+
+	for i, vevent := range masters {
+		uid := vevent.PropertyValue("UID")
+		result := s.importEvent(vevent, overrides[uid], defaultLoc, req)
+		result.Index = i
+		delete(overrides, uid)
+		response.Results = append(response.Results, result)
+	}
+
+	// Overrides whose series is not part of the file cannot be imported
+	for uid, orphans := range overrides {
+		for _, orphan := range orphans {
+			response.Results = append(response.Results, dto.ImportedEventResponse{
+				Index:   len(response.Results),
+				UID:     uid,
+				Summary: utils.ICalUnescapeText(orphan.PropertyValue("SUMMARY")),
+				Status:  dto.ImportStatusFailed,
+				Error:   "RECURRENCE-ID refers to a series that is not in this file",
+			})
+		}
+	}
+
+	response.Total = len(response.Results)
+	for _, result := range response.Results {
+		switch result.Status {
+		case dto.ImportStatusCreated:
+			response.Created++
+		case dto.ImportStatusFailed:
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
+
+func (s *eventICalService) importEvent(vevent *utils.ICalComponent, overrides []*utils.ICalComponent, defaultLoc *time.Location, req *dto.ImportEventsRequest) dto.ImportedEventResponse {
+	result := dto.ImportedEventResponse{
+		UID:     vevent.PropertyValue("UID"),
+		Summary: utils.ICalUnescapeText(vevent.PropertyValue("SUMMARY")),
+	}
+	fail := func(err error) dto.ImportedEventResponse {
+		result.Status = dto.ImportStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	createReq, loc, warnings, err := s.veventToCreateRequest(vevent, defaultLoc, req)
+	if err != nil {
+		return fail(err)
+	}
+	result.Warnings = warnings
+	result.Request = createReq
+
+	var exceptions []entity.RecurrenceException
+	if createReq.RecurrenceRule != nil {
+		exceptions, err = s.collectImportExceptions(vevent, overrides, createReq, loc)
+		if err != nil {
+			return fail(err)
+		}
+	} else if len(vevent.PropertiesNamed("EXDATE")) > 0 || len(overrides) > 0 {
+		result.Warnings = append(result.Warnings, "EXDATE and RECURRENCE-ID are ignored for non-recurring events")
+	}
+
+	for _, exception := range exceptions {
+		result.Exceptions = append(result.Exceptions, dto.ImportedExceptionResponse{
+			OccurrenceDate: exception.ExceptionDate.Format("2006-01-02"),
+			IsSkipped:      exception.IsSkipped,
+			OverrideStart:  exception.OverrideStart,
+			OverrideEnd:    exception.OverrideEnd,
+		})
+	}
+
+	// A dry run makes the same checks as the import, including the venue booking and churches
+	if req.DryRun {
+		if err := s.eventService.ValidateCreateEvent(createReq); err != nil {
+			return fail(err)
+		}
+		result.Status = dto.ImportStatusWouldCreate
+		return result
+	}
+
+	event, err := s.eventService.CreateEventWithExceptions(createReq, exceptions)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Status = dto.ImportStatusCreated
+	result.Request = nil
+	result.Event = event
+	return result
+}
+
+// veventToCreateRequest maps a VEVENT to the request EventService.CreateEvent expects
+func (s *eventICalService) veventToCreateRequest(vevent *utils.ICalComponent, defaultLoc *time.Location, req *dto.ImportEventsRequest) (*dto.CreateEventRequest, *time.Location, []string, error) {
+	var warnings []string
+
+	dtstart := vevent.Property("DTSTART")
+	if dtstart == nil {
+		return nil, nil, nil, errors.New("DTSTART is required")
+	}
+	start, allDay, err := utils.ICalParseDateTime(dtstart.Value, dtstart.Params, defaultLoc)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("DTSTART: %w", err)
+	}
+	loc := start.Location()
+
+	var end time.Time
+	if dtend := vevent.Property("DTEND"); dtend != nil {
+		end, _, err = utils.ICalParseDateTime(dtend.Value, dtend.Params, loc)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("DTEND: %w", err)
+		}
+		end = end.In(loc)
+	} else if duration := vevent.PropertyValue("DURATION"); duration != "" {
+		d, err := utils.ICalParseDuration(duration)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("DURATION: %w", err)
+		}
+		end = start.Add(d)
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	} else {
+		end = start
+	}
+
+	startTime := start.Format("15:04")
+	endTime := end.Format("15:04")
+	if allDay {
+		startTime, endTime = "00:00", "23:59"
+		if end.Sub(start) > 24*time.Hour {
+			warnings = append(warnings, "multi-day all-day event imported as a single day")
+		}
+	} else if end.Format("2006-01-02") != start.Format("2006-01-02") {
+		warnings = append(warnings, "event spans multiple days; end time is kept on the start date")
+	}
+
+	title := utils.ICalUnescapeText(vevent.PropertyValue("SUMMARY"))
+	if title == "" {
+		title = "Untitled event"
+	}
+
+	eventType := req.Type
+	if eventType == "" {
+		eventType = "event"
+	}
+	for _, category := range strings.Split(utils.ICalUnescapeText(vevent.PropertyValue("CATEGORIES")), ",") {
+		switch strings.ToLower(strings.TrimSpace(category)) {
+		case "event", "ibadah", "spiritual_journey":
+			eventType = strings.ToLower(strings.TrimSpace(category))
+		}
+	}
+
+	isPublic := req.IsPublic
+	switch strings.ToUpper(vevent.PropertyValue("CLASS")) {
+	case "PUBLIC":
+		isPublic = true
+	case "PRIVATE", "CONFIDENTIAL":
+		isPublic = false
+	}
+
+	createReq := &dto.CreateEventRequest{
+		Title:         title,
+		Description:   utils.ICalUnescapeText(vevent.PropertyValue("DESCRIPTION")),
+		Type:          eventType,
+		EventDate:     start.Format("2006-01-02"),
+		EventLocation: utils.ICalUnescapeText(vevent.PropertyValue("LOCATION")),
+		StartTime:     startTime,
+		EndTime:       endTime,
+		AllDay:        allDay,
+		Timezone:      loc.String(),
+		IsPublic:      isPublic,
+		ChurchID:      req.ChurchID,
+	}
+
+	rrules := vevent.PropertiesNamed("RRULE")
+	if len(rrules) > 1 {
+		return nil, nil, nil, errors.New("multiple RRULE properties are not supported")
+	}
+	if len(rrules) == 1 {
+		rule, err := s.recurrenceGenerator.ParseRRule(rrules[0].Value, loc)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(rule.ByYearDay) > 0 {
+			return nil, nil, nil, &RRuleError{Part: "BYYEARDAY", Message: "part is not supported by the recurrence generator"}
+		}
+		createReq.RecurrenceRule = rule
+	}
+	if rdates := vevent.PropertiesNamed("RDATE"); len(rdates) > 0 {
+		if createReq.RecurrenceRule == nil {
+			warnings = append(warnings, "RDATE without RRULE is not supported and was ignored")
+		} else {
+			rdateWarnings, err := s.importRDates(rdates, createReq, loc)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			warnings = append(warnings, rdateWarnings...)
+		}
+	}
+	if len(vevent.PropertiesNamed("EXRULE")) > 0 {
+		warnings = append(warnings, "EXRULE is not supported and was ignored")
+	}
+
+	return createReq, loc, warnings, nil
+}
+
+// importRDates adds RDATE values to the rule as extra dates on the event's wall clock. Periods and
+// dates outside the series are left out with a warning; a day that already has an occurrence keeps it.
+func (s *eventICalService) importRDates(properties []utils.ICalProperty, createReq *dto.CreateEventRequest, loc *time.Location) ([]string, error) {
+	var warnings []string
+	rule := createReq.RecurrenceRule
+	seen := map[string]bool{createReq.EventDate: true}
+
+	for _, rdate := range properties {
+		if rdate.Params["VALUE"] == "PERIOD" {
+			warnings = append(warnings, "RDATE periods are not supported and were ignored")
+			continue
+		}
+		for _, value := range strings.Split(rdate.Value, ",") {
+			extra, isDate, err := utils.ICalParseDateTime(strings.TrimSpace(value), rdate.Params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("RDATE: %w", err)
+			}
+			extra = extra.In(loc)
+			dateKey := extra.Format("2006-01-02")
+			switch {
+			case dateKey < createReq.EventDate || (rule.Until != nil && dateKey > *rule.Until):
+				warnings = append(warnings, fmt.Sprintf("RDATE %s is outside the series and was ignored", dateKey))
+				continue
+			case seen[dateKey]:
+				continue
+			}
+			seen[dateKey] = true
+
+			if isDate || createReq.AllDay || extra.Format("15:04") == createReq.StartTime {
+				rule.RDates = append(rule.RDates, dateKey)
+			} else {
+				rule.RDates = append(rule.RDates, extra.Format("2006-01-02T15:04"))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// collectImportExceptions turns EXDATE values and overridden instances into recurrence exceptions
+func (s *eventICalService) collectImportExceptions(vevent *utils.ICalComponent, overrides []*utils.ICalComponent, createReq *dto.CreateEventRequest, loc *time.Location) ([]entity.RecurrenceException, error) {
+	var exceptions []entity.RecurrenceException
+	seen := make(map[string]bool)
+
+	for _, exdate := range vevent.PropertiesNamed("EXDATE") {
+		for _, value := range strings.Split(exdate.Value, ",") {
+			excluded, _, err := utils.ICalParseDateTime(strings.TrimSpace(value), exdate.Params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("EXDATE: %w", err)
+			}
+			excluded = excluded.In(loc)
+			dateKey := excluded.Format("2006-01-02")
+			if seen[dateKey] {
+				continue
+			}
+			seen[dateKey] = true
+
+			exceptions = append(exceptions, entity.RecurrenceException{
+				ExceptionDate:    time.Date(excluded.Year(), excluded.Month(), excluded.Day(), 0, 0, 0, 0, time.UTC),
+				ModificationType: entity.ModificationTypeSingle,
+				IsSkipped:        true,
+				Notes:            "Imported from EXDATE",
+			})
+		}
+	}
+
+	for _, override := range overrides {
+		recurrenceID := override.Property("RECURRENCE-ID")
+		original, _, err := utils.ICalParseDateTime(recurrenceID.Value, recurrenceID.Params, loc)
+		if err != nil {
+			return nil, fmt.Errorf("RECURRENCE-ID: %w", err)
+		}
+		original = original.In(loc)
+		dateKey := original.Format("2006-01-02")
+		if seen[dateKey] {
+			continue
+		}
+		seen[dateKey] = true
+
+		// Stored times keep the event's wall clock, like CreateEvent does
+		originalStart := time.Date(original.Year(), original.Month(), original.Day(), original.Hour(), original.Minute(), 0, 0, time.UTC)
+		exception := entity.RecurrenceException{
+			ExceptionDate:     time.Date(original.Year(), original.Month(), original.Day(), 0, 0, 0, 0, time.UTC),
+			ModificationType:  entity.ModificationTypeSingle,
+			OriginalStartTime: &originalStart,
+			Notes:             "Imported from RECURRENCE-ID",
+		}
+
+		if strings.EqualFold(override.PropertyValue("STATUS"), "CANCELLED") {
+			exception.IsSkipped = true
+			exceptions = append(exceptions, exception)
+			continue
+		}
+
+		overrideReq, _, _, err := s.veventToCreateRequest(override, loc, &dto.ImportEventsRequest{Type: createReq.Type, IsPublic: createReq.IsPublic})
+		if err != nil {
+			return nil, fmt.Errorf("override for %s: %w", dateKey, err)
+		}
+		overrideDate, _ := time.Parse("2006-01-02", overrideReq.EventDate)
+		overrideStartTime, _ := time.Parse("15:04", overrideReq.StartTime)
+		overrideEndTime, _ := time.Parse("15:04", overrideReq.EndTime)
+		overrideStart := time.Date(overrideDate.Year(), overrideDate.Month(), overrideDate.Day(),
+			overrideStartTime.Hour(), overrideStartTime.Minute(), 0, 0, time.UTC)
+		overrideEnd := time.Date(overrideDate.Year(), overrideDate.Month(), overrideDate.Day(),
+			overrideEndTime.Hour(), overrideEndTime.Minute(), 0, 0, time.UTC)
+		exception.OverrideStart = &overrideStart
+		exception.OverrideEnd = &overrideEnd
+
+		exceptions = append(exceptions, exception)
+	}
+
+	return exceptions, nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"gorm.io/gorm"
+)
+
+// OccurrenceIndexYears is how far ahead of now recurring events are materialized in the occurrence index.
+// Queries only trust the index up to occurrenceIndexQueryYears ahead, so the periodic rebuild has a year
+// of slack to roll the horizon forward.
+const (
+	OccurrenceIndexYears      = 3
+	occurrenceIndexQueryYears = 2
+)
+
+// ReindexOccurrences regenerates the indexed occurrences of one event from its rule and exceptions
+func (s *eventService) ReindexOccurrences(eventID uuid.UUID) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := s.occurrenceRepo.DeleteByEventID(eventID); err != nil {
+				return fmt.Errorf("failed to delete occurrences: %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	occurrences, err := s.materializeOccurrences(event, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := s.occurrenceRepo.ReplaceForEvent(event.ID, occurrences); err != nil {
+		return fmt.Errorf("failed to save occurrences: %w", err)
+	}
+	return nil
+}
+
+// RebuildOccurrenceIndex reindexes every event and drops rows of deleted events.
+// It keeps going when a single event fails and returns how many events were indexed.
+func (s *eventService) RebuildOccurrenceIndex() (int, error) {
+	if err := s.occurrenceRepo.DeleteOrphans(); err != nil {
+		return 0, fmt.Errorf("failed to delete orphaned occurrences: %w", err)
+	}
+
+	eventIDs, err := s.occurrenceRepo.ListEventIDs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	indexed := 0
+	var firstErr error
+	failed := 0
+	for _, eventID := range eventIDs {
+		if err := s.ReindexOccurrences(eventID); err != nil {
+			log.Printf("occurrence index: event %s: %v", eventID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		indexed++
+	}
+
+	if firstErr != nil {
+		return indexed, fmt.Errorf("failed to reindex %d event(s), first error: %w", failed, firstErr)
+	}
+	return indexed, nil
+}
+
+func (s *eventService) ListOccurrences(req *dto.ListEventOccurrencesRequest) (*dto.EventOccurrenceListResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+	if !s.occurrenceIndexCovers(endDate) {
+		return nil, fmt.Errorf("end date is beyond %s, the end of the occurrence index",
+			time.Now().AddDate(occurrenceIndexQueryYears, 0, 0).Format("2006-01-02"))
+	}
+
+	viewLoc, err := s.occurrenceViewLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := occurrenceRangeFilters(startDate, endDate, viewLoc)
+	if err != nil {
+		return nil, err
+	}
+	filters.Type = req.Type
+	filters.IsPublic = req.IsPublic
+	filters.Search = req.Search
+	filters.ChurchID = req.ChurchID
+	filters.Viewer = req.Viewer
+
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	filters.Limit = req.Limit
+	filters.Offset = (req.Page - 1) * req.Limit
+
+	occurrences, total, err := s.occurrenceRepo.List(*filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list occurrences: %w", err)
+	}
+
+	return &dto.EventOccurrenceListResponse{
+		Occurrences: s.occurrencesToResponses(occurrences, viewLoc),
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		TotalCount:  int(total),
+		Page:        req.Page,
+		Limit:       req.Limit,
+	}, nil
+}
+
+// GetWeekOccurrences lists what is on in the Monday-to-Sunday week containing req.Date
+func (s *eventService) GetWeekOccurrences(req *dto.WeekEventOccurrencesRequest) (*dto.EventOccurrenceListResponse, error) {
+	viewLoc, err := s.occurrenceViewLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if viewLoc == nil {
+		viewLoc = time.UTC
+	}
+
+	day := time.Now().In(viewLoc)
+	if req.Date != "" {
+		if day, err = time.Parse("2006-01-02", req.Date); err != nil {
+			return nil, fmt.Errorf("invalid date format: %w", err)
+		}
+	}
+
+	monday := time.Date(day.Year(), day.Month(), day.Day()-(int(day.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	sunday := monday.AddDate(0, 0, 6)
+
+	return s.ListOccurrences(&dto.ListEventOccurrencesRequest{
+		StartDate: monday.Format("2006-01-02"),
+		EndDate:   sunday.Format("2006-01-02"),
+		Timezone:  req.Timezone,
+		Type:      req.Type,
+		IsPublic:  req.IsPublic,
+		ChurchID:  req.ChurchID,
+		Page:      req.Page,
+		Limit:     req.Limit,
+		Viewer:    req.Viewer,
+	})
+}
+
+// reindex keeps the occurrence index in step with a change that is already saved. A failure is only
+// logged: the change itself succeeded and the next rebuild repairs the index.
+func (s *eventService) reindex(eventID uuid.UUID) {
+	if err := s.ReindexOccurrences(eventID); err != nil {
+		log.Printf("occurrence index: event %s: %v", eventID, err)
+	}
+	s.eventChanged(eventID)
+}
+
+// OnEventChange registers a listener called after an event, its rule or its exceptions are saved or
+// the event is deleted. Listeners are registered while wiring the service, before it is used.
+func (s *eventService) OnEventChange(listener func(eventID uuid.UUID)) {
+	s.changeListeners = append(s.changeListeners, listener)
+}
+
+func (s *eventService) eventChanged(eventID uuid.UUID) {
+	for _, listener := range s.changeListeners {
+		listener(eventID)
+	}
+}
+
+// occurrenceIndexCovers reports whether the index holds every occurrence up to endDate
+func (s *eventService) occurrenceIndexCovers(endDate time.Time) bool {
+	return !endDate.After(time.Now().AddDate(occurrenceIndexQueryYears, 0, 0))
+}
+
+// materializeOccurrences expands an event from its first occurrence up to the index horizon
+func (s *eventService) materializeOccurrences(event *entity.Event, now time.Time) ([]entity.EventOccurrence, error) {
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(event.EventDate.Year(), event.EventDate.Month(), event.EventDate.Day(), 0, 0, 0, 0, loc)
+	rangeEnd := now.AddDate(OccurrenceIndexYears, 0, 0)
+	if event.RecurrenceRule == nil {
+		// A single event is indexed however far ahead it is
+		rangeEnd = time.Date(event.EventDate.Year(), event.EventDate.Month(), event.EventDate.Day()+1, 0, 0, 0, 0, loc)
+	}
+
+	expanded, err := s.expandOccurrences(event, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]entity.EventOccurrence, len(expanded))
+	for i, occurrence := range expanded {
+		occurrences[i] = entity.EventOccurrence{
+			EventID:        event.ID,
+			OccurrenceDate: occurrence.Date,
+			StartsAt:       occurrence.Start.UTC(),
+			EndsAt:         occurrence.End.UTC(),
+			IsException:    occurrence.Exception != nil,
+		}
+		if occurrence.Exception != nil {
+			occurrences[i].ExceptionNotes = occurrence.Exception.Notes
+		}
+	}
+	return occurrences, nil
+}
+
+// occurrenceRangeFilters selects the occurrences starting on the given dates. Without a view timezone
+// the dates are read in each event's own zone, which is what the stored occurrence date holds.
+func occurrenceRangeFilters(startDate, endDate time.Time, viewLoc *time.Location) (*repository.EventOccurrenceFilters, error) {
+	if viewLoc == nil {
+		return &repository.EventOccurrenceFilters{DateFrom: &startDate, DateTo: &endDate}, nil
+	}
+
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, viewLoc).UTC()
+	rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, viewLoc).Add(-time.Nanosecond).UTC()
+	return &repository.EventOccurrenceFilters{StartsFrom: &rangeStart, StartsTo: &rangeEnd}, nil
+}
+
+func (s *eventService) occurrencesToResponses(occurrences []entity.EventOccurrence, viewLoc *time.Location) []dto.EventOccurrenceResponse {
+	// Events repeat across occurrences, so each is converted once
+	events := make(map[uuid.UUID]*dto.EventResponse)
+
+	responses := make([]dto.EventOccurrenceResponse, len(occurrences))
+	for i, occurrence := range occurrences {
+		event, ok := events[occurrence.EventID]
+		if !ok {
+			event = s.entityToResponse(&occurrence.Event)
+			events[occurrence.EventID] = event
+		}
+
+		loc := viewLoc
+		if loc == nil {
+			loc = recurrenceLocation(occurrence.Event.Timezone)
+		}
+
+		responses[i] = dto.EventOccurrenceResponse{
+			EventID:        occurrence.EventID,
+			OccurrenceDate: occurrence.OccurrenceDate,
+			StartDatetime:  occurrence.StartsAt.In(loc),
+			EndDatetime:    occurrence.EndsAt.In(loc),
+			IsException:    occurrence.IsException,
+			ExceptionNotes: occurrence.ExceptionNotes,
+			OriginalEvent:  event,
+		}
+	}
+	return responses
+}
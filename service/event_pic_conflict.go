@@ -0,0 +1,198 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+// maxPICConflictReportDays bounds the range of the person conflict report, since every assignment
+// in the range is expanded into its occurrences
+const maxPICConflictReportDays = 366
+
+// PICScheduleConflictError is returned when a person would be PIC of two overlapping occurrences
+type PICScheduleConflictError struct {
+	Conflicts []dto.PICScheduleConflictResponse
+}
+
+func (e *PICScheduleConflictError) Error() string {
+	return fmt.Sprintf("person is already PIC at that time: %d conflicting occurrence(s)", len(e.Conflicts))
+}
+
+// checkScheduleConflicts rejects assigning the person to the event from startDate to endDate with a
+// PICScheduleConflictError when one of the event's upcoming occurrences overlaps an occurrence of
+// another event the person is already PIC of. allowConflict skips the check.
+func (s *eventPICService) checkScheduleConflicts(event *entity.Event, personID uuid.UUID, startDate time.Time, endDate *time.Time, allowConflict bool) error {
+	if allowConflict {
+		return nil
+	}
+
+	conflicts, err := s.findScheduleConflicts(event, personID, startDate, endDate, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &PICScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// findScheduleConflicts expands the event over the assignment period, from today up to the occurrence
+// index horizon, and returns the occurrences of the person's other assignments that overlap it
+func (s *eventPICService) findScheduleConflicts(event *entity.Event, personID uuid.UUID, startDate time.Time, endDate *time.Time, now time.Time) ([]dto.PICScheduleConflictResponse, error) {
+	loc := recurrenceLocation(event.Timezone)
+	today := now.In(loc)
+	windowStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+	windowEnd := now.AddDate(OccurrenceIndexYears, 0, 0)
+
+	occurrences, err := s.assignmentOccurrences(event, startDate, endDate, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	first := occurrences[0].Start
+	last := occurrences[0].End
+	for _, occurrence := range occurrences {
+		if occurrence.End.After(last) {
+			last = occurrence.End
+		}
+	}
+
+	pics, err := s.eventPICRepo.GetActivePICsByPersonIDInRange(personID, first.AddDate(0, 0, -1), last.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PIC assignments for person: %w", err)
+	}
+
+	var conflicts []dto.PICScheduleConflictResponse
+	for i := range pics {
+		pic := &pics[i]
+		if pic.EventID == event.ID {
+			continue
+		}
+
+		// Start early enough to catch an occurrence that began before the window and is still running
+		duration := pic.Event.EndDatetime.Sub(pic.Event.StartDatetime)
+		theirOccurrences, err := s.assignmentOccurrences(&pic.Event, pic.StartDate, pic.EndDate, first.Add(-duration), last)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, theirs := range theirOccurrences {
+			for _, ours := range occurrences {
+				if ours.Start.Before(theirs.End) && theirs.Start.Before(ours.End) {
+					conflicts = append(conflicts, dto.PICScheduleConflictResponse{
+						EventID:        pic.EventID,
+						EventTitle:     pic.Event.Title,
+						Role:           pic.Role,
+						OccurrenceDate: theirs.Date.Format("2006-01-02"),
+						StartDatetime:  theirs.Start,
+						EndDatetime:    theirs.End,
+						ConflictsWith:  ours.Date.Format("2006-01-02"),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].StartDatetime.Before(conflicts[j].StartDatetime) })
+	return conflicts, nil
+}
+
+// GetPersonPICConflicts lists every pair of overlapping occurrences among the events the person is
+// PIC of between the two dates, which are read in each event's own timezone
+func (s *eventPICService) GetPersonPICConflicts(personID uuid.UUID, req *dto.PersonPICConflictsRequest) (*dto.PersonPICConflictsResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+	if endDate.Sub(startDate) > maxPICConflictReportDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not be longer than %d days", maxPICConflictReportDays)
+	}
+
+	pics, err := s.eventPICRepo.GetActivePICsByPersonIDInRange(personID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PIC assignments for person: %w", err)
+	}
+
+	var occurrences []dto.PICOccurrenceSummary
+	for i := range pics {
+		pic := &pics[i]
+		loc := recurrenceLocation(pic.Event.Timezone)
+		rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+		rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+
+		expanded, err := s.assignmentOccurrences(&pic.Event, pic.StartDate, pic.EndDate, rangeStart, rangeEnd)
+		if err != nil {
+			return nil, err
+		}
+		for _, occurrence := range expanded {
+			occurrences = append(occurrences, dto.PICOccurrenceSummary{
+				EventPICID:     pic.ID,
+				EventID:        pic.EventID,
+				EventTitle:     pic.Event.Title,
+				Role:           pic.Role,
+				OccurrenceDate: occurrence.Date.Format("2006-01-02"),
+				StartDatetime:  occurrence.Start,
+				EndDatetime:    occurrence.End,
+			})
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].StartDatetime.Before(occurrences[j].StartDatetime) })
+
+	// Sorted by start, an occurrence can only overlap the ones after it that start before it ends
+	conflicts := []dto.PICOccurrenceConflict{}
+	for i := range occurrences {
+		for j := i + 1; j < len(occurrences) && occurrences[j].StartDatetime.Before(occurrences[i].EndDatetime); j++ {
+			if occurrences[j].EventID == occurrences[i].EventID {
+				continue
+			}
+			conflicts = append(conflicts, dto.PICOccurrenceConflict{First: occurrences[i], Second: occurrences[j]})
+		}
+	}
+
+	return &dto.PersonPICConflictsResponse{
+		PersonID:  personID,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// assignmentOccurrences expands the occurrences of an event that start between from and to and fall
+// within the assignment period, whose start and end dates are inclusive days in the event's timezone
+func (s *eventPICService) assignmentOccurrences(event *entity.Event, startDate time.Time, endDate *time.Time, from, to time.Time) ([]expandedOccurrence, error) {
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	if from.After(rangeStart) {
+		rangeStart = from
+	}
+	rangeEnd := to
+	if endDate != nil {
+		periodEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+		if periodEnd.Before(rangeEnd) {
+			rangeEnd = periodEnd
+		}
+	}
+	if rangeEnd.Before(rangeStart) {
+		return nil, nil
+	}
+
+	return expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeEnd)
+}
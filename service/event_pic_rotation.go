@@ -0,0 +1,421 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+const defaultRotationFillAhead = 4
+
+// Occurrence-level assignments
+
+// AssignOccurrencePIC puts a person in a role for one occurrence. A manual assignment replaces whoever
+// held the role on that occurrence and is never overwritten by a rotation.
+func (s *eventPICService) AssignOccurrencePIC(eventID uuid.UUID, req *dto.AssignOccurrencePICRequest, assignedBy *uuid.UUID) (*dto.EventOccurrencePICResponse, error) {
+	_, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	pic := &entity.EventOccurrencePIC{
+		EventID:        eventID,
+		OccurrenceDate: occurrenceDate,
+		Role:           req.Role,
+		PersonID:       req.PersonID,
+		Notes:          req.Notes,
+	}
+	if err := s.eventPICRepo.SaveOccurrencePIC(pic); err != nil {
+		return nil, fmt.Errorf("failed to assign occurrence PIC: %w", err)
+	}
+
+	// History needs to know who made the change
+	if assignedBy != nil {
+		history := &entity.EventPICHistory{
+			EventID:    eventID,
+			PersonID:   req.PersonID,
+			Action:     entity.EventPICActionAssigned,
+			NewRole:    req.Role,
+			ChangedBy:  *assignedBy,
+			Reason:     fmt.Sprintf("PIC assigned for the occurrence on %s", req.OccurrenceDate),
+			ActionDate: time.Now(),
+		}
+		s.eventPICRepo.CreateHistory(history)
+	}
+
+	saved, err := s.eventPICRepo.GetOccurrencePICByID(pic.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence PIC: %w", err)
+	}
+	return occurrencePICToResponse(saved), nil
+}
+
+func (s *eventPICService) GetOccurrencePICs(eventID uuid.UUID, req *dto.OccurrencePICFilterRequest) ([]dto.EventOccurrencePICResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	pics, err := s.eventPICRepo.GetOccurrencePICs(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence PICs: %w", err)
+	}
+
+	responses := make([]dto.EventOccurrencePICResponse, len(pics))
+	for i := range pics {
+		responses[i] = *occurrencePICToResponse(&pics[i])
+	}
+	return responses, nil
+}
+
+func (s *eventPICService) DeleteOccurrencePIC(id uuid.UUID, deletedBy *uuid.UUID) error {
+	pic, err := s.eventPICRepo.GetOccurrencePICByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get occurrence PIC: %w", err)
+	}
+
+	if deletedBy != nil {
+		history := &entity.EventPICHistory{
+			EventID:    pic.EventID,
+			PersonID:   pic.PersonID,
+			Action:     entity.EventPICActionRemoved,
+			OldRole:    pic.Role,
+			ChangedBy:  *deletedBy,
+			Reason:     fmt.Sprintf("PIC removed from the occurrence on %s", pic.OccurrenceDate.Format("2006-01-02")),
+			ActionDate: time.Now(),
+		}
+		s.eventPICRepo.CreateHistory(history)
+	}
+
+	if err := s.eventPICRepo.DeleteOccurrencePIC(id); err != nil {
+		return fmt.Errorf("failed to delete occurrence PIC: %w", err)
+	}
+	return nil
+}
+
+// Rotations
+
+// CreatePICRotation sets up a rotation for a role of a recurring event and fills its first occurrences
+func (s *eventPICService) CreatePICRotation(eventID uuid.UUID, req *dto.CreateEventPICRotationRequest) (*dto.EventPICRotationResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.RecurrenceRule == nil {
+		return nil, fmt.Errorf("event is not recurring")
+	}
+
+	if _, err := s.eventPICRepo.GetRotationByEventAndRole(eventID, req.Role); err == nil {
+		return nil, fmt.Errorf("rotation for role %s already exists", req.Role)
+	}
+
+	rotation := &entity.EventPICRotation{
+		EventID:   eventID,
+		Role:      req.Role,
+		Strategy:  req.Strategy,
+		FillAhead: defaultRotationFillAhead,
+		IsActive:  true,
+		Members:   rotationMembers(req.PersonIDs),
+	}
+	if rotation.Strategy == "" {
+		rotation.Strategy = entity.EventPICRotationRoundRobin
+	}
+	if req.FillAhead != nil {
+		rotation.FillAhead = *req.FillAhead
+	}
+
+	if err := s.eventPICRepo.CreateRotation(rotation); err != nil {
+		return nil, fmt.Errorf("failed to create rotation: %w", err)
+	}
+
+	s.topUpRotation(rotation.ID, event)
+	return s.GetPICRotation(rotation.ID)
+}
+
+func (s *eventPICService) GetPICRotation(id uuid.UUID) (*dto.EventPICRotationResponse, error) {
+	rotation, err := s.eventPICRepo.GetRotationByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+	return rotationToResponse(rotation), nil
+}
+
+func (s *eventPICService) GetPICRotationsByEventID(eventID uuid.UUID) ([]dto.EventPICRotationResponse, error) {
+	rotations, err := s.eventPICRepo.GetRotationsByEventID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotations: %w", err)
+	}
+
+	responses := make([]dto.EventPICRotationResponse, len(rotations))
+	for i := range rotations {
+		responses[i] = *rotationToResponse(&rotations[i])
+	}
+	return responses, nil
+}
+
+// UpdatePICRotation changes the pool or settings of a rotation. A new pool starts again from its first
+// person; occurrences already filled keep their assignments until the rotation is filled with overwrite.
+func (s *eventPICService) UpdatePICRotation(id uuid.UUID, req *dto.UpdateEventPICRotationRequest) (*dto.EventPICRotationResponse, error) {
+	rotation, err := s.eventPICRepo.GetRotationByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if req.PersonIDs != nil {
+		if err := s.eventPICRepo.ReplaceRotationMembers(rotation.ID, rotationMembers(*req.PersonIDs)); err != nil {
+			return nil, fmt.Errorf("failed to update rotation members: %w", err)
+		}
+		rotation.NextPosition = 0
+	}
+	if req.FillAhead != nil {
+		rotation.FillAhead = *req.FillAhead
+	}
+	if req.IsActive != nil {
+		rotation.IsActive = *req.IsActive
+	}
+
+	if err := s.eventPICRepo.UpdateRotation(rotation); err != nil {
+		return nil, fmt.Errorf("failed to update rotation: %w", err)
+	}
+
+	if rotation.IsActive {
+		event, err := s.eventRepo.GetByID(rotation.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		s.topUpRotation(rotation.ID, event)
+	}
+	return s.GetPICRotation(rotation.ID)
+}
+
+// DeletePICRotation stops a rotation. Occurrences it already filled keep their PICs.
+func (s *eventPICService) DeletePICRotation(id uuid.UUID) error {
+	if _, err := s.eventPICRepo.GetRotationByID(id); err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+	if err := s.eventPICRepo.DeleteRotation(id); err != nil {
+		return fmt.Errorf("failed to delete rotation: %w", err)
+	}
+	return nil
+}
+
+// FillPICRotation assigns the rotation's people to the next upcoming occurrences that have nobody in
+// the role. With Overwrite, occurrences the rotation filled before are reassigned as well.
+func (s *eventPICService) FillPICRotation(id uuid.UUID, req *dto.FillEventPICRotationRequest) (*dto.FillEventPICRotationResponse, error) {
+	rotation, err := s.eventPICRepo.GetRotationByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+	if !rotation.IsActive {
+		return nil, fmt.Errorf("rotation is not active")
+	}
+
+	event, err := s.eventRepo.GetByID(rotation.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	count := req.Occurrences
+	if count <= 0 {
+		count = rotation.FillAhead
+	}
+
+	assigned, err := s.fillRotation(rotation, event, count, req.Overwrite, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.EventOccurrencePICResponse, len(assigned))
+	for i := range assigned {
+		responses[i] = *occurrencePICToResponse(&assigned[i])
+	}
+	return &dto.FillEventPICRotationResponse{RotationID: rotation.ID, Assigned: responses}, nil
+}
+
+// FillActiveRotations tops up every active rotation to its fill-ahead count, for the periodic job.
+// It keeps going when a single rotation fails and returns how many occurrences were assigned.
+func (s *eventPICService) FillActiveRotations() (int, error) {
+	rotations, err := s.eventPICRepo.GetActiveRotations()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rotations: %w", err)
+	}
+
+	assigned := 0
+	var firstErr error
+	failed := 0
+	for i := range rotations {
+		rotation := &rotations[i]
+		event, err := s.eventRepo.GetByID(rotation.EventID)
+		if err == nil {
+			var filled []entity.EventOccurrencePIC
+			filled, err = s.fillRotation(rotation, event, rotation.FillAhead, false, time.Now())
+			assigned += len(filled)
+		}
+		if err != nil {
+			log.Printf("PIC rotation: rotation %s: %v", rotation.ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+
+	if firstErr != nil {
+		return assigned, fmt.Errorf("failed to fill %d rotation(s), first error: %w", failed, firstErr)
+	}
+	return assigned, nil
+}
+
+// topUpRotation fills a rotation that was just saved. A failure is only logged: the rotation itself
+// was saved and the periodic fill catches up.
+func (s *eventPICService) topUpRotation(rotationID uuid.UUID, event *entity.Event) {
+	rotation, err := s.eventPICRepo.GetRotationByID(rotationID)
+	if err == nil {
+		_, err = s.fillRotation(rotation, event, rotation.FillAhead, false, time.Now())
+	}
+	if err != nil {
+		log.Printf("PIC rotation: rotation %s: %v", rotationID, err)
+	}
+}
+
+// fillRotation walks the next count occurrences of the event from today and gives each one without
+// anybody in the role to the member whose turn it is. Manual overrides are never touched and do not
+// use up a turn.
+func (s *eventPICService) fillRotation(rotation *entity.EventPICRotation, event *entity.Event, count int, overwrite bool, now time.Time) ([]entity.EventOccurrencePIC, error) {
+	if count <= 0 || len(rotation.Members) == 0 {
+		return nil, nil
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	today := now.In(loc)
+	windowStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, windowStart, now.AddDate(OccurrenceIndexYears, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) > count {
+		occurrences = occurrences[:count]
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.eventPICRepo.GetOccurrencePICs(event.ID, occurrences[0].Date, occurrences[len(occurrences)-1].Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence PICs: %w", err)
+	}
+	current := make(map[string]*entity.EventOccurrencePIC)
+	for i := range existing {
+		if existing[i].Role == rotation.Role {
+			current[existing[i].OccurrenceDate.Format("2006-01-02")] = &existing[i]
+		}
+	}
+
+	position := rotation.NextPosition % len(rotation.Members)
+	var assigned []entity.EventOccurrencePIC
+	for _, occurrence := range occurrences {
+		if pic, ok := current[occurrence.Date.Format("2006-01-02")]; ok && (pic.RotationID == nil || !overwrite) {
+			continue
+		}
+
+		member := rotation.Members[position]
+		pic := entity.EventOccurrencePIC{
+			EventID:        event.ID,
+			OccurrenceDate: occurrence.Date,
+			Role:           rotation.Role,
+			PersonID:       member.PersonID,
+			RotationID:     &rotation.ID,
+		}
+		if err := s.eventPICRepo.SaveOccurrencePIC(&pic); err != nil {
+			return assigned, fmt.Errorf("failed to assign occurrence PIC: %w", err)
+		}
+		pic.Person = member.Person
+		assigned = append(assigned, pic)
+		position = (position + 1) % len(rotation.Members)
+	}
+
+	if position != rotation.NextPosition {
+		rotation.NextPosition = position
+		if err := s.eventPICRepo.UpdateRotation(rotation); err != nil {
+			return assigned, fmt.Errorf("failed to update rotation: %w", err)
+		}
+	}
+	return assigned, nil
+}
+
+func rotationMembers(personIDs []uuid.UUID) []entity.EventPICRotationMember {
+	members := make([]entity.EventPICRotationMember, len(personIDs))
+	for i, personID := range personIDs {
+		members[i] = entity.EventPICRotationMember{PersonID: personID, Position: i}
+	}
+	return members
+}
+
+func occurrencePICToResponse(pic *entity.EventOccurrencePIC) *dto.EventOccurrencePICResponse {
+	return &dto.EventOccurrencePICResponse{
+		ID:             pic.ID,
+		EventID:        pic.EventID,
+		OccurrenceDate: pic.OccurrenceDate.Format("2006-01-02"),
+		PersonID:       pic.PersonID,
+		Person: dto.PersonSummary{
+			ID:           pic.Person.ID,
+			Nama:         pic.Person.Nama,
+			Email:        pic.Person.Email,
+			NomorTelepon: pic.Person.NomorTelepon,
+			ChurchID:     pic.Person.ChurchID,
+		},
+		Role:       pic.Role,
+		RotationID: pic.RotationID,
+		IsOverride: pic.RotationID == nil,
+		Notes:      pic.Notes,
+		CreatedAt:  pic.CreatedAt,
+		UpdatedAt:  pic.UpdatedAt,
+	}
+}
+
+func rotationToResponse(rotation *entity.EventPICRotation) *dto.EventPICRotationResponse {
+	members := make([]dto.EventPICRotationMemberResponse, len(rotation.Members))
+	for i, member := range rotation.Members {
+		members[i] = dto.EventPICRotationMemberResponse{
+			PersonID: member.PersonID,
+			Person: dto.PersonSummary{
+				ID:           member.Person.ID,
+				Nama:         member.Person.Nama,
+				Email:        member.Person.Email,
+				NomorTelepon: member.Person.NomorTelepon,
+				ChurchID:     member.Person.ChurchID,
+			},
+			Position: member.Position,
+		}
+	}
+
+	return &dto.EventPICRotationResponse{
+		ID:           rotation.ID,
+		EventID:      rotation.EventID,
+		Role:         rotation.Role,
+		Strategy:     rotation.Strategy,
+		FillAhead:    rotation.FillAhead,
+		NextPosition: rotation.NextPosition,
+		IsActive:     rotation.IsActive,
+		Members:      members,
+		CreatedAt:    rotation.CreatedAt,
+		UpdatedAt:    rotation.UpdatedAt,
+	}
+}
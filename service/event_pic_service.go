@@ -34,6 +34,19 @@ type EventPICService interface {
 	// Validation operations
 	ValidateEventPICPermissions(eventID, personID uuid.UUID, action string) (bool, error)
 	CheckPICConflicts(eventID, personID uuid.UUID, role string, isPrimary bool) error
+	GetPersonPICConflicts(personID uuid.UUID, req *dto.PersonPICConflictsRequest) (*dto.PersonPICConflictsResponse, error)
+	
+	// Occurrence-level assignments and rotations
+	AssignOccurrencePIC(eventID uuid.UUID, req *dto.AssignOccurrencePICRequest, assignedBy *uuid.UUID) (*dto.EventOccurrencePICResponse, error)
+	GetOccurrencePICs(eventID uuid.UUID, req *dto.OccurrencePICFilterRequest) ([]dto.EventOccurrencePICResponse, error)
+	DeleteOccurrencePIC(id uuid.UUID, deletedBy *uuid.UUID) error
+	CreatePICRotation(eventID uuid.UUID, req *dto.CreateEventPICRotationRequest) (*dto.EventPICRotationResponse, error)
+	GetPICRotation(id uuid.UUID) (*dto.EventPICRotationResponse, error)
+	GetPICRotationsByEventID(eventID uuid.UUID) ([]dto.EventPICRotationResponse, error)
+	UpdatePICRotation(id uuid.UUID, req *dto.UpdateEventPICRotationRequest) (*dto.EventPICRotationResponse, error)
+	DeletePICRotation(id uuid.UUID) error
+	FillPICRotation(id uuid.UUID, req *dto.FillEventPICRotationRequest) (*dto.FillEventPICRotationResponse, error)
+	FillActiveRotations() (int, error)
 	
 	// Role management
 	CreateEventPICRole(req *dto.CreateEventPICRoleRequest) (*dto.EventPICRoleResponse, error)
@@ -47,26 +60,28 @@ type EventPICService interface {
 	GetPersonPICHistory(personID uuid.UUID) ([]dto.EventPICHistoryResponse, error)
 	
 	// Utility methods
-	GetExpiringPICs(days int) ([]dto.EventPICResponse, error)
+	GetExpiringPICs(days int, viewer *dto.EventViewer) ([]dto.EventPICResponse, error)
 	NotifyPICsForEvent(eventID uuid.UUID, message string) error
 }
 
 type eventPICService struct {
-	eventPICRepo repository.EventPICRepository
-	eventRepo    repository.EventRepository
+	eventPICRepo        repository.EventPICRepository
+	eventRepo           repository.EventRepository
+	recurrenceGenerator *RecurrenceGenerator
 }
 
 func NewEventPICService(eventPICRepo repository.EventPICRepository, eventRepo repository.EventRepository) EventPICService {
 	return &eventPICService{
-		eventPICRepo: eventPICRepo,
-		eventRepo:    eventRepo,
+		eventPICRepo:        eventPICRepo,
+		eventRepo:           eventRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
 	}
 }
 
 // Basic PIC operations
 func (s *eventPICService) CreateEventPIC(eventID uuid.UUID, req *dto.CreateEventPICRequest, createdBy uuid.UUID) (*dto.EventPICResponse, error) {
 	// Validate event exists
-	_, err := s.eventRepo.GetByID(eventID)
+	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
@@ -91,6 +106,11 @@ func (s *eventPICService) CreateEventPIC(eventID uuid.UUID, req *dto.CreateEvent
 		endDate = &parsed
 	}
 	
+	// Check the person is not PIC of something else at the same time
+	if err := s.checkScheduleConflicts(event, req.PersonID, startDate, endDate, req.AllowScheduleConflict); err != nil {
+		return nil, err
+	}
+	
 	// If this is a primary PIC, ensure no other primary exists
 	if req.IsPrimary {
 		primaryCount, err := s.eventPICRepo.CountPrimaryPICsForEvent(eventID)
@@ -331,6 +351,8 @@ func (s *eventPICService) ListEventPICs(req *dto.EventPICFilterRequest) (*dto.Ev
 		IsActive:  req.IsActive,
 		IsPrimary: req.IsPrimary,
 		Search:    req.Search,
+		ChurchID:  req.ChurchID,
+		Viewer:    req.Viewer,
 		Limit:     req.Limit,
 		Offset:    (req.Page - 1) * req.Limit,
 	}
@@ -363,7 +385,7 @@ func (s *eventPICService) ListEventPICs(req *dto.EventPICFilterRequest) (*dto.Ev
 
 func (s *eventPICService) AssignMultiplePICs(eventID uuid.UUID, req *dto.BulkAssignEventPICRequest, createdBy uuid.UUID) error {
 	// Validate event exists
-	_, err := s.eventRepo.GetByID(eventID)
+	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return fmt.Errorf("event not found: %w", err)
 	}
@@ -391,6 +413,10 @@ func (s *eventPICService) AssignMultiplePICs(eventID uuid.UUID, req *dto.BulkAss
 			endDate = &parsed
 		}
 		
+		if err := s.checkScheduleConflicts(event, picReq.PersonID, startDate, endDate, picReq.AllowScheduleConflict); err != nil {
+			return fmt.Errorf("PIC conflict for person %s: %w", picReq.PersonID, err)
+		}
+		
 		eventPIC := entity.EventPIC{
 			EventID:           eventID,
 			PersonID:          picReq.PersonID,
@@ -449,6 +475,16 @@ func (s *eventPICService) TransferPICRole(eventID uuid.UUID, req *dto.TransferEv
 		return fmt.Errorf("invalid effective date format: %w", err)
 	}
 	
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+	
+	// The new PIC takes over open-ended from the effective date
+	if err := s.checkScheduleConflicts(event, req.ToPersonID, effectiveDate, nil, req.AllowScheduleConflict); err != nil {
+		return err
+	}
+	
 	if err := s.eventPICRepo.TransferPICRole(req.FromPersonID, req.ToPersonID, eventID, req.TransferType); err != nil {
 		return fmt.Errorf("failed to transfer PIC role: %w", err)
 	}
@@ -647,8 +683,8 @@ func (s *eventPICService) GetPersonPICHistory(personID uuid.UUID) ([]dto.EventPI
 }
 
 // Utility methods
-func (s *eventPICService) GetExpiringPICs(days int) ([]dto.EventPICResponse, error) {
-	pics, err := s.eventPICRepo.GetExpiringPICs(days)
+func (s *eventPICService) GetExpiringPICs(days int, viewer *dto.EventViewer) ([]dto.EventPICResponse, error) {
+	pics, err := s.eventPICRepo.GetExpiringPICs(days, viewer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get expiring PICs: %w", err)
 	}
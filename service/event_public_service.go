@@ -0,0 +1,285 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+const (
+	// publicEventCacheTTL bounds how stale a cached page can get from things the event service does not
+	// report, such as occurrences starting or a venue being renamed
+	publicEventCacheTTL        = 5 * time.Minute
+	publicEventCacheMaxEntries = 500
+	publicEventDefaultDays     = 90
+	publicEventMaxDays         = 366
+	publicEventMaxLimit        = 100
+)
+
+// PublicEventFeed is one page of the public events feed, encoded once so cached copies can be served
+// and revalidated without touching the database
+type PublicEventFeed struct {
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
+type PublicEventService interface {
+	ListPublicEvents(req *dto.PublicEventFilterRequest) (*PublicEventFeed, error)
+	Invalidate()
+}
+
+type publicEventCacheEntry struct {
+	feed    *PublicEventFeed
+	expires time.Time
+}
+
+type publicEventService struct {
+	occurrenceRepo repository.EventOccurrenceRepository
+
+	mu      sync.Mutex
+	entries map[string]*publicEventCacheEntry
+	// generation counts invalidations, so a page built while one happened is not cached
+	generation uint64
+}
+
+// NewPublicEventService creates the public feed and subscribes its cache to changes made through
+// eventService
+func NewPublicEventService(occurrenceRepo repository.EventOccurrenceRepository, eventService EventService) PublicEventService {
+	s := &publicEventService{
+		occurrenceRepo: occurrenceRepo,
+		entries:        make(map[string]*publicEventCacheEntry),
+	}
+	eventService.OnEventChange(func(uuid.UUID) { s.Invalidate() })
+	return s
+}
+
+// Invalidate marks every cached page stale. Entries are kept so a rebuilt page with the same content
+// keeps its Last-Modified time.
+func (s *publicEventService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	for _, entry := range s.entries {
+		entry.expires = time.Time{}
+	}
+}
+
+// ListPublicEvents returns the upcoming occurrences of public events, from the cache when it is fresh
+func (s *publicEventService) ListPublicEvents(req *dto.PublicEventFilterRequest) (*PublicEventFeed, error) {
+	filters, list, err := s.publicFilters(req)
+	if err != nil {
+		return nil, err
+	}
+
+	churchKey := ""
+	if filters.ChurchID != nil {
+		churchKey = filters.ChurchID.String()
+	}
+	key := fmt.Sprintf("%s|%s|%s|%s|%d|%d|%s|%d|%d",
+		list.StartDate, list.EndDate, req.Timezone, req.Type, list.Page, list.Limit,
+		churchKey, req.ProvinsiID, req.KabupatenID)
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	if ok && now.Before(entry.expires) {
+		s.mu.Unlock()
+		return entry.feed, nil
+	}
+	generation := s.generation
+	s.mu.Unlock()
+
+	// Only occurrences that have not started yet are listed
+	if filters.StartsFrom == nil || filters.StartsFrom.Before(now) {
+		from := now.UTC()
+		filters.StartsFrom = &from
+	}
+
+	occurrences, total, err := s.occurrenceRepo.List(*filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public events: %w", err)
+	}
+
+	viewLoc, _ := publicViewLocation(req.Timezone)
+	list.Occurrences = make([]dto.PublicEventOccurrenceResponse, len(occurrences))
+	for i := range occurrences {
+		list.Occurrences[i] = publicOccurrenceResponse(&occurrences[i], viewLoc)
+	}
+	list.TotalCount = int(total)
+
+	body, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public events: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	feed := &PublicEventFeed{
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: now.UTC().Truncate(time.Second),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if previous, ok := s.entries[key]; ok && previous.feed.ETag == feed.ETag {
+		feed.LastModified = previous.feed.LastModified
+	}
+	// An event changed while the page was built, so it may already be out of date; the next request
+	// builds it again
+	if s.generation != generation {
+		return feed, nil
+	}
+	if len(s.entries) >= publicEventCacheMaxEntries {
+		s.entries = make(map[string]*publicEventCacheEntry)
+	}
+	s.entries[key] = &publicEventCacheEntry{feed: feed, expires: now.Add(publicEventCacheTTL)}
+	return feed, nil
+}
+
+// publicFilters validates the request and turns it into index filters and the page being built
+func (s *publicEventService) publicFilters(req *dto.PublicEventFilterRequest) (*repository.EventOccurrenceFilters, *dto.PublicEventListResponse, error) {
+	viewLoc, err := publicViewLocation(req.Timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	today := time.Now()
+	if viewLoc != nil {
+		today = today.In(viewLoc)
+	}
+	startDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if req.StartDate != "" {
+		if startDate, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			return nil, nil, fmt.Errorf("invalid start date format: %w", err)
+		}
+	}
+	endDate := startDate.AddDate(0, 0, publicEventDefaultDays)
+	if req.EndDate != "" {
+		if endDate, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			return nil, nil, fmt.Errorf("invalid end date format: %w", err)
+		}
+	}
+	if endDate.Before(startDate) {
+		return nil, nil, fmt.Errorf("end date must not be before start date")
+	}
+	if endDate.After(startDate.AddDate(0, 0, publicEventMaxDays)) {
+		return nil, nil, fmt.Errorf("date range must not exceed %d days", publicEventMaxDays)
+	}
+	if endDate.After(time.Now().AddDate(occurrenceIndexQueryYears, 0, 0)) {
+		return nil, nil, fmt.Errorf("end date is beyond %s, the end of the occurrence index",
+			time.Now().AddDate(occurrenceIndexQueryYears, 0, 0).Format("2006-01-02"))
+	}
+
+	filters, err := occurrenceRangeFilters(startDate, endDate, viewLoc)
+	if err != nil {
+		return nil, nil, err
+	}
+	isPublic := true
+	filters.IsPublic = &isPublic
+	filters.Type = req.Type
+
+	if req.ChurchID != "" {
+		churchID, err := uuid.Parse(req.ChurchID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid church ID format")
+		}
+		filters.ChurchID = &churchID
+	}
+	if req.KabupatenID != 0 {
+		filters.KabupatenID = &req.KabupatenID
+	}
+	if req.ProvinsiID != 0 {
+		filters.ProvinsiID = &req.ProvinsiID
+	}
+
+	page, limit := req.Page, req.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > publicEventMaxLimit {
+		limit = publicEventMaxLimit
+	}
+	filters.Limit = limit
+	filters.Offset = (page - 1) * limit
+
+	return filters, &dto.PublicEventListResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Page:      page,
+		Limit:     limit,
+	}, nil
+}
+
+// publicViewLocation resolves the timezone the feed is shown in; nil means each event's own zone
+func publicViewLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	return loc, nil
+}
+
+func publicOccurrenceResponse(occurrence *entity.EventOccurrence, viewLoc *time.Location) dto.PublicEventOccurrenceResponse {
+	event := &occurrence.Event
+	loc := viewLoc
+	if loc == nil {
+		loc = recurrenceLocation(event.Timezone)
+	}
+
+	response := dto.PublicEventOccurrenceResponse{
+		EventID:        event.ID,
+		OccurrenceDate: occurrence.OccurrenceDate.Format("2006-01-02"),
+		Title:          event.Title,
+		Description:    event.Description,
+		BannerImage:    event.BannerImage,
+		Type:           event.Type,
+		Location:       event.EventLocation,
+		StartDatetime:  occurrence.StartsAt.In(loc),
+		EndDatetime:    occurrence.EndsAt.In(loc),
+		AllDay:         event.AllDay,
+		Timezone:       event.Timezone,
+		IsRecurring:    event.RecurrenceRule != nil,
+	}
+	// The owning church, or for events without one the church of the venue
+	var church *entity.Church
+	if event.Venue != nil {
+		response.Venue = event.Venue.Name
+		if event.Venue.Church.ID != uuid.Nil {
+			church = &event.Venue.Church
+		}
+	}
+	if event.Church != nil {
+		church = event.Church
+	}
+	if church != nil {
+		response.Church = publicChurchResponse(church)
+	}
+	for i := range event.Churches {
+		response.SharedWith = append(response.SharedWith, *publicChurchResponse(&event.Churches[i]))
+	}
+	return response
+}
+
+func publicChurchResponse(church *entity.Church) *dto.PublicChurchResponse {
+	return &dto.PublicChurchResponse{
+		ID:        church.ID,
+		Name:      church.Name,
+		Address:   church.Address,
+		Kabupaten: church.Kabupaten.Name,
+		Provinsi:  church.Kabupaten.Provinsi.Name,
+	}
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+type EventRegistrationService interface {
+	Register(eventID uuid.UUID, req *dto.CreateEventRegistrationRequest) (*dto.EventRegistrationResponse, error)
+	GetRegistration(id uuid.UUID) (*dto.EventRegistrationResponse, error)
+	UpdateRegistration(id uuid.UUID, req *dto.UpdateEventRegistrationRequest) (*dto.EventRegistrationResponse, error)
+	CancelRegistration(id uuid.UUID) (*dto.CancelEventRegistrationResponse, error)
+
+	ListRegistrations(eventID uuid.UUID, req *dto.EventRegistrationFilterRequest) (*dto.EventRegistrationListResponse, error)
+	GetOccurrenceCapacity(eventID uuid.UUID, occurrenceDate string) (*dto.OccurrenceCapacityResponse, error)
+	FillFromWaitlist(eventID uuid.UUID, occurrenceDate string) ([]dto.EventRegistrationResponse, error)
+	ExportRegistrations(eventID uuid.UUID, req *dto.EventRegistrationFilterRequest) ([]byte, error)
+
+	GetPersonRegistrations(personID uuid.UUID) ([]dto.EventRegistrationResponse, error)
+}
+
+type eventRegistrationService struct {
+	registrationRepo    repository.EventRegistrationRepository
+	eventRepo           repository.EventRepository
+	personRepo          repository.PersonRepository
+	visitorRepo         repository.VisitorRepository
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+func NewEventRegistrationService(
+	registrationRepo repository.EventRegistrationRepository,
+	eventRepo repository.EventRepository,
+	personRepo repository.PersonRepository,
+	visitorRepo repository.VisitorRepository,
+) EventRegistrationService {
+	return &eventRegistrationService{
+		registrationRepo:    registrationRepo,
+		eventRepo:           eventRepo,
+		personRepo:          personRepo,
+		visitorRepo:         visitorRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+func (s *eventRegistrationService) Register(eventID uuid.UUID, req *dto.CreateEventRegistrationRequest) (*dto.EventRegistrationResponse, error) {
+	if (req.PersonID == nil) == (req.VisitorID == nil) {
+		return nil, fmt.Errorf("exactly one of personId or visitorId is required")
+	}
+
+	event, occurrenceDate, occurrenceStart, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+	if !occurrenceStart.After(time.Now()) {
+		return nil, fmt.Errorf("registration is closed: occurrence has already started")
+	}
+
+	if req.PersonID != nil {
+		if _, err := s.personRepo.GetByID(context.Background(), *req.PersonID); err != nil {
+			return nil, fmt.Errorf("person not found: %w", err)
+		}
+	} else {
+		if _, err := s.visitorRepo.GetByID(context.Background(), *req.VisitorID); err != nil {
+			return nil, fmt.Errorf("visitor not found: %w", err)
+		}
+	}
+
+	registration := &entity.EventRegistration{
+		EventID:        event.ID,
+		OccurrenceDate: occurrenceDate,
+		PersonID:       req.PersonID,
+		VisitorID:      req.VisitorID,
+		Notes:          req.Notes,
+	}
+	if err := s.registrationRepo.Register(registration, event.Capacity); err != nil {
+		if errors.Is(err, repository.ErrAlreadyRegistered) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create registration: %w", err)
+	}
+
+	return s.GetRegistration(registration.ID)
+}
+
+func (s *eventRegistrationService) GetRegistration(id uuid.UUID) (*dto.EventRegistrationResponse, error) {
+	registration, err := s.registrationRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+
+	response := s.entityToResponse(registration)
+	position, err := s.registrationRepo.WaitlistPosition(registration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist position: %w", err)
+	}
+	response.WaitlistPosition = position
+
+	return response, nil
+}
+
+// UpdateRegistration lets a PIC edit notes, promote a waitlisted registrant while seats are free, or cancel
+func (s *eventRegistrationService) UpdateRegistration(id uuid.UUID, req *dto.UpdateEventRegistrationRequest) (*dto.EventRegistrationResponse, error) {
+	registration, err := s.registrationRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+
+	if req.Notes != nil {
+		registration.Notes = *req.Notes
+		if err := s.registrationRepo.Update(registration); err != nil {
+			return nil, fmt.Errorf("failed to update registration: %w", err)
+		}
+	}
+
+	if req.Status != nil && *req.Status != registration.Status {
+		switch *req.Status {
+		case entity.EventRegistrationStatusRegistered:
+			if registration.Status != entity.EventRegistrationStatusWaitlisted {
+				return nil, fmt.Errorf("only waitlisted registrations can be promoted")
+			}
+			if _, err := s.registrationRepo.Promote(id, registration.Event.Capacity); err != nil {
+				if errors.Is(err, repository.ErrOccurrenceFull) || errors.Is(err, repository.ErrNotWaitlisted) {
+					return nil, err
+				}
+				return nil, fmt.Errorf("failed to promote registration: %w", err)
+			}
+		case entity.EventRegistrationStatusCancelled:
+			if _, _, err := s.registrationRepo.Cancel(id); err != nil {
+				return nil, fmt.Errorf("failed to cancel registration: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid status: %s", *req.Status)
+		}
+	}
+
+	return s.GetRegistration(id)
+}
+
+func (s *eventRegistrationService) CancelRegistration(id uuid.UUID) (*dto.CancelEventRegistrationResponse, error) {
+	if _, err := s.registrationRepo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+
+	_, promoted, err := s.registrationRepo.Cancel(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel registration: %w", err)
+	}
+
+	cancelled, err := s.GetRegistration(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CancelEventRegistrationResponse{
+		Registration: *cancelled,
+	}
+	if promoted != nil {
+		response.Promoted, err = s.GetRegistration(promoted.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (s *eventRegistrationService) ListRegistrations(eventID uuid.UUID, req *dto.EventRegistrationFilterRequest) (*dto.EventRegistrationListResponse, error) {
+	capacity, err := s.GetOccurrenceCapacity(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	occurrenceDate := capacity.OccurrenceDate
+	filters := repository.EventRegistrationFilters{
+		EventID:        &eventID,
+		OccurrenceDate: &occurrenceDate,
+		Status:         req.Status,
+		Search:         req.Search,
+		Limit:          req.Limit,
+		Offset:         (req.Page - 1) * req.Limit,
+	}
+
+	registrations, total, err := s.registrationRepo.List(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registrations: %w", err)
+	}
+
+	responses, err := s.toResponses(registrations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EventRegistrationListResponse{
+		Capacity:      *capacity,
+		Registrations: responses,
+		TotalCount:    int(total),
+		Page:          req.Page,
+		Limit:         filters.Limit,
+	}, nil
+}
+
+func (s *eventRegistrationService) GetOccurrenceCapacity(eventID uuid.UUID, occurrenceDate string) (*dto.OccurrenceCapacityResponse, error) {
+	event, date, _, err := s.resolveOccurrence(eventID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.registrationRepo.CountByStatus(eventID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count registrations: %w", err)
+	}
+
+	registered := int(counts[entity.EventRegistrationStatusRegistered])
+	available := event.Capacity - registered
+	if available < 0 {
+		available = 0
+	}
+
+	return &dto.OccurrenceCapacityResponse{
+		EventID:        eventID,
+		OccurrenceDate: date,
+		Capacity:       event.Capacity,
+		Registered:     registered,
+		Waitlisted:     int(counts[entity.EventRegistrationStatusWaitlisted]),
+		Available:      available,
+	}, nil
+}
+
+// FillFromWaitlist promotes waitlisted registrants into seats freed by a capacity increase
+func (s *eventRegistrationService) FillFromWaitlist(eventID uuid.UUID, occurrenceDate string) ([]dto.EventRegistrationResponse, error) {
+	event, date, _, err := s.resolveOccurrence(eventID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	promoted, err := s.registrationRepo.FillFromWaitlist(eventID, date, event.Capacity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote waitlisted registrations: %w", err)
+	}
+
+	responses := make([]dto.EventRegistrationResponse, 0, len(promoted))
+	for _, registration := range promoted {
+		response, err := s.GetRegistration(registration.ID)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+// ExportRegistrations renders the registrant list of an occurrence as CSV
+func (s *eventRegistrationService) ExportRegistrations(eventID uuid.UUID, req *dto.EventRegistrationFilterRequest) ([]byte, error) {
+	_, date, _, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	registrations, _, err := s.registrationRepo.List(repository.EventRegistrationFilters{
+		EventID:        &eventID,
+		OccurrenceDate: &date,
+		Status:         req.Status,
+		Search:         req.Search,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registrations: %w", err)
+	}
+
+	responses, err := s.toResponses(registrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"No", "Status", "Waitlist Position", "Type", "Name", "Email", "Phone", "Notes", "Registered At"})
+	for i, registration := range responses {
+		registrantType, name, email, phone := "", "", "", ""
+		if registration.Person != nil {
+			registrantType, name, email, phone = "person", registration.Person.Nama, registration.Person.Email, registration.Person.NomorTelepon
+		} else if registration.Visitor != nil {
+			registrantType, name, phone = "visitor", registration.Visitor.Name, registration.Visitor.PhoneNumber
+		}
+
+		position := ""
+		if registration.WaitlistPosition > 0 {
+			position = strconv.Itoa(registration.WaitlistPosition)
+		}
+
+		w.Write([]string{
+			strconv.Itoa(i + 1),
+			registration.Status,
+			position,
+			registrantType,
+			name,
+			email,
+			phone,
+			registration.Notes,
+			registration.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *eventRegistrationService) GetPersonRegistrations(personID uuid.UUID) ([]dto.EventRegistrationResponse, error) {
+	registrations, err := s.registrationRepo.GetByPersonID(personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person registrations: %w", err)
+	}
+
+	return s.toResponses(registrations)
+}
+
+// Helper methods
+
+// resolveOccurrence loads the event and checks that it actually occurs on the given date
+func (s *eventRegistrationService) resolveOccurrence(eventID uuid.UUID, occurrenceDate string) (*entity.Event, time.Time, time.Time, error) {
+	return resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, occurrenceDate)
+}
+
+// resolveEventOccurrence returns the event, the occurrence date and the occurrence start,
+// or an error if the event does not occur on the date (given in the event's timezone)
+func resolveEventOccurrence(eventRepo repository.EventRepository, rg *RecurrenceGenerator, eventID uuid.UUID, occurrenceDate string) (*entity.Event, time.Time, time.Time, error) {
+	event, err := eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	date, err := time.Parse("2006-01-02", occurrenceDate)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("invalid occurrence date format: %w", err)
+	}
+
+	exceptions, err := eventRepo.GetRecurrenceExceptions(eventID)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("failed to get recurrence exceptions: %w", err)
+	}
+
+	start, err := rg.OccurrenceOn(event, date, exceptions)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("failed to generate occurrences: %w", err)
+	}
+	if start == nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("event does not occur on %s", occurrenceDate)
+	}
+
+	return event, date, *start, nil
+}
+
+// toResponses converts an ordered list and numbers the waitlist without a query per row
+func (s *eventRegistrationService) toResponses(registrations []entity.EventRegistration) ([]dto.EventRegistrationResponse, error) {
+	responses := make([]dto.EventRegistrationResponse, len(registrations))
+	positions := make(map[string]int)
+	for i := range registrations {
+		responses[i] = *s.entityToResponse(&registrations[i])
+		if registrations[i].Status != entity.EventRegistrationStatusWaitlisted {
+			continue
+		}
+
+		key := registrations[i].EventID.String() + registrations[i].OccurrenceDate.Format("2006-01-02")
+		if _, ok := positions[key]; !ok {
+			// The first waitlisted row of a page may not be first on the waitlist
+			position, err := s.registrationRepo.WaitlistPosition(&registrations[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get waitlist position: %w", err)
+			}
+			positions[key] = position - 1
+		}
+		positions[key]++
+		responses[i].WaitlistPosition = positions[key]
+	}
+	return responses, nil
+}
+
+func (s *eventRegistrationService) entityToResponse(registration *entity.EventRegistration) *dto.EventRegistrationResponse {
+	response := &dto.EventRegistrationResponse{
+		ID:             registration.ID,
+		EventID:        registration.EventID,
+		EventTitle:     registration.Event.Title,
+		OccurrenceDate: registration.OccurrenceDate,
+		PersonID:       registration.PersonID,
+		VisitorID:      registration.VisitorID,
+		Status:         registration.Status,
+		Notes:          registration.Notes,
+		PromotedAt:     registration.PromotedAt,
+		CancelledAt:    registration.CancelledAt,
+		CreatedAt:      registration.CreatedAt,
+		UpdatedAt:      registration.UpdatedAt,
+	}
+
+	if registration.Person != nil {
+		response.Person = &dto.PersonSummary{
+			ID:           registration.Person.ID,
+			Nama:         registration.Person.Nama,
+			Email:        registration.Person.Email,
+			NomorTelepon: registration.Person.NomorTelepon,
+			ChurchID:     registration.Person.ChurchID,
+		}
+	}
+	if registration.Visitor != nil {
+		response.Visitor = &dto.VisitorSummary{
+			ID:   registration.Visitor.ID,
+			Name: registration.Visitor.Name,
+		}
+		if registration.Visitor.PhoneNumber != nil {
+			response.Visitor.PhoneNumber = *registration.Visitor.PhoneNumber
+		}
+		if registration.Visitor.IGUsername != nil {
+			response.Visitor.IGUsername = *registration.Visitor.IGUsername
+		}
+	}
+
+	return response
+}
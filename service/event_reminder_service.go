@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// EventReminderService sends reminders of upcoming occurrences to PICs who opted in with NotifyOnReminders
+type EventReminderService interface {
+	SendDueReminders(now time.Time) (int, error)
+}
+
+// DefaultReminderOffsets are used when no offsets are configured
+var DefaultReminderOffsets = []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+type eventReminderService struct {
+	reminderRepo        repository.EventReminderRepository
+	eventRepo           repository.EventRepository
+	userRepo            repository.UserRepository
+	notificationRepo    repository.NotificationRepository
+	recurrenceGenerator *RecurrenceGenerator
+	offsets             []time.Duration
+	sendMail            func(to, subject, body string) error
+}
+
+func NewEventReminderService(
+	reminderRepo repository.EventReminderRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	offsets []time.Duration,
+) EventReminderService {
+	if len(offsets) == 0 {
+		offsets = DefaultReminderOffsets
+	}
+
+	// Largest offset first; SendDueReminders relies on this order
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	return &eventReminderService{
+		reminderRepo:        reminderRepo,
+		eventRepo:           eventRepo,
+		userRepo:            userRepo,
+		notificationRepo:    notificationRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+		offsets:             sorted,
+		sendMail:            utils.SendMail,
+	}
+}
+
+// SendDueReminders sends every reminder that is due at now and returns how many were sent.
+// A reminder is due once now is within its offset before the occurrence start and the occurrence has not started.
+// When several offsets are due at once (the event was created late or the scheduler was down) only the nearest
+// one is sent, so a PIC never receives a burst of stale reminders.
+func (s *eventReminderService) SendDueReminders(now time.Time) (int, error) {
+	windowEnd := now.Add(s.offsets[0])
+
+	events, err := s.upcomingEvents(now, windowEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range events {
+		event := &events[i]
+		if !hasReminderRecipients(event) {
+			continue
+		}
+
+		exceptions, err := s.eventRepo.GetRecurrenceExceptions(event.ID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get recurrence exceptions: %w", err)
+		}
+
+		// Scan the calendar dates of the window in the event's zone; dates are kept at midnight UTC, so the
+		// last one is compared as a date too
+		loc := recurrenceLocation(event.Timezone)
+		firstDay := now.In(loc)
+		lastDay := windowEnd.In(loc)
+		lastDate := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day(), 0, 0, 0, 0, time.UTC)
+		for day := time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day(), 0, 0, 0, 0, time.UTC); !day.After(lastDate); day = day.AddDate(0, 0, 1) {
+			start, err := s.recurrenceGenerator.OccurrenceOn(event, day, exceptions)
+			if err != nil {
+				log.Printf("event reminders: skipping event %s: %v", event.ID, err)
+				break
+			}
+			if start == nil || !start.After(now) {
+				continue
+			}
+
+			offset, due := s.dueOffset(now, *start)
+			if !due {
+				continue
+			}
+
+			for _, pic := range event.EventPICs {
+				if !picWantsReminder(&pic, day) {
+					continue
+				}
+				ok, err := s.sendReminder(event, &pic.Person, day, *start, offset, now)
+				if err != nil {
+					return sent, err
+				}
+				if ok {
+					sent++
+				}
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// dueOffset returns the smallest configured offset whose reminder time has passed
+func (s *eventReminderService) dueOffset(now, start time.Time) (time.Duration, bool) {
+	for i := len(s.offsets) - 1; i >= 0; i-- {
+		if !now.Before(start.Add(-s.offsets[i])) {
+			return s.offsets[i], true
+		}
+	}
+	return 0, false
+}
+
+// sendReminder claims the reminder and delivers it; it returns false if the reminder was already claimed
+func (s *eventReminderService) sendReminder(event *entity.Event, person *entity.Person, occurrenceDate, start time.Time, offset time.Duration, now time.Time) (bool, error) {
+	reminder := &entity.EventReminderLog{
+		EventID:         event.ID,
+		OccurrenceDate:  occurrenceDate,
+		PersonID:        person.ID,
+		OffsetMinutes:   int(offset / time.Minute),
+		OccurrenceStart: start,
+	}
+	claimed, err := s.reminderRepo.Claim(reminder)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reminder: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	title := fmt.Sprintf("Reminder: %s", event.Title)
+	message := fmt.Sprintf("%s starts %s at %s (%s).",
+		event.Title, formatTimeUntil(start.Sub(now)), start.Format("Monday, 2 January 2006 15:04 MST"), event.EventLocation)
+
+	var problems []string
+
+	// In-app notifications need a user account; people without one are reached by email only
+	if user, err := s.userRepo.GetByPersonID(context.Background(), person.ID); err == nil {
+		churchID := person.ChurchID
+		notification := &entity.Notification{
+			ID:       uuid.New(),
+			Title:    title,
+			Message:  message,
+			Type:     "info",
+			UserID:   user.ID,
+			ChurchID: &churchID,
+		}
+		if err := s.notificationRepo.Create(notification); err != nil {
+			problems = append(problems, fmt.Sprintf("notification: %v", err))
+		} else {
+			reminder.NotificationID = &notification.ID
+		}
+	}
+
+	if person.Email != "" {
+		body := fmt.Sprintf("<p>Hi %s,</p><p>%s</p><p>You are receiving this because you are a PIC of this event.</p>",
+			html.EscapeString(person.Nama), html.EscapeString(message))
+		if err := s.sendMail(person.Email, title, body); err != nil {
+			problems = append(problems, fmt.Sprintf("email: %v", err))
+		} else {
+			sentAt := time.Now()
+			reminder.EmailSentAt = &sentAt
+		}
+	}
+
+	reminder.Error = strings.Join(problems, "; ")
+	if err := s.reminderRepo.Update(reminder); err != nil {
+		return true, fmt.Errorf("failed to update reminder log: %w", err)
+	}
+	if reminder.Error != "" {
+		log.Printf("event reminders: reminder %s for %s delivered with errors: %s", reminder.ID, person.Email, reminder.Error)
+	}
+
+	return true, nil
+}
+
+// upcomingEvents loads recurring events plus single events that may occur between from and to
+func (s *eventReminderService) upcomingEvents(from, to time.Time) ([]entity.Event, error) {
+	recurring, err := s.eventRepo.GetEventsWithRecurrenceInRange(from.AddDate(0, 0, -1), to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring events: %w", err)
+	}
+
+	single, err := s.eventRepo.GetByDateRange(from.AddDate(0, 0, -1), to.AddDate(0, 0, 1), repository.EventFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	events := recurring
+	for _, event := range single {
+		if event.RecurrenceRuleID == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func hasReminderRecipients(event *entity.Event) bool {
+	for _, pic := range event.EventPICs {
+		if pic.IsActive && pic.NotifyOnReminders {
+			return true
+		}
+	}
+	return false
+}
+
+// picWantsReminder checks the opt-in and that the assignment covers the occurrence date
+func picWantsReminder(pic *entity.EventPIC, occurrenceDate time.Time) bool {
+	if !pic.IsActive || !pic.NotifyOnReminders {
+		return false
+	}
+	date := occurrenceDate.Format("2006-01-02")
+	if pic.StartDate.Format("2006-01-02") > date {
+		return false
+	}
+	return pic.EndDate == nil || pic.EndDate.Format("2006-01-02") >= date
+}
+
+// formatTimeUntil describes the time left before an occurrence, e.g. "in 2 hours"
+func formatTimeUntil(d time.Duration) string {
+	switch {
+	case d >= 36*time.Hour:
+		return fmt.Sprintf("in %d days", int(d.Round(24*time.Hour)/(24*time.Hour)))
+	case d >= 23*time.Hour:
+		return "in 1 day"
+	case d >= 90*time.Minute:
+		return fmt.Sprintf("in %d hours", int(d.Round(time.Hour)/time.Hour))
+	case d >= 55*time.Minute:
+		return "in 1 hour"
+	default:
+		return fmt.Sprintf("in %d minutes", int(d.Round(time.Minute)/time.Minute))
+	}
+}
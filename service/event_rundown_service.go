@@ -0,0 +1,456 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+// maxRundownRangeDays bounds the occurrences one template can be applied to at once
+const maxRundownRangeDays = 366
+
+type EventRundownService interface {
+	// Occurrence run-sheets
+	GetRundown(eventID uuid.UUID, req *dto.EventRundownFilterRequest) (*dto.EventRundownResponse, error)
+	SetRundown(eventID uuid.UUID, req *dto.SetEventRundownRequest) (*dto.EventRundownResponse, error)
+	ExportRundown(eventID uuid.UUID, req *dto.EventRundownFilterRequest) ([]byte, error)
+
+	// Run-sheet template
+	GetTemplate(eventID uuid.UUID) (*dto.RundownTemplateResponse, error)
+	SetTemplate(eventID uuid.UUID, req *dto.SetRundownTemplateRequest) (*dto.RundownTemplateResponse, error)
+	SaveAsTemplate(eventID uuid.UUID, req *dto.SaveRundownTemplateRequest) (*dto.RundownTemplateResponse, error)
+	ApplyTemplate(eventID uuid.UUID, req *dto.ApplyRundownTemplateRequest) (*dto.ApplyRundownTemplateResponse, error)
+}
+
+type eventRundownService struct {
+	rundownRepo         repository.EventRundownRepository
+	eventRepo           repository.EventRepository
+	personRepo          repository.PersonRepository
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+func NewEventRundownService(rundownRepo repository.EventRundownRepository, eventRepo repository.EventRepository, personRepo repository.PersonRepository) EventRundownService {
+	return &eventRundownService{
+		rundownRepo:         rundownRepo,
+		eventRepo:           eventRepo,
+		personRepo:          personRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+// Occurrence run-sheets
+func (s *eventRundownService) GetRundown(eventID uuid.UUID, req *dto.EventRundownFilterRequest) (*dto.EventRundownResponse, error) {
+	_, occurrence, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.rundownRepo.GetOccurrenceSegments(eventID, occurrence.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet: %w", err)
+	}
+	return rundownToResponse(eventID, occurrence, segments), nil
+}
+
+// SetRundown replaces the run-sheet of one occurrence. Every segment has to fit between the start and
+// end of the occurrence; segments may overlap, e.g. for a band setting up during announcements.
+func (s *eventRundownService) SetRundown(eventID uuid.UUID, req *dto.SetEventRundownRequest) (*dto.EventRundownResponse, error) {
+	_, occurrence, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSegments(req.Segments, occurrenceMinutes(occurrence)); err != nil {
+		return nil, err
+	}
+
+	requests := sortedSegmentRequests(req.Segments)
+	segments := make([]entity.EventRundownSegment, len(requests))
+	for i, segment := range requests {
+		segments[i] = entity.EventRundownSegment{
+			Title:       strings.TrimSpace(segment.Title),
+			Type:        strings.TrimSpace(segment.Type),
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			PersonID:    segment.PersonID,
+			Notes:       segment.Notes,
+		}
+	}
+
+	if err := s.rundownRepo.ReplaceOccurrenceSegments(eventID, occurrence.Date, segments); err != nil {
+		return nil, fmt.Errorf("failed to save run-sheet: %w", err)
+	}
+	return s.GetRundown(eventID, &dto.EventRundownFilterRequest{OccurrenceDate: req.OccurrenceDate})
+}
+
+var rundownSheetTemplate = template.Must(template.New("rundown").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 2em; color: #000; }
+h1 { font-size: 1.4em; margin-bottom: 0.2em; }
+.when { color: #555; margin-bottom: 1.2em; }
+table { width: 100%; border-collapse: collapse; }
+th, td { border: 1px solid #999; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #eee; }
+tr { break-inside: avoid; page-break-inside: avoid; }
+.time { white-space: nowrap; }
+.notes { white-space: pre-line; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="when">{{.When}}</div>
+<table>
+<thead><tr><th>Time</th><th>Minutes</th><th>Segment</th><th>Responsible</th><th>Notes</th></tr></thead>
+<tbody>
+{{range .Segments}}<tr><td class="time">{{.Time}}</td><td>{{.Duration}}</td><td>{{.Title}}{{if .Type}} ({{.Type}}){{end}}</td><td>{{.Person}}</td><td class="notes">{{.Notes}}</td></tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+// ExportRundown renders the run-sheet of an occurrence as a printable HTML page
+func (s *eventRundownService) ExportRundown(eventID uuid.UUID, req *dto.EventRundownFilterRequest) ([]byte, error) {
+	event, occurrence, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.rundownRepo.GetOccurrenceSegments(eventID, occurrence.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet: %w", err)
+	}
+
+	type sheetSegment struct {
+		Time     string
+		Duration int
+		Title    string
+		Type     string
+		Person   string
+		Notes    string
+	}
+	data := struct {
+		Title    string
+		When     string
+		Segments []sheetSegment
+	}{
+		Title: event.Title,
+		When: fmt.Sprintf("%s, %s - %s", occurrence.Start.Format("Monday, 2 January 2006"),
+			occurrence.Start.Format("15:04"), occurrence.End.Format("15:04")),
+		Segments: make([]sheetSegment, len(segments)),
+	}
+	for i, segment := range segments {
+		start := occurrence.Start.Add(time.Duration(segment.StartOffset) * time.Minute)
+		end := start.Add(time.Duration(segment.Duration) * time.Minute)
+		data.Segments[i] = sheetSegment{
+			Time:     start.Format("15:04") + " - " + end.Format("15:04"),
+			Duration: segment.Duration,
+			Title:    segment.Title,
+			Type:     segment.Type,
+			Notes:    segment.Notes,
+		}
+		if segment.Person != nil {
+			data.Segments[i].Person = segment.Person.Nama
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := rundownSheetTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to write run-sheet: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Run-sheet template
+func (s *eventRundownService) GetTemplate(eventID uuid.UUID) (*dto.RundownTemplateResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	segments, err := s.rundownRepo.GetTemplateSegments(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet template: %w", err)
+	}
+
+	response := &dto.RundownTemplateResponse{EventID: eventID, Segments: make([]dto.RundownSegmentResponse, len(segments))}
+	for i := range segments {
+		segment := &segments[i]
+		response.Segments[i] = dto.RundownSegmentResponse{
+			ID:          segment.ID,
+			Position:    segment.Position,
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			Person:      rundownPersonSummary(segment.Person),
+			Notes:       segment.Notes,
+		}
+	}
+	return response, nil
+}
+
+// SetTemplate replaces the run-sheet template. The segments have to fit the event's usual length.
+func (s *eventRundownService) SetTemplate(eventID uuid.UUID, req *dto.SetRundownTemplateRequest) (*dto.RundownTemplateResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := s.validateSegments(req.Segments, int(event.EndDatetime.Sub(event.StartDatetime)/time.Minute)); err != nil {
+		return nil, err
+	}
+
+	requests := sortedSegmentRequests(req.Segments)
+	segments := make([]entity.EventRundownTemplateSegment, len(requests))
+	for i, segment := range requests {
+		segments[i] = entity.EventRundownTemplateSegment{
+			Title:       strings.TrimSpace(segment.Title),
+			Type:        strings.TrimSpace(segment.Type),
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			PersonID:    segment.PersonID,
+			Notes:       segment.Notes,
+		}
+	}
+
+	if err := s.rundownRepo.ReplaceTemplateSegments(eventID, segments); err != nil {
+		return nil, fmt.Errorf("failed to save run-sheet template: %w", err)
+	}
+	return s.GetTemplate(eventID)
+}
+
+// SaveAsTemplate makes the run-sheet of an occurrence the template later occurrences are planned from
+func (s *eventRundownService) SaveAsTemplate(eventID uuid.UUID, req *dto.SaveRundownTemplateRequest) (*dto.RundownTemplateResponse, error) {
+	_, occurrence, err := s.resolveOccurrence(eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.rundownRepo.GetOccurrenceSegments(eventID, occurrence.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("occurrence on %s has no run-sheet", req.OccurrenceDate)
+	}
+
+	templateSegments := make([]entity.EventRundownTemplateSegment, len(segments))
+	for i, segment := range segments {
+		templateSegments[i] = entity.EventRundownTemplateSegment{
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			PersonID:    segment.PersonID,
+			Notes:       segment.Notes,
+		}
+	}
+
+	if err := s.rundownRepo.ReplaceTemplateSegments(eventID, templateSegments); err != nil {
+		return nil, fmt.Errorf("failed to save run-sheet template: %w", err)
+	}
+	return s.GetTemplate(eventID)
+}
+
+// ApplyTemplate copies the template onto the occurrences between the dates that have not started yet.
+// Occurrences that already have a run-sheet are skipped unless overwrite is set, as are occurrences too
+// short for the template.
+func (s *eventRundownService) ApplyTemplate(eventID uuid.UUID, req *dto.ApplyRundownTemplateRequest) (*dto.ApplyRundownTemplateResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+	if endDate.Sub(startDate) > maxRundownRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not be longer than %d days", maxRundownRangeDays)
+	}
+
+	templateSegments, err := s.rundownRepo.GetTemplateSegments(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet template: %w", err)
+	}
+	if len(templateSegments) == 0 {
+		return nil, fmt.Errorf("event has no run-sheet template")
+	}
+	templateMinutes := 0
+	for _, segment := range templateSegments {
+		if end := segment.StartOffset + segment.Duration; end > templateMinutes {
+			templateMinutes = end
+		}
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	planned, err := s.rundownRepo.GetPlannedOccurrenceDates(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheets: %w", err)
+	}
+	hasRundown := make(map[string]bool, len(planned))
+	for _, date := range planned {
+		hasRundown[date.Format("2006-01-02")] = true
+	}
+
+	response := &dto.ApplyRundownTemplateResponse{EventID: eventID, Applied: []string{}, Skipped: []dto.SkippedOccurrence{}}
+	now := time.Now()
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		key := occurrence.Date.Format("2006-01-02")
+		date := time.Date(occurrence.Date.Year(), occurrence.Date.Month(), occurrence.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+		switch {
+		case !occurrence.Start.After(now):
+			response.Skipped = append(response.Skipped, dto.SkippedOccurrence{OccurrenceDate: key, Reason: "occurrence has already started"})
+			continue
+		case hasRundown[key] && !req.Overwrite:
+			response.Skipped = append(response.Skipped, dto.SkippedOccurrence{OccurrenceDate: key, Reason: "occurrence already has a run-sheet"})
+			continue
+		case templateMinutes > occurrenceMinutes(occurrence):
+			response.Skipped = append(response.Skipped, dto.SkippedOccurrence{OccurrenceDate: key, Reason: "occurrence is too short for the template"})
+			continue
+		}
+
+		segments := make([]entity.EventRundownSegment, len(templateSegments))
+		for j, segment := range templateSegments {
+			segments[j] = entity.EventRundownSegment{
+				Title:       segment.Title,
+				Type:        segment.Type,
+				StartOffset: segment.StartOffset,
+				Duration:    segment.Duration,
+				PersonID:    segment.PersonID,
+				Notes:       segment.Notes,
+			}
+		}
+		if err := s.rundownRepo.ReplaceOccurrenceSegments(eventID, date, segments); err != nil {
+			return nil, fmt.Errorf("failed to save run-sheet for %s: %w", key, err)
+		}
+		response.Applied = append(response.Applied, key)
+	}
+	return response, nil
+}
+
+// resolveOccurrence finds the occurrence of the event on a date with its start and end, overrides applied
+func (s *eventRundownService) resolveOccurrence(eventID uuid.UUID, occurrenceDate string) (*entity.Event, *expandedOccurrence, error) {
+	event, date, start, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, occurrenceDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, dayStart, dayStart.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range occurrences {
+		if occurrences[i].Date.Format("2006-01-02") == occurrenceDate {
+			occurrences[i].Date = date
+			return event, &occurrences[i], nil
+		}
+	}
+
+	// An overridden start may move the occurrence off its own date; keep the event's length
+	return event, &expandedOccurrence{Date: date, Start: start, End: start.Add(event.EndDatetime.Sub(event.StartDatetime))}, nil
+}
+
+// validateSegments checks that every segment ends within the given minutes and that the responsible
+// people exist
+func (s *eventRundownService) validateSegments(segments []dto.RundownSegmentRequest, minutes int) error {
+	checked := make(map[uuid.UUID]bool)
+	for _, segment := range segments {
+		if strings.TrimSpace(segment.Title) == "" {
+			return fmt.Errorf("segment title must not be empty")
+		}
+		if segment.StartOffset+segment.Duration > minutes {
+			return fmt.Errorf("segment %q ends %d minutes after the start, past the end of the occurrence at %d minutes",
+				segment.Title, segment.StartOffset+segment.Duration, minutes)
+		}
+
+		if segment.PersonID != nil && !checked[*segment.PersonID] {
+			if _, err := s.personRepo.GetByID(context.Background(), *segment.PersonID); err != nil {
+				return fmt.Errorf("person not found: %s", *segment.PersonID)
+			}
+			checked[*segment.PersonID] = true
+		}
+	}
+	return nil
+}
+
+// sortedSegmentRequests orders segments by start offset, keeping the given order for equal offsets
+func sortedSegmentRequests(segments []dto.RundownSegmentRequest) []dto.RundownSegmentRequest {
+	sorted := make([]dto.RundownSegmentRequest, len(segments))
+	copy(sorted, segments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartOffset < sorted[j].StartOffset })
+	return sorted
+}
+
+// occurrenceMinutes is the length of an occurrence in whole minutes
+func occurrenceMinutes(occurrence *expandedOccurrence) int {
+	return int(occurrence.End.Sub(occurrence.Start) / time.Minute)
+}
+
+func rundownToResponse(eventID uuid.UUID, occurrence *expandedOccurrence, segments []entity.EventRundownSegment) *dto.EventRundownResponse {
+	response := &dto.EventRundownResponse{
+		EventID:        eventID,
+		OccurrenceDate: occurrence.Date.Format("2006-01-02"),
+		StartDatetime:  occurrence.Start,
+		EndDatetime:    occurrence.End,
+		Segments:       make([]dto.RundownSegmentResponse, len(segments)),
+	}
+	for i := range segments {
+		segment := &segments[i]
+		startsAt := occurrence.Start.Add(time.Duration(segment.StartOffset) * time.Minute)
+		endsAt := startsAt.Add(time.Duration(segment.Duration) * time.Minute)
+		response.Segments[i] = dto.RundownSegmentResponse{
+			ID:          segment.ID,
+			Position:    segment.Position,
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			StartsAt:    &startsAt,
+			EndsAt:      &endsAt,
+			Person:      rundownPersonSummary(segment.Person),
+			Notes:       segment.Notes,
+		}
+	}
+	return response
+}
+
+func rundownPersonSummary(person *entity.Person) *dto.PersonSummary {
+	if person == nil {
+		return nil
+	}
+	summary := pemusikPersonSummary(person)
+	return &summary
+}
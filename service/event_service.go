@@ -3,6 +3,7 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ type EventService interface {
 	
 	// Event creation with PIC assignment
 	CreateEventWithPICs(req *dto.CreateEventRequest, createdBy uuid.UUID) (*dto.EventResponse, error)
+	CreateEventWithExceptions(req *dto.CreateEventRequest, exceptions []entity.RecurrenceException) (*dto.EventResponse, error)
 
 	// Recurring event operations
 	UpdateRecurringEvent(id uuid.UUID, req *dto.UpdateRecurringEventRequest) error
@@ -29,43 +31,100 @@ type EventService interface {
 	GetEventOccurrences(id uuid.UUID, req *dto.GetEventOccurrencesRequest) ([]dto.EventOccurrenceResponse, error)
 	GetOccurrencesInRange(req *dto.GetEventOccurrencesRequest) ([]dto.EventOccurrenceResponse, error)
 
+	// Occurrence index
+	ListOccurrences(req *dto.ListEventOccurrencesRequest) (*dto.EventOccurrenceListResponse, error)
+	GetWeekOccurrences(req *dto.WeekEventOccurrencesRequest) (*dto.EventOccurrenceListResponse, error)
+	ReindexOccurrences(eventID uuid.UUID) error
+	RebuildOccurrenceIndex() (int, error)
+	OnEventChange(listener func(eventID uuid.UUID))
+
 	// Validation and utility methods
 	ValidateRecurrenceRule(rule *dto.CreateRecurrenceRuleRequest) error
+	ValidateCreateEvent(req *dto.CreateEventRequest) error
 	GetNextOccurrence(id uuid.UUID, after time.Time) (*time.Time, error)
 }
 
 type eventService struct {
 	eventRepo           repository.EventRepository
 	eventPICRepo        repository.EventPICRepository
+	occurrenceRepo      repository.EventOccurrenceRepository
+	venueRepo           repository.VenueRepository
+	changeNotifier      EventChangeNotifier
 	recurrenceGenerator *RecurrenceGenerator
+	changeListeners     []func(eventID uuid.UUID)
 }
 
-func NewEventService(eventRepo repository.EventRepository, eventPICRepo repository.EventPICRepository) EventService {
+// NewEventService creates the event service; changeNotifier may be nil to disable change notifications
+func NewEventService(eventRepo repository.EventRepository, eventPICRepo repository.EventPICRepository, occurrenceRepo repository.EventOccurrenceRepository, venueRepo repository.VenueRepository, changeNotifier EventChangeNotifier) EventService {
 	return &eventService{
 		eventRepo:           eventRepo,
 		eventPICRepo:        eventPICRepo,
+		occurrenceRepo:      occurrenceRepo,
+		venueRepo:           venueRepo,
+		changeNotifier:      changeNotifier,
 		recurrenceGenerator: NewRecurrenceGenerator(),
 	}
 }
 
 func (s *eventService) CreateEvent(req *dto.CreateEventRequest) (*dto.EventResponse, error) {
+	event, sharedChurchIDs, err := s.buildEvent(req, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveNewEvent(event, sharedChurchIDs)
+}
+
+// CreateEventWithExceptions creates an event together with the occurrences it skips or overrides; the
+// event, its churches and its exceptions are saved in one transaction
+func (s *eventService) CreateEventWithExceptions(req *dto.CreateEventRequest, exceptions []entity.RecurrenceException) (*dto.EventResponse, error) {
+	event, sharedChurchIDs, err := s.buildEvent(req, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.CreateWithExceptions(event, sharedChurchIDs, exceptions); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+	if len(sharedChurchIDs) > 0 {
+		event.Churches = churchStubs(sharedChurchIDs)
+	}
+	s.reindex(event.ID)
+
+	return s.entityToResponse(event), nil
+}
+
+// ValidateCreateEvent runs every check CreateEvent makes, including the venue booking and churches,
+// without saving anything
+func (s *eventService) ValidateCreateEvent(req *dto.CreateEventRequest) error {
+	_, _, err := s.buildEvent(req, uuid.Nil)
+	return err
+}
+
+// buildEvent validates a create request, including its venue booking and churches, and returns the
+// unsaved event with the churches it is shared with. Bookings of the series the event continues, if
+// any, do not count as conflicts.
+func (s *eventService) buildEvent(req *dto.CreateEventRequest, continuesSeries uuid.UUID) (*entity.Event, []uuid.UUID, error) {
 	// Parse dates
 	eventDate, err := time.Parse("2006-01-02", req.EventDate)
 	if err != nil {
-		return nil, fmt.Errorf("invalid event date format: %w", err)
+		return nil, nil, fmt.Errorf("invalid event date format: %w", err)
 	}
 
 	startTime, err := time.Parse("15:04", req.StartTime)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start time format: %w", err)
+		return nil, nil, fmt.Errorf("invalid start time format: %w", err)
 	}
 
 	endTime, err := time.Parse("15:04", req.EndTime)
 	if err != nil {
-		return nil, fmt.Errorf("invalid end time format: %w", err)
+		return nil, nil, fmt.Errorf("invalid end time format: %w", err)
 	}
 
-	// Combine date and time
+	// Occurrences are generated in this zone, so it has to be a valid IANA name
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return nil, nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	// Combine date and time (wall clock in req.Timezone, stored as UTC)
 	startDateTime := time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(),
 		startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
 	endDateTime := time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(),
@@ -84,6 +143,7 @@ func (s *eventService) CreateEvent(req *dto.CreateEventRequest) (*dto.EventRespo
 		EndDatetime:           endDateTime,
 		AllDay:                req.AllDay,
 		Timezone:              req.Timezone,
+		VenueID:               req.VenueID,
 		IsPublic:              req.IsPublic,
 		DiscipleshipJourneyID: req.DiscipleshipJourneyID,
 	}
@@ -104,9 +164,12 @@ func (s *eventService) CreateEvent(req *dto.CreateEventRequest) (*dto.EventRespo
 
 	// Handle recurrence rule
 	if req.RecurrenceRule != nil {
-		rule, err := s.createRecurrenceRuleEntity(req.RecurrenceRule)
+		rule, err := s.createRecurrenceRuleEntity(req.RecurrenceRule, recurrenceLocation(req.Timezone))
 		if err != nil {
-			return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+			return nil, nil, fmt.Errorf("invalid recurrence rule: %w", err)
+		}
+		if rdates := req.RecurrenceRule.RDates; len(rdates) > 0 && rdates[0][:10] < eventDate.Format("2006-01-02") {
+			return nil, nil, fmt.Errorf("invalid recurrence rule: additional date %s is before the event date", rdates[0])
 		}
 		event.RecurrenceRule = rule
 	}
@@ -116,10 +179,38 @@ func (s *eventService) CreateEvent(req *dto.CreateEventRequest) (*dto.EventRespo
 		event.Capacity = 99999
 	}
 
-	// Create event
+	if event.VenueID != nil {
+		// The conflict check skips bookings of the event's own ID, which the continued series shares
+		// until the event is saved
+		event.ID = continuesSeries
+		err := s.checkVenueBooking(event, req.AllowVenueConflict)
+		event.ID = uuid.Nil
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	churchID, sharedChurchIDs, err := s.eventChurches(req.ChurchID, req.SharedChurchIDs, event.VenueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	event.ChurchID = churchID
+
+	return event, sharedChurchIDs, nil
+}
+
+// saveNewEvent stores an event built by buildEvent and shares it with the churches
+func (s *eventService) saveNewEvent(event *entity.Event, sharedChurchIDs []uuid.UUID) (*dto.EventResponse, error) {
 	if err := s.eventRepo.Create(event); err != nil {
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
+	if len(sharedChurchIDs) > 0 {
+		if err := s.eventRepo.ReplaceChurches(event.ID, sharedChurchIDs); err != nil {
+			return nil, fmt.Errorf("failed to share event with churches: %w", err)
+		}
+		event.Churches = churchStubs(sharedChurchIDs)
+	}
+	s.reindex(event.ID)
 
 	return s.entityToResponse(event), nil
 }
@@ -219,6 +310,7 @@ func (s *eventService) UpdateEvent(id uuid.UUID, req *dto.UpdateEventRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
+	before := *event
 
 	// Update fields if provided
 	if req.Title != nil {
@@ -288,6 +380,10 @@ func (s *eventService) UpdateEvent(id uuid.UUID, req *dto.UpdateEventRequest) (*
 		event.AllDay = *req.AllDay
 	}
 	if req.Timezone != nil {
+		// Occurrences are generated in this zone, so it has to be a valid IANA name
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
 		event.Timezone = *req.Timezone
 	}
 
@@ -305,10 +401,57 @@ func (s *eventService) UpdateEvent(id uuid.UUID, req *dto.UpdateEventRequest) (*
 		event.ExpectedKids = *req.ExpectedKids
 	}
 
+	if req.VenueID != nil {
+		event.VenueID = req.VenueID
+		if *req.VenueID == uuid.Nil {
+			event.VenueID = nil
+		}
+		// The loaded association would otherwise be saved back over the new venue ID
+		event.Venue = nil
+	}
+
+	// Only a change of venue or time is checked, so unrelated edits are not blocked by an old conflict
+	if event.VenueID != nil && venueBookingChanged(&before, event) {
+		if err := s.checkVenueBooking(event, req.AllowVenueConflict); err != nil {
+			return nil, err
+		}
+	}
+
+	var sharedChurchIDs []uuid.UUID
+	if req.ChurchID != nil || req.SharedChurchIDs != nil {
+		churchID := event.ChurchID
+		if req.ChurchID != nil {
+			churchID = req.ChurchID
+		}
+		sharedChurchIDs = churchIDsOf(event.Churches)
+		if req.SharedChurchIDs != nil {
+			sharedChurchIDs = *req.SharedChurchIDs
+		}
+		if churchID, sharedChurchIDs, err = s.eventChurches(churchID, sharedChurchIDs, event.VenueID); err != nil {
+			return nil, err
+		}
+		// Only PICs and admins of the owning church manage an event, so it keeps an owner
+		if churchID == nil {
+			return nil, fmt.Errorf("church is required for an event without a venue")
+		}
+		event.ChurchID = churchID
+		// The loaded association would otherwise be saved back over the new church ID
+		event.Church = nil
+	}
+
 	// Update event
 	if err := s.eventRepo.Update(event); err != nil {
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
+	if req.ChurchID != nil || req.SharedChurchIDs != nil {
+		if err := s.eventRepo.ReplaceChurches(event.ID, sharedChurchIDs); err != nil {
+			return nil, fmt.Errorf("failed to share event with churches: %w", err)
+		}
+		event.Churches = churchStubs(sharedChurchIDs)
+	}
+	s.reindex(event.ID)
+
+	s.notifyChange(eventUpdateChange(&before, event))
 
 	return s.entityToResponse(event), nil
 }
@@ -317,6 +460,10 @@ func (s *eventService) DeleteEvent(id uuid.UUID) error {
 	if err := s.eventRepo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
+	if err := s.occurrenceRepo.DeleteByEventID(id); err != nil {
+		log.Printf("occurrence index: failed to remove event %s: %v", id, err)
+	}
+	s.eventChanged(id)
 	return nil
 }
 
@@ -325,6 +472,8 @@ func (s *eventService) ListEvents(req *dto.EventFilterRequest) (*dto.EventListRe
 		Type:     req.Type,
 		IsPublic: req.IsPublic,
 		Search:   req.Search,
+		ChurchID: req.ChurchID,
+		Viewer:   req.Viewer,
 		Limit:    req.Limit,
 		Offset:   (req.Page - 1) * req.Limit,
 	}
@@ -418,7 +567,12 @@ func (s *eventService) GetEventOccurrences(id uuid.UUID, req *dto.GetEventOccurr
 		return nil, fmt.Errorf("invalid end date format: %w", err)
 	}
 
-	return s.generateOccurrences(event, startDate, endDate)
+	viewLoc, err := s.occurrenceViewLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.generateOccurrences(event, startDate, endDate, viewLoc)
 }
 
 func (s *eventService) GetOccurrencesInRange(req *dto.GetEventOccurrencesRequest) ([]dto.EventOccurrenceResponse, error) {
@@ -432,16 +586,51 @@ func (s *eventService) GetOccurrencesInRange(req *dto.GetEventOccurrencesRequest
 		return nil, fmt.Errorf("invalid end date format: %w", err)
 	}
 
-	// Get all events that could have occurrences in this range
-	events, err := s.eventRepo.GetEventsWithRecurrenceInRange(startDate, endDate)
+	viewLoc, err := s.occurrenceViewLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.occurrenceIndexCovers(endDate) {
+		filters, err := occurrenceRangeFilters(startDate, endDate, viewLoc)
+		if err != nil {
+			return nil, err
+		}
+		filters.ChurchID = req.ChurchID
+		filters.Viewer = req.Viewer
+		occurrences, _, err := s.occurrenceRepo.List(*filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list occurrences: %w", err)
+		}
+		return s.occurrencesToResponses(occurrences, viewLoc), nil
+	}
+
+	// Beyond the index horizon the rules are expanded in memory; the range is widened by a day
+	// because the requested dates may be in a different zone than the events
+	events, err := s.eventRepo.GetEventsWithRecurrenceInRange(startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 1))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recurring events: %w", err)
 	}
+	singleEvents, err := s.eventRepo.GetByDateRange(startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 1), repository.EventFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	for _, event := range singleEvents {
+		if event.RecurrenceRuleID == nil {
+			events = append(events, event)
+		}
+	}
 
 	var allOccurrences []dto.EventOccurrenceResponse
 
 	for _, event := range events {
-		occurrences, err := s.generateOccurrences(&event, startDate, endDate)
+		if req.ChurchID != nil && !eventOfChurch(&event, *req.ChurchID) {
+			continue
+		}
+		if !eventVisibleTo(&event, req.Viewer) {
+			continue
+		}
+		occurrences, err := s.generateOccurrences(&event, startDate, endDate, viewLoc)
 		if err != nil {
 			continue // Skip events with generation errors
 		}
@@ -451,9 +640,33 @@ func (s *eventService) GetOccurrencesInRange(req *dto.GetEventOccurrencesRequest
 	return allOccurrences, nil
 }
 
+// occurrenceViewLocation resolves the timezone occurrences are returned in; nil means each event's own zone
+func (s *eventService) occurrenceViewLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	return loc, nil
+}
+
 // Helper methods
 
-func (s *eventService) createRecurrenceRuleEntity(req *dto.CreateRecurrenceRuleRequest) (*entity.RecurrenceRule, error) {
+// createRecurrenceRuleEntity builds and validates the rule entity. An RRULE string replaces the
+// field-by-field shape but keeps the extra dates, and req.RRule is rewritten to the canonical form of
+// the resulting rule.
+func (s *eventService) createRecurrenceRuleEntity(req *dto.CreateRecurrenceRuleRequest, loc *time.Location) (*entity.RecurrenceRule, error) {
+	if req.RRule != "" {
+		parsed, err := s.recurrenceGenerator.ParseRRule(req.RRule, loc)
+		if err != nil {
+			return nil, err
+		}
+		parsed.RDates = req.RDates
+		*req = *parsed
+	}
+
 	// Convert slice fields to JSON strings
 	byWeekdayJSON, err := s.sliceToJSON(req.ByWeekday)
 	if err != nil {
@@ -480,6 +693,16 @@ func (s *eventService) createRecurrenceRuleEntity(req *dto.CreateRecurrenceRuleR
 		return nil, fmt.Errorf("failed to convert ByYearDay to JSON: %w", err)
 	}
 
+	rdates, err := s.recurrenceGenerator.NormalizeRDates(req.RDates)
+	if err != nil {
+		return nil, err
+	}
+	req.RDates = rdates
+	rdatesJSON, err := s.sliceToJSON(rdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert RDates to JSON: %w", err)
+	}
+
 	rule := &entity.RecurrenceRule{
 		Frequency:  req.Frequency,
 		Interval:   req.Interval,
@@ -490,6 +713,7 @@ func (s *eventService) createRecurrenceRuleEntity(req *dto.CreateRecurrenceRuleR
 		WeekStart:  req.WeekStart,
 		ByYearDay:  byYearDayJSON,
 		Count:      req.Count,
+		RDates:     rdatesJSON,
 	}
 
 	if rule.Interval == 0 {
@@ -513,6 +737,8 @@ func (s *eventService) createRecurrenceRuleEntity(req *dto.CreateRecurrenceRuleR
 		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
 	}
 
+	req.RRule = s.recurrenceGenerator.FormatRRule(rule, loc)
+
 	return rule, nil
 }
 
@@ -530,6 +756,7 @@ func (s *eventService) entityToResponse(event *entity.Event) *dto.EventResponse
 		EndDatetime:           event.EndDatetime,
 		AllDay:                event.AllDay,
 		Timezone:              event.Timezone,
+		VenueID:               event.VenueID,
 		IsPublic:              event.IsPublic,
 		DiscipleshipJourneyID: event.DiscipleshipJourneyID,
 		ExpectedParticipants:  event.ExpectedParticipants,
@@ -540,6 +767,18 @@ func (s *eventService) entityToResponse(event *entity.Event) *dto.EventResponse
 		UpdatedAt:             event.UpdatedAt,
 	}
 	
+	if event.Venue != nil {
+		response.Venue = venueToResponse(event.Venue)
+	}
+
+	response.ChurchID = event.ChurchID
+	if event.Church != nil {
+		response.Church = &dto.EventChurchResponse{ID: event.Church.ID, Name: event.Church.Name}
+	}
+	for _, church := range event.Churches {
+		response.SharedChurches = append(response.SharedChurches, dto.EventChurchResponse{ID: church.ID, Name: church.Name})
+	}
+
 	// Convert EventPICs
 	if len(event.EventPICs) > 0 {
 		eventPICs := make([]dto.EventPICResponse, 0, len(event.EventPICs))
@@ -610,6 +849,11 @@ func (s *eventService) entityToResponse(event *entity.Event) *dto.EventResponse
 			byYearDay = []int64{} // Default to empty slice on error
 		}
 
+		rdates, err := s.jsonToStringSlice(event.RecurrenceRule.RDates)
+		if err != nil {
+			rdates = []string{} // Default to empty slice on error
+		}
+
 		response.RecurrenceRule = &dto.RecurrenceRuleResponse{
 			ID:         event.RecurrenceRule.ID,
 			Frequency:  event.RecurrenceRule.Frequency,
@@ -622,6 +866,8 @@ func (s *eventService) entityToResponse(event *entity.Event) *dto.EventResponse
 			ByYearDay:  byYearDay,
 			Count:      event.RecurrenceRule.Count,
 			Until:      event.RecurrenceRule.Until,
+			RDates:     rdates,
+			RRule:      s.recurrenceGenerator.FormatRRule(event.RecurrenceRule, recurrenceLocation(event.Timezone)),
 		}
 	}
 
@@ -639,34 +885,80 @@ func (s *eventService) entityToResponse(event *entity.Event) *dto.EventResponse
 	return response
 }
 
-func (s *eventService) generateOccurrences(event *entity.Event, startDate, endDate time.Time) ([]dto.EventOccurrenceResponse, error) {
-	var occurrenceResponses []dto.EventOccurrenceResponse
+// generateOccurrences returns the occurrences whose start falls on startDate..endDate in viewLoc.
+// Times are converted to viewLoc, or left in the event's own zone when viewLoc is nil.
+func (s *eventService) generateOccurrences(event *entity.Event, startDate, endDate time.Time, viewLoc *time.Location) ([]dto.EventOccurrenceResponse, error) {
+	loc := recurrenceLocation(event.Timezone)
+	if viewLoc == nil {
+		viewLoc = loc
+	}
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, viewLoc)
+	rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, viewLoc).Add(-time.Nanosecond)
 
-	// Get exceptions for this event
-	exceptions, err := s.eventRepo.GetRecurrenceExceptions(event.ID)
+	occurrences, err := s.expandOccurrences(event, rangeStart, rangeEnd)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recurrence exceptions: %w", err)
+		return nil, err
+	}
+
+	var occurrenceResponses []dto.EventOccurrenceResponse
+	for _, occurrence := range occurrences {
+		response := dto.EventOccurrenceResponse{
+			EventID:        event.ID,
+			OccurrenceDate: occurrence.Date,
+			StartDatetime:  occurrence.Start.In(viewLoc),
+			EndDatetime:    occurrence.End.In(viewLoc),
+			IsException:    occurrence.Exception != nil,
+			IsSkipped:      false,
+			OriginalEvent:  s.entityToResponse(event),
+		}
+		if occurrence.Exception != nil {
+			response.ExceptionNotes = occurrence.Exception.Notes
+		}
+		occurrenceResponses = append(occurrenceResponses, response)
 	}
 
+	return occurrenceResponses, nil
+}
+
+// expandedOccurrence is one generated occurrence; Start and End are in the event's zone
+type expandedOccurrence struct {
+	Date      time.Time
+	Start     time.Time
+	End       time.Time
+	Exception *entity.RecurrenceException
+}
+
+// expandOccurrences expands an event with the service's own repository and generator
+func (s *eventService) expandOccurrences(event *entity.Event, rangeStart, rangeEnd time.Time) ([]expandedOccurrence, error) {
+	return expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeEnd)
+}
+
+// expandEventOccurrences generates the occurrences of an event that start between rangeStart and rangeEnd,
+// with skipped occurrences left out and overridden times applied
+func expandEventOccurrences(eventRepo repository.EventRepository, rg *RecurrenceGenerator, event *entity.Event, rangeStart, rangeEnd time.Time) ([]expandedOccurrence, error) {
+	loc := recurrenceLocation(event.Timezone)
+
 	if event.RecurrenceRule == nil {
 		// Single event - check if it's in range
-		if (event.EventDate.Equal(startDate) || event.EventDate.After(startDate)) &&
-			(event.EventDate.Equal(endDate) || event.EventDate.Before(endDate)) {
-			occurrenceResponses = append(occurrenceResponses, dto.EventOccurrenceResponse{
-				EventID:        event.ID,
-				OccurrenceDate: event.EventDate,
-				StartDatetime:  event.StartDatetime,
-				EndDatetime:    event.EndDatetime,
-				IsException:    false,
-				IsSkipped:      false,
-				OriginalEvent:  s.entityToResponse(event),
-			})
+		startTime := wallClockIn(event.StartDatetime, loc)
+		if startTime.Before(rangeStart) || startTime.After(rangeEnd) {
+			return nil, nil
 		}
-		return occurrenceResponses, nil
+		return []expandedOccurrence{{
+			Date:  event.EventDate,
+			Start: startTime,
+			End:   wallClockIn(event.EndDatetime, loc),
+		}}, nil
+	}
+
+	// Get exceptions for this event
+	exceptions, err := eventRepo.GetRecurrenceExceptions(event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrence exceptions: %w", err)
 	}
 
 	// Use the RecurrenceGenerator for recurring events
-	occurrenceDates, err := s.recurrenceGenerator.GenerateOccurrences(event, event.RecurrenceRule, startDate, endDate, exceptions)
+	occurrenceStarts, err := rg.GenerateOccurrences(event, event.RecurrenceRule, rangeStart, rangeEnd, exceptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate occurrences: %w", err)
 	}
@@ -678,46 +970,37 @@ func (s *eventService) generateOccurrences(event *entity.Event, startDate, endDa
 		exceptionMap[dateKey] = &exceptions[i]
 	}
 
-	// Convert generated dates to response objects
-	for _, occurrenceDate := range occurrenceDates {
-		dateKey := occurrenceDate.Format("2006-01-02")
+	// The duration is kept on the wall clock, so an occurrence spanning a DST change still ends at the same local time
+	duration := event.EndDatetime.Sub(event.StartDatetime)
+
+	occurrences := make([]expandedOccurrence, 0, len(occurrenceStarts))
+	for _, occurrenceStart := range occurrenceStarts {
+		// Exceptions are keyed by the occurrence's date in the event's zone
+		dateKey := occurrenceStart.Format("2006-01-02")
 		exception := exceptionMap[dateKey]
 
-		// Calculate start and end times for this occurrence
-		duration := event.EndDatetime.Sub(event.StartDatetime)
-		startTime := time.Date(occurrenceDate.Year(), occurrenceDate.Month(), occurrenceDate.Day(),
-			event.StartDatetime.Hour(), event.StartDatetime.Minute(), event.StartDatetime.Second(),
-			event.StartDatetime.Nanosecond(), event.StartDatetime.Location())
-		endTime := startTime.Add(duration)
+		startTime := occurrenceStart
+		endTime := wallClockIn(wallClockOf(occurrenceStart).Add(duration), loc)
 
 		// Use exception override times if available
 		if exception != nil {
 			if exception.OverrideStart != nil {
-				startTime = *exception.OverrideStart
+				startTime = wallClockIn(*exception.OverrideStart, loc)
 			}
 			if exception.OverrideEnd != nil {
-				endTime = *exception.OverrideEnd
+				endTime = wallClockIn(*exception.OverrideEnd, loc)
 			}
 		}
 
-		occurrenceResponses = append(occurrenceResponses, dto.EventOccurrenceResponse{
-			EventID:        event.ID,
-			OccurrenceDate: occurrenceDate,
-			StartDatetime:  startTime,
-			EndDatetime:    endTime,
-			IsException:    exception != nil,
-			IsSkipped:      false,
-			ExceptionNotes: func() string {
-				if exception != nil {
-					return exception.Notes
-				}
-				return ""
-			}(),
-			OriginalEvent: s.entityToResponse(event),
+		occurrences = append(occurrences, expandedOccurrence{
+			Date:      time.Date(occurrenceStart.Year(), occurrenceStart.Month(), occurrenceStart.Day(), 0, 0, 0, 0, time.UTC),
+			Start:     startTime,
+			End:       endTime,
+			Exception: exception,
 		})
 	}
 
-	return occurrenceResponses, nil
+	return occurrences, nil
 }
 
 // New methods for handling single and future occurrence updates
@@ -755,42 +1038,15 @@ func (s *eventService) UpdateFutureOccurrences(id uuid.UUID, req *dto.UpdateFutu
 		return fmt.Errorf("invalid from date format: %w", err)
 	}
 
-	// End the current series at the from date
-	if err := s.eventRepo.SetRecurrenceUntilDate(event.ID, fromDate.AddDate(0, 0, -1)); err != nil {
-		return fmt.Errorf("failed to end current series: %w", err)
-	}
-
-	// Create a new event for the updated series starting from the from date
+	// End the current series before the from date and continue it in a new event
 	return s.createNewSeriesFromDate(event, fromDate, req.StartTime, req.EndTime, &req.Event, req.RecurrenceRule)
 }
 
+// ValidateRecurrenceRule validates the rule without a timezone, so RRULE UNTIL values are read as UTC.
+// On success rule is normalized in place and rule.RRule holds the canonical RRULE.
 func (s *eventService) ValidateRecurrenceRule(rule *dto.CreateRecurrenceRuleRequest) error {
-	// Convert DTO to entity for validation
-	byWeekdayJSON, _ := s.sliceToJSON(rule.ByWeekday)
-	byMonthDayJSON, _ := s.sliceToJSON(rule.ByMonthDay)
-	byMonthJSON, _ := s.sliceToJSON(rule.ByMonth)
-	bySetPosJSON, _ := s.sliceToJSON(rule.BySetPos)
-	byYearDayJSON, _ := s.sliceToJSON(rule.ByYearDay)
-
-	entityRule := &entity.RecurrenceRule{
-		Frequency:  rule.Frequency,
-		Interval:   rule.Interval,
-		ByWeekday:  byWeekdayJSON,
-		ByMonthDay: byMonthDayJSON,
-		ByMonth:    byMonthJSON,
-		BySetPos:   bySetPosJSON,
-		WeekStart:  rule.WeekStart,
-		ByYearDay:  byYearDayJSON,
-	}
-
-	if entityRule.Interval == 0 {
-		entityRule.Interval = 1
-	}
-	if entityRule.WeekStart == "" {
-		entityRule.WeekStart = "MO"
-	}
-
-	return s.recurrenceGenerator.ValidateRecurrenceRule(entityRule)
+	_, err := s.createRecurrenceRuleEntity(rule, time.UTC)
+	return err
 }
 
 func (s *eventService) GetNextOccurrence(id uuid.UUID, after time.Time) (*time.Time, error) {
@@ -799,7 +1055,11 @@ func (s *eventService) GetNextOccurrence(id uuid.UUID, after time.Time) (*time.T
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	return s.recurrenceGenerator.GetNextOccurrence(event, event.RecurrenceRule, after)
+	// after is a calendar date; the next occurrence starts after that whole day in the event's zone
+	loc := recurrenceLocation(event.Timezone)
+	endOfDay := time.Date(after.Year(), after.Month(), after.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+
+	return s.recurrenceGenerator.GetNextOccurrence(event, event.RecurrenceRule, endOfDay)
 }
 
 // Recurring event update helper methods
@@ -816,6 +1076,7 @@ func (s *eventService) updateSingleOccurrenceFromRecurring(event *entity.Event,
 func (s *eventService) createOrUpdateException(event *entity.Event, occurrenceDate time.Time, startTime, endTime *string, eventUpdates *dto.UpdateEventRequest, modificationType string) error {
 	// Create or get existing exception
 	exception, err := s.eventRepo.GetExceptionByEventAndDate(event.ID, occurrenceDate)
+	oldStart, oldEnd := s.occurrenceTimes(event, occurrenceDate, exception)
 	if err != nil {
 		// Create new exception
 		exception = &entity.RecurrenceException{
@@ -825,13 +1086,9 @@ func (s *eventService) createOrUpdateException(event *entity.Event, occurrenceDa
 			IsSkipped:        false,
 		}
 
-		// Store original times for reference
-		originalStart := time.Date(occurrenceDate.Year(), occurrenceDate.Month(), occurrenceDate.Day(),
-			event.StartDatetime.Hour(), event.StartDatetime.Minute(), event.StartDatetime.Second(),
-			event.StartDatetime.Nanosecond(), event.StartDatetime.Location())
-		originalEnd := time.Date(occurrenceDate.Year(), occurrenceDate.Month(), occurrenceDate.Day(),
-			event.EndDatetime.Hour(), event.EndDatetime.Minute(), event.EndDatetime.Second(),
-			event.EndDatetime.Nanosecond(), event.EndDatetime.Location())
+		// Store original times for reference; an extra date may have its own start time
+		originalStart := s.recurrenceGenerator.ScheduledStartOn(event, occurrenceDate)
+		originalEnd := originalStart.Add(event.EndDatetime.Sub(event.StartDatetime))
 
 		exception.OriginalStartTime = &originalStart
 		exception.OriginalEndTime = &originalEnd
@@ -869,12 +1126,51 @@ func (s *eventService) createOrUpdateException(event *entity.Event, occurrenceDa
 
 	// Save the exception
 	if exception.ID == uuid.Nil {
-		return s.eventRepo.CreateRecurrenceException(exception)
+		err = s.eventRepo.CreateRecurrenceException(exception)
+	} else {
+		err = s.eventRepo.UpdateRecurrenceException(exception)
+	}
+	if err != nil {
+		return err
+	}
+	s.reindex(event.ID)
+
+	newStart, newEnd := s.occurrenceTimes(event, occurrenceDate, exception)
+	if !newStart.Equal(oldStart) || !newEnd.Equal(oldEnd) {
+		s.notifyChange(&EventChange{
+			Event: event,
+			Occurrences: []OccurrenceChange{{
+				Date:     occurrenceDate,
+				OldStart: oldStart,
+				OldEnd:   oldEnd,
+				NewStart: &newStart,
+				NewEnd:   &newEnd,
+			}},
+		})
 	}
-	return s.eventRepo.UpdateRecurrenceException(exception)
+	return nil
 }
 
+// createNewSeriesFromDate ends the original series the day before fromDate and continues it in a new
+// event. The new series is validated before the original is cut short, so a rejected venue or church
+// leaves the original untouched.
 func (s *eventService) createNewSeriesFromDate(originalEvent *entity.Event, fromDate time.Time, startTime, endTime *string, eventUpdates *dto.UpdateEventRequest, recurrenceRule *dto.CreateRecurrenceRuleRequest) error {
+	// The original series ends with UNTIL instead of COUNT: the day before the from date, or the day of
+	// its last counted occurrence when COUNT runs out earlier
+	until := fromDate.AddDate(0, 0, -1)
+	var counted []time.Time
+	if originalEvent.RecurrenceRule != nil && originalEvent.RecurrenceRule.Count != nil {
+		var err error
+		counted, err = s.countedBefore(originalEvent, fromDate)
+		if err != nil {
+			return err
+		}
+		if n := len(counted); n > 0 && n >= *originalEvent.RecurrenceRule.Count {
+			last := counted[n-1]
+			until = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+		}
+	}
+
 	// Build create request for new series
 	createReq := &dto.CreateEventRequest{
 		Title:                 originalEvent.Title,
@@ -888,6 +1184,10 @@ func (s *eventService) createNewSeriesFromDate(originalEvent *entity.Event, from
 		EndTime:               originalEvent.EndDatetime.Format("15:04"),
 		AllDay:                originalEvent.AllDay,
 		Timezone:              originalEvent.Timezone,
+		VenueID:               originalEvent.VenueID,
+		AllowVenueConflict:    eventUpdates.AllowVenueConflict,
+		ChurchID:              originalEvent.ChurchID,
+		SharedChurchIDs:       churchIDsOf(originalEvent.Churches),
 		IsPublic:              originalEvent.IsPublic,
 		DiscipleshipJourneyID: originalEvent.DiscipleshipJourneyID,
 	}
@@ -905,6 +1205,18 @@ func (s *eventService) createNewSeriesFromDate(originalEvent *entity.Event, from
 	if eventUpdates.EventLocation != nil {
 		createReq.EventLocation = *eventUpdates.EventLocation
 	}
+	if eventUpdates.VenueID != nil {
+		createReq.VenueID = eventUpdates.VenueID
+		if *eventUpdates.VenueID == uuid.Nil {
+			createReq.VenueID = nil
+		}
+	}
+	if eventUpdates.ChurchID != nil {
+		createReq.ChurchID = eventUpdates.ChurchID
+	}
+	if eventUpdates.SharedChurchIDs != nil {
+		createReq.SharedChurchIDs = *eventUpdates.SharedChurchIDs
+	}
 	if startTime != nil {
 		createReq.StartTime = *startTime
 	}
@@ -932,17 +1244,107 @@ func (s *eventService) createNewSeriesFromDate(originalEvent *entity.Event, from
 			BySetPos:   bySetPos,
 			WeekStart:  originalEvent.RecurrenceRule.WeekStart,
 			ByYearDay:  byYearDay,
-			Count:      originalEvent.RecurrenceRule.Count,
+		}
+		// Occurrences before the from date have used up part of COUNT
+		if count := originalEvent.RecurrenceRule.Count; count != nil {
+			remaining := *count - len(counted)
+			if remaining <= 0 {
+				return fmt.Errorf("the series has no occurrences from %s on", fromDate.Format("2006-01-02"))
+			}
+			createReq.RecurrenceRule.Count = &remaining
 		}
 		if originalEvent.RecurrenceRule.Until != nil {
 			until := originalEvent.RecurrenceRule.Until.Format("2006-01-02")
 			createReq.RecurrenceRule.Until = &until
 		}
+		// Extra dates from the from date on move to the new series
+		rdates, _ := s.jsonToStringSlice(originalEvent.RecurrenceRule.RDates)
+		for _, rdate := range rdates {
+			if len(rdate) >= 10 && rdate[:10] >= fromDate.Format("2006-01-02") {
+				createReq.RecurrenceRule.RDates = append(createReq.RecurrenceRule.RDates, rdate)
+			}
+		}
 	}
 
-	// Create the new event series
-	_, err := s.CreateEvent(createReq)
-	return err
+	event, sharedChurchIDs, err := s.buildEvent(createReq, originalEvent.ID)
+	if err != nil {
+		return err
+	}
+
+	// Ending the original, creating the new series and handing it everything from the from date on
+	// happen in one transaction, so a failure part way leaves the original series as it was
+	err = s.eventRepo.Transaction(func(eventRepo repository.EventRepository, eventPICRepo repository.EventPICRepository) error {
+		if err := eventRepo.SetRecurrenceUntilDate(originalEvent.ID, until); err != nil {
+			return fmt.Errorf("failed to end current series: %w", err)
+		}
+		if err := eventRepo.CreateWithExceptions(event, sharedChurchIDs, nil); err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+
+		// Rotations, per-occurrence PICs, setlists, musician rosters and run-sheets from the from date on belong to the new series now
+		if err := eventPICRepo.SplitOccurrencePICs(originalEvent.ID, event.ID, fromDate); err != nil {
+			return fmt.Errorf("failed to move occurrence PICs to the new series: %w", err)
+		}
+		if err := eventRepo.MoveSetlistItems(originalEvent.ID, event.ID, fromDate); err != nil {
+			return fmt.Errorf("failed to move setlists to the new series: %w", err)
+		}
+		if err := eventRepo.MoveMusicianSchedules(originalEvent.ID, event.ID, fromDate); err != nil {
+			return fmt.Errorf("failed to move musician schedules to the new series: %w", err)
+		}
+		if err := eventRepo.MoveRundowns(originalEvent.ID, event.ID, fromDate); err != nil {
+			return fmt.Errorf("failed to move run-sheets to the new series: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(sharedChurchIDs) > 0 {
+		event.Churches = churchStubs(sharedChurchIDs)
+	}
+	s.reindex(originalEvent.ID)
+	s.reindex(event.ID)
+	created := s.entityToResponse(event)
+
+	change := &EventChange{Event: originalEvent}
+	change.AddField("Title", originalEvent.Title, created.Title)
+	change.AddField("Description", originalEvent.Description, created.Description)
+	change.AddField("Location", originalEvent.EventLocation, created.EventLocation)
+	change.AddField("Banner image", originalEvent.BannerImage, created.BannerImage)
+	if originalEvent.RecurrenceRule != nil && created.RecurrenceRule != nil {
+		loc := recurrenceLocation(originalEvent.Timezone)
+		change.AddField("Repeats", s.recurrenceGenerator.FormatRRule(originalEvent.RecurrenceRule, loc), created.RecurrenceRule.RRule)
+	}
+
+	oldStart, oldEnd := s.occurrenceTimes(originalEvent, fromDate, nil)
+	if !created.StartDatetime.Equal(oldStart) || !created.EndDatetime.Equal(oldEnd) {
+		change.Occurrences = append(change.Occurrences, OccurrenceChange{
+			Date:     fromDate,
+			Future:   true,
+			OldStart: oldStart,
+			OldEnd:   oldEnd,
+			NewStart: &created.StartDatetime,
+			NewEnd:   &created.EndDatetime,
+		})
+	}
+	s.notifyChange(change)
+
+	return nil
+}
+
+// countedBefore returns the occurrences limited by the rule's COUNT that fall before date. Extra dates
+// are not counted and skipped occurrences are, as in RFC 5545.
+func (s *eventService) countedBefore(event *entity.Event, date time.Time) ([]time.Time, error) {
+	rule := *event.RecurrenceRule
+	rule.RDates = ""
+	loc := recurrenceLocation(event.Timezone)
+	end := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc).Add(-time.Second)
+
+	occurrences, err := s.recurrenceGenerator.GenerateOccurrences(event, &rule, time.Time{}, end, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count occurrences: %w", err)
+	}
+	return occurrences, nil
 }
 
 func (s *eventService) updateEntireSeries(event *entity.Event, req *dto.UpdateRecurringEventRequest) error {
@@ -957,16 +1359,14 @@ func (s *eventService) updateThisAndFutureOccurrences(event *entity.Event, req *
 		return fmt.Errorf("invalid occurrence date format: %w", err)
 	}
 
-	// End the current series at the occurrence date (before it)
-	if err := s.eventRepo.SetRecurrenceUntilDate(event.ID, occurrenceDate.AddDate(0, 0, -1)); err != nil {
-		return fmt.Errorf("failed to end current series: %w", err)
-	}
-
-	// Create a new event for the updated series starting from the occurrence date
+	// End the current series before the occurrence date and continue it in a new event
 	return s.createNewSeriesFromDate(event, occurrenceDate, req.StartTime, req.EndTime, &req.Event, nil)
 }
 
 func (s *eventService) skipSingleOccurrence(event *entity.Event, occurrenceDate time.Time) error {
+	existing, _ := s.eventRepo.GetExceptionByEventAndDate(event.ID, occurrenceDate)
+	oldStart, oldEnd := s.occurrenceTimes(event, occurrenceDate, existing)
+
 	exception := &entity.RecurrenceException{
 		EventID:          event.ID,
 		ExceptionDate:    occurrenceDate,
@@ -975,11 +1375,99 @@ func (s *eventService) skipSingleOccurrence(event *entity.Event, occurrenceDate
 		Notes:            "Occurrence deleted by user",
 	}
 
-	return s.eventRepo.CreateRecurrenceException(exception)
+	if err := s.eventRepo.CreateRecurrenceException(exception); err != nil {
+		return err
+	}
+	s.reindex(event.ID)
+
+	s.notifyChange(&EventChange{
+		Event:       event,
+		Occurrences: []OccurrenceChange{{Date: occurrenceDate, OldStart: oldStart, OldEnd: oldEnd}},
+	})
+	return nil
 }
 
 func (s *eventService) deleteFutureOccurrences(event *entity.Event, fromDate time.Time) error {
-	return s.eventRepo.DeleteFutureOccurrences(event.ID, fromDate)
+	// The series ends with UNTIL instead of COUNT; when COUNT runs out before the from date there is
+	// nothing left to delete, and ending it with UNTIL would only lift the limit
+	if event.RecurrenceRule != nil && event.RecurrenceRule.Count != nil {
+		counted, err := s.countedBefore(event, fromDate)
+		if err != nil {
+			return err
+		}
+		if len(counted) >= *event.RecurrenceRule.Count {
+			return nil
+		}
+	}
+
+	if err := s.eventRepo.DeleteFutureOccurrences(event.ID, fromDate); err != nil {
+		return err
+	}
+	s.reindex(event.ID)
+
+	oldStart, oldEnd := s.occurrenceTimes(event, fromDate, nil)
+	s.notifyChange(&EventChange{
+		Event:       event,
+		Occurrences: []OccurrenceChange{{Date: fromDate, Future: true, OldStart: oldStart, OldEnd: oldEnd}},
+	})
+	return nil
+}
+
+// notifyChange tells opted-in PICs about a saved change; failures are logged because the change itself succeeded
+func (s *eventService) notifyChange(change *EventChange) {
+	if s.changeNotifier == nil || change.IsEmpty() {
+		return
+	}
+	if err := s.changeNotifier.NotifyEventChanged(change); err != nil {
+		log.Printf("event %s: %v", change.Event.ID, err)
+	}
+}
+
+// eventUpdateChange diffs an event before and after UpdateEvent
+func eventUpdateChange(before, after *entity.Event) *EventChange {
+	change := &EventChange{Event: before}
+	change.AddField("Title", before.Title, after.Title)
+	change.AddField("Description", before.Description, after.Description)
+	change.AddField("Type", before.Type, after.Type)
+	change.AddField("Location", before.EventLocation, after.EventLocation)
+	change.AddField("Banner image", before.BannerImage, after.BannerImage)
+	change.AddField("Capacity", fmt.Sprint(before.Capacity), fmt.Sprint(after.Capacity))
+	change.AddField("Public", fmt.Sprint(before.IsPublic), fmt.Sprint(after.IsPublic))
+	change.AddField("All day", fmt.Sprint(before.AllDay), fmt.Sprint(after.AllDay))
+	change.AddField("Timezone", before.Timezone, after.Timezone)
+
+	// A rescheduled series moves every occurrence, starting with the first one
+	if !before.StartDatetime.Equal(after.StartDatetime) || !before.EndDatetime.Equal(after.EndDatetime) {
+		change.Occurrences = append(change.Occurrences, OccurrenceChange{
+			Date:     before.EventDate,
+			Future:   before.RecurrenceRule != nil,
+			OldStart: before.StartDatetime,
+			OldEnd:   before.EndDatetime,
+			NewStart: &after.StartDatetime,
+			NewEnd:   &after.EndDatetime,
+		})
+	}
+	return change
+}
+
+// venueBookingChanged reports whether an update moves the event to another venue or other times
+func venueBookingChanged(before, after *entity.Event) bool {
+	if before.VenueID == nil || *before.VenueID != *after.VenueID {
+		return true
+	}
+	return !before.StartDatetime.Equal(after.StartDatetime) ||
+		!before.EndDatetime.Equal(after.EndDatetime) ||
+		before.Timezone != after.Timezone ||
+		before.AllDay != after.AllDay
+}
+
+// occurrenceTimes returns the wall-clock start and end of the occurrence on date, honouring overridden times
+func (s *eventService) occurrenceTimes(event *entity.Event, date time.Time, exception *entity.RecurrenceException) (time.Time, time.Time) {
+	if exception != nil && exception.OverrideStart != nil && exception.OverrideEnd != nil {
+		return *exception.OverrideStart, *exception.OverrideEnd
+	}
+	start := s.recurrenceGenerator.ScheduledStartOn(event, date).Truncate(time.Minute)
+	return start, start.Add(event.EndDatetime.Sub(event.StartDatetime))
 }
 
 // Helper function to convert slice to JSON string
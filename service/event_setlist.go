@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+// defaultRecentLaguWeeks is how far back the recent songs list looks when no period is given
+const defaultRecentLaguWeeks = 6
+
+func (s *laguService) GetEventSetlist(eventID uuid.UUID, req *dto.EventSetlistFilterRequest) (*dto.EventSetlistResponse, error) {
+	_, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.laguRepo.GetOccurrenceSetlist(eventID, occurrenceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setlist: %w", err)
+	}
+
+	response := &dto.EventSetlistResponse{
+		EventID:        eventID,
+		OccurrenceDate: occurrenceDate.Format("2006-01-02"),
+		Items:          make([]dto.SetlistItemResponse, len(items)),
+	}
+	for i := range items {
+		response.Items[i] = *setlistItemToResponse(&items[i])
+	}
+	return response, nil
+}
+
+// SetEventSetlist replaces the setlist of one occurrence. A song may appear more than once, for a reprise.
+func (s *laguService) SetEventSetlist(eventID uuid.UUID, req *dto.SetEventSetlistRequest) (*dto.EventSetlistResponse, error) {
+	_, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var laguIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, item := range req.Items {
+		if !seen[item.LaguID] {
+			seen[item.LaguID] = true
+			laguIDs = append(laguIDs, item.LaguID)
+		}
+	}
+	if len(laguIDs) > 0 {
+		songs, err := s.laguRepo.GetByIDs(laguIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get songs: %w", err)
+		}
+		if len(songs) != len(laguIDs) {
+			return nil, fmt.Errorf("song not found: %s", missingID(laguIDs, songs))
+		}
+	}
+
+	checked := make(map[uuid.UUID]bool)
+	items := make([]entity.EventSetlistItem, len(req.Items))
+	for i, item := range req.Items {
+		if item.SongLeaderID != nil && !checked[*item.SongLeaderID] {
+			if _, err := s.personRepo.GetByID(context.Background(), *item.SongLeaderID); err != nil {
+				return nil, fmt.Errorf("song leader not found: %s", *item.SongLeaderID)
+			}
+			checked[*item.SongLeaderID] = true
+		}
+
+		performedKey, err := normalizeOptionalKey(item.PerformedKey)
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = entity.EventSetlistItem{
+			LaguID:       item.LaguID,
+			PerformedKey: performedKey,
+			SongLeaderID: item.SongLeaderID,
+			Notes:        item.Notes,
+		}
+	}
+
+	if err := s.laguRepo.ReplaceOccurrenceSetlist(eventID, occurrenceDate, items); err != nil {
+		return nil, fmt.Errorf("failed to save setlist: %w", err)
+	}
+
+	return s.GetEventSetlist(eventID, &dto.EventSetlistFilterRequest{OccurrenceDate: req.OccurrenceDate})
+}
+
+// GetLaguHistory answers "when did we last sing this song", listing the times it was sung, most recent first
+func (s *laguService) GetLaguHistory(laguID uuid.UUID, req *dto.LaguHistoryRequest) (*dto.LaguHistoryResponse, error) {
+	lagu, err := s.laguRepo.GetByID(laguID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song: %w", err)
+	}
+
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	churchID, err := parseChurchFilter(req.ChurchID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := repository.SetlistHistoryFilters{ChurchID: churchID, To: currentDate()}
+	if req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date format: %w", err)
+		}
+		filters.From = &startDate
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date format: %w", err)
+		}
+		filters.To = endDate
+	}
+	if filters.From != nil && filters.To.Before(*filters.From) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	// The most recent time is the first row of the first page
+	latest, total, err := s.laguRepo.GetSongHistory(laguID, repository.SetlistHistoryFilters{
+		ChurchID: filters.ChurchID, From: filters.From, To: filters.To, Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song history: %w", err)
+	}
+
+	filters.Limit = req.Limit
+	filters.Offset = (req.Page - 1) * req.Limit
+	items, _, err := s.laguRepo.GetSongHistory(laguID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song history: %w", err)
+	}
+
+	response := &dto.LaguHistoryResponse{
+		LaguID:    lagu.ID,
+		Judul:     lagu.Judul,
+		TimesSung: int(total),
+		Usage:     make([]dto.LaguUsageResponse, len(items)),
+		Page:      req.Page,
+		Limit:     req.Limit,
+	}
+	if len(latest) > 0 {
+		lastSungOn := latest[0].OccurrenceDate.Format("2006-01-02")
+		response.LastSungOn = &lastSungOn
+	}
+	for i := range items {
+		item := &items[i]
+		response.Usage[i] = dto.LaguUsageResponse{
+			EventID:        item.EventID,
+			EventTitle:     item.Event.Title,
+			OccurrenceDate: item.OccurrenceDate.Format("2006-01-02"),
+			Position:       item.Position,
+			PerformedKey:   performedKey(item.PerformedKey, lagu.NadaDasar),
+			SongLeader:     songLeaderSummary(item.SongLeader),
+			Notes:          item.Notes,
+		}
+	}
+	return response, nil
+}
+
+// GetRecentLagu lists the songs sung in the last weeks up to today, most sung first
+func (s *laguService) GetRecentLagu(req *dto.RecentLaguRequest) (*dto.RecentLaguListResponse, error) {
+	churchID, err := parseChurchFilter(req.ChurchID)
+	if err != nil {
+		return nil, err
+	}
+
+	weeks := req.Weeks
+	if weeks <= 0 {
+		weeks = defaultRecentLaguWeeks
+	}
+
+	endDate := currentDate()
+	startDate := endDate.AddDate(0, 0, -7*weeks+1)
+
+	usage, err := s.laguRepo.GetSongUsage(repository.SongUsageFilters{
+		ChurchID: churchID,
+		From:     startDate,
+		To:       endDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song usage: %w", err)
+	}
+
+	response := &dto.RecentLaguListResponse{
+		ChurchID:  churchID,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Lagu:      make([]dto.RecentLaguResponse, len(usage)),
+	}
+	for i, song := range usage {
+		response.Lagu[i] = dto.RecentLaguResponse{
+			LaguID:     song.LaguID,
+			Judul:      song.Judul,
+			Artis:      song.Artis,
+			TimesSung:  song.TimesUsed,
+			LastSungOn: song.LastUsed.Format("2006-01-02"),
+		}
+	}
+	return response, nil
+}
+
+func parseChurchFilter(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	churchID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid church ID: %w", err)
+	}
+	return &churchID, nil
+}
+
+// currentDate is the current date, as stored in occurrence date columns
+func currentDate() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func missingID(ids []uuid.UUID, songs []entity.Lagu) uuid.UUID {
+	found := make(map[uuid.UUID]bool, len(songs))
+	for _, lagu := range songs {
+		found[lagu.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return id
+		}
+	}
+	return uuid.Nil
+}
+
+// performedKey is the key a song is sung in, its own key unless another was set
+func performedKey(key, nadaDasar string) string {
+	if key != "" {
+		return key
+	}
+	return nadaDasar
+}
+
+func songLeaderSummary(person *entity.Person) *dto.PersonSummary {
+	if person == nil {
+		return nil
+	}
+	return &dto.PersonSummary{
+		ID:           person.ID,
+		Nama:         person.Nama,
+		Email:        person.Email,
+		NomorTelepon: person.NomorTelepon,
+		ChurchID:     person.ChurchID,
+	}
+}
+
+func setlistItemToResponse(item *entity.EventSetlistItem) *dto.SetlistItemResponse {
+	return &dto.SetlistItemResponse{
+		ID:           item.ID,
+		Position:     item.Position,
+		LaguID:       item.LaguID,
+		Judul:        item.Lagu.Judul,
+		Artis:        item.Lagu.Artis,
+		NadaDasar:    item.Lagu.NadaDasar,
+		PerformedKey: performedKey(item.PerformedKey, item.Lagu.NadaDasar),
+		SongLeader:   songLeaderSummary(item.SongLeader),
+		Notes:        item.Notes,
+	}
+}
@@ -0,0 +1,991 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"gorm.io/gorm"
+)
+
+// maxTemplateSpanDays bounds how many days of a series a template keeps setlists and run-sheets for
+const maxTemplateSpanDays = 366
+
+type EventTemplateService interface {
+	CreateTemplate(req *dto.CreateEventTemplateRequest) (*dto.EventTemplateResponse, error)
+	SaveEventAsTemplate(eventID uuid.UUID, req *dto.SaveEventTemplateRequest) (*dto.EventTemplateResponse, error)
+	GetTemplate(id uuid.UUID) (*dto.EventTemplateResponse, error)
+	ListTemplates(req *dto.EventTemplateFilterRequest) (*dto.EventTemplateListResponse, error)
+	UpdateTemplate(id uuid.UUID, req *dto.UpdateEventTemplateRequest) (*dto.EventTemplateResponse, error)
+	DeleteTemplate(id uuid.UUID) error
+
+	// Creating events
+	InstantiateTemplate(id uuid.UUID, req *dto.InstantiateEventTemplateRequest) (*dto.InstantiateEventTemplateResponse, error)
+	CloneEvent(eventID uuid.UUID, req *dto.CloneEventRequest) (*dto.InstantiateEventTemplateResponse, error)
+}
+
+type eventTemplateService struct {
+	templateRepo        repository.EventTemplateRepository
+	eventRepo           repository.EventRepository
+	eventPICRepo        repository.EventPICRepository
+	rundownRepo         repository.EventRundownRepository
+	personRepo          repository.PersonRepository
+	venueRepo           repository.VenueRepository
+	eventService        EventService
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+func NewEventTemplateService(
+	templateRepo repository.EventTemplateRepository,
+	eventRepo repository.EventRepository,
+	eventPICRepo repository.EventPICRepository,
+	rundownRepo repository.EventRundownRepository,
+	personRepo repository.PersonRepository,
+	venueRepo repository.VenueRepository,
+	eventService EventService,
+) EventTemplateService {
+	return &eventTemplateService{
+		templateRepo:        templateRepo,
+		eventRepo:           eventRepo,
+		eventPICRepo:        eventPICRepo,
+		rundownRepo:         rundownRepo,
+		personRepo:          personRepo,
+		venueRepo:           venueRepo,
+		eventService:        eventService,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+func (s *eventTemplateService) CreateTemplate(req *dto.CreateEventTemplateRequest) (*dto.EventTemplateResponse, error) {
+	template := &entity.EventTemplate{
+		Name:          req.Name,
+		Title:         req.Title,
+		BannerImage:   req.BannerImage,
+		Description:   req.Description,
+		Capacity:      req.Capacity,
+		Type:          req.Type,
+		EventLocation: req.EventLocation,
+		VenueID:       req.VenueID,
+		ChurchID:      req.ChurchID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		AllDay:        req.AllDay,
+		Timezone:      req.Timezone,
+		IsPublic:      req.IsPublic,
+		RRule:         req.RRule,
+		UntilOffset:   req.UntilOffset,
+	}
+	if req.ExpectedParticipants != nil {
+		template.ExpectedParticipants = *req.ExpectedParticipants
+	}
+	if req.ExpectedAdults != nil {
+		template.ExpectedAdults = *req.ExpectedAdults
+	}
+	if req.ExpectedYouth != nil {
+		template.ExpectedYouth = *req.ExpectedYouth
+	}
+	if req.ExpectedKids != nil {
+		template.ExpectedKids = *req.ExpectedKids
+	}
+	if template.Capacity == 0 {
+		template.Capacity = 99999
+	}
+
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+	pics, err := s.templatePICs(req.PICs)
+	if err != nil {
+		return nil, err
+	}
+	template.PICs = pics
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("failed to create event template: %w", err)
+	}
+	return s.GetTemplate(template.ID)
+}
+
+// SaveEventAsTemplate saves an event with its PICs, setlists and run-sheets as a template
+func (s *eventTemplateService) SaveEventAsTemplate(eventID uuid.UUID, req *dto.SaveEventTemplateRequest) (*dto.EventTemplateResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	template, err := s.captureEvent(event, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+	template.Name = req.Name
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("failed to create event template: %w", err)
+	}
+	return s.GetTemplate(template.ID)
+}
+
+func (s *eventTemplateService) GetTemplate(id uuid.UUID) (*dto.EventTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return templateToResponse(template), nil
+}
+
+func (s *eventTemplateService) ListTemplates(req *dto.EventTemplateFilterRequest) (*dto.EventTemplateListResponse, error) {
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	templates, total, err := s.templateRepo.List(repository.EventTemplateFilters{
+		Type:     req.Type,
+		Search:   req.Search,
+		ChurchID: req.ChurchID,
+		Viewer:   req.Viewer,
+		Limit:    req.Limit,
+		Offset:   (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event templates: %w", err)
+	}
+
+	responses := make([]dto.EventTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *templateToResponse(&templates[i])
+	}
+
+	return &dto.EventTemplateListResponse{
+		Templates:  responses,
+		TotalCount: int(total),
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+func (s *eventTemplateService) UpdateTemplate(id uuid.UUID, req *dto.UpdateEventTemplateRequest) (*dto.EventTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Title != nil {
+		template.Title = *req.Title
+	}
+	if req.BannerImage != nil {
+		template.BannerImage = *req.BannerImage
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Capacity != nil {
+		template.Capacity = *req.Capacity
+	}
+	if req.Type != nil {
+		template.Type = *req.Type
+	}
+	if req.EventLocation != nil {
+		template.EventLocation = *req.EventLocation
+	}
+	if req.VenueID != nil {
+		if *req.VenueID == uuid.Nil {
+			template.VenueID = nil
+		} else {
+			template.VenueID = req.VenueID
+		}
+		template.Venue = nil
+	}
+	if req.ChurchID != nil {
+		if *req.ChurchID == uuid.Nil {
+			template.ChurchID = nil
+		} else {
+			template.ChurchID = req.ChurchID
+		}
+	}
+	if req.StartTime != nil {
+		template.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		template.EndTime = *req.EndTime
+	}
+	if req.AllDay != nil {
+		template.AllDay = *req.AllDay
+	}
+	if req.Timezone != nil {
+		template.Timezone = *req.Timezone
+	}
+	if req.IsPublic != nil {
+		template.IsPublic = *req.IsPublic
+	}
+	if req.ExpectedParticipants != nil {
+		template.ExpectedParticipants = *req.ExpectedParticipants
+	}
+	if req.ExpectedAdults != nil {
+		template.ExpectedAdults = *req.ExpectedAdults
+	}
+	if req.ExpectedYouth != nil {
+		template.ExpectedYouth = *req.ExpectedYouth
+	}
+	if req.ExpectedKids != nil {
+		template.ExpectedKids = *req.ExpectedKids
+	}
+	if req.RRule != nil {
+		template.RRule = *req.RRule
+		if template.RRule == "" {
+			template.UntilOffset = nil
+		}
+	}
+	if req.UntilOffset != nil {
+		if *req.UntilOffset < 0 {
+			template.UntilOffset = nil
+		} else {
+			template.UntilOffset = req.UntilOffset
+		}
+	}
+
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	var pics []entity.EventTemplatePIC
+	if req.PICs != nil {
+		pics, err = s.templatePICs(*req.PICs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, fmt.Errorf("failed to update event template: %w", err)
+	}
+	if req.PICs != nil {
+		if err := s.templateRepo.ReplacePICs(template.ID, pics); err != nil {
+			return nil, fmt.Errorf("failed to update event template PICs: %w", err)
+		}
+	}
+	return s.GetTemplate(template.ID)
+}
+
+func (s *eventTemplateService) DeleteTemplate(id uuid.UUID) error {
+	if _, err := s.templateRepo.GetByID(id); err != nil {
+		return fmt.Errorf("failed to get event template: %w", err)
+	}
+	if err := s.templateRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete event template: %w", err)
+	}
+	return nil
+}
+
+func (s *eventTemplateService) InstantiateTemplate(id uuid.UUID, req *dto.InstantiateEventTemplateRequest) (*dto.InstantiateEventTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return s.instantiate(template, req)
+}
+
+// CloneEvent copies an event to new dates without keeping a template of it
+func (s *eventTemplateService) CloneEvent(eventID uuid.UUID, req *dto.CloneEventRequest) (*dto.InstantiateEventTemplateResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	template, err := s.captureEvent(event, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+	return s.instantiate(template, &req.InstantiateEventTemplateRequest)
+}
+
+// captureEvent builds an unsaved template of an event. Everything dated is kept relative to the first
+// date: the event's own, or occurrenceDate when only that occurrence's setlist and run-sheet are kept.
+func (s *eventTemplateService) captureEvent(event *entity.Event, occurrenceDate string) (*entity.EventTemplate, error) {
+	firstDate := calendarDate(event.EventDate)
+	from, to := firstDate, firstDate
+	if occurrenceDate != "" {
+		_, date, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, event.ID, occurrenceDate)
+		if err != nil {
+			return nil, err
+		}
+		from, to = date, date
+	} else {
+		lastDate, err := s.lastOccurrenceDate(event)
+		if err != nil {
+			return nil, err
+		}
+		to = lastDate
+	}
+
+	template := &entity.EventTemplate{
+		SourceEventID:        &event.ID,
+		Title:                event.Title,
+		BannerImage:          event.BannerImage,
+		Description:          event.Description,
+		Capacity:             event.Capacity,
+		Type:                 event.Type,
+		EventLocation:        event.EventLocation,
+		VenueID:              event.VenueID,
+		ChurchID:             event.ChurchID,
+		StartTime:            event.StartDatetime.Format("15:04"),
+		EndTime:              event.EndDatetime.Format("15:04"),
+		AllDay:               event.AllDay,
+		Timezone:             event.Timezone,
+		IsPublic:             event.IsPublic,
+		ExpectedParticipants: event.ExpectedParticipants,
+		ExpectedAdults:       event.ExpectedAdults,
+		ExpectedYouth:        event.ExpectedYouth,
+		ExpectedKids:         event.ExpectedKids,
+	}
+
+	// Extra dates belong to the calendar of this series, so a template keeps only the rule
+	if event.RecurrenceRule != nil {
+		rule := *event.RecurrenceRule
+		if rule.Until != nil {
+			untilOffset := daysBetween(from, calendarDate(*rule.Until))
+			template.UntilOffset = &untilOffset
+			rule.Until = nil
+			rule.Count = nil
+		}
+		template.RRule = s.recurrenceGenerator.FormatRRule(&rule, recurrenceLocation(event.Timezone))
+	}
+
+	pics, err := s.capturePICs(event, from)
+	if err != nil {
+		return nil, err
+	}
+	template.PICs = pics
+
+	items, err := s.templateRepo.GetSetlistItemsInRange(event.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setlists: %w", err)
+	}
+	for _, item := range items {
+		template.SetlistItems = append(template.SetlistItems, entity.EventTemplateSetlistItem{
+			DayOffset:    daysBetween(from, item.OccurrenceDate),
+			Position:     item.Position,
+			LaguID:       item.LaguID,
+			PerformedKey: item.PerformedKey,
+			SongLeaderID: item.SongLeaderID,
+			Notes:        item.Notes,
+		})
+	}
+
+	segments, err := s.templateRepo.GetRundownSegmentsInRange(event.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheets: %w", err)
+	}
+	for _, segment := range segments {
+		dayOffset := daysBetween(from, segment.OccurrenceDate)
+		template.RundownSegments = append(template.RundownSegments, entity.EventTemplateRundownSegment{
+			DayOffset:   &dayOffset,
+			Position:    segment.Position,
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			PersonID:    segment.PersonID,
+			Notes:       segment.Notes,
+		})
+	}
+
+	rundownTemplate, err := s.rundownRepo.GetTemplateSegments(event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run-sheet template: %w", err)
+	}
+	for _, segment := range rundownTemplate {
+		template.RundownSegments = append(template.RundownSegments, entity.EventTemplateRundownSegment{
+			Position:    segment.Position,
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			PersonID:    segment.PersonID,
+			Notes:       segment.Notes,
+		})
+	}
+
+	return template, nil
+}
+
+// lastOccurrenceDate is the date of the last occurrence of a series with an end, within
+// maxTemplateSpanDays of its start. Single events and open-ended series end on their first date.
+func (s *eventTemplateService) lastOccurrenceDate(event *entity.Event) (time.Time, error) {
+	firstDate := calendarDate(event.EventDate)
+	rule := event.RecurrenceRule
+	if rule == nil || (rule.Until == nil && rule.Count == nil) {
+		return firstDate, nil
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(firstDate.Year(), firstDate.Month(), firstDate.Day(), 0, 0, 0, 0, loc)
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeStart.AddDate(0, 0, maxTemplateSpanDays))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	lastDate := firstDate
+	for _, occurrence := range occurrences {
+		if occurrence.Date.After(lastDate) {
+			lastDate = occurrence.Date
+		}
+	}
+	return lastDate, nil
+}
+
+// capturePICs keeps the PIC assignments still active on from, with their dates relative to it. When a
+// later occurrence is saved, assignments made before it start on that occurrence instead.
+func (s *eventTemplateService) capturePICs(event *entity.Event, from time.Time) ([]entity.EventTemplatePIC, error) {
+	firstDate := calendarDate(event.EventDate)
+	roleIDs := make(map[string]*uuid.UUID)
+
+	var pics []entity.EventTemplatePIC
+	for _, pic := range event.EventPICs {
+		if !pic.IsActive || (pic.EndDate != nil && calendarDate(*pic.EndDate).Before(from)) {
+			continue
+		}
+
+		roleID, ok := roleIDs[pic.Role]
+		if !ok {
+			role, err := s.eventPICRepo.GetRoleByName(pic.Role)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to get PIC role: %w", err)
+			}
+			if role != nil {
+				roleID = &role.ID
+			}
+			roleIDs[pic.Role] = roleID
+		}
+
+		startOffset := daysBetween(from, calendarDate(pic.StartDate))
+		if from.After(firstDate) && startOffset < 0 {
+			startOffset = 0
+		}
+		var endOffset *int
+		if pic.EndDate != nil {
+			offset := daysBetween(from, calendarDate(*pic.EndDate))
+			endOffset = &offset
+		}
+
+		personID := pic.PersonID
+		pics = append(pics, entity.EventTemplatePIC{
+			RoleID:            roleID,
+			Role:              pic.Role,
+			Description:       pic.Description,
+			PersonID:          &personID,
+			IsPrimary:         pic.IsPrimary,
+			StartOffset:       startOffset,
+			EndOffset:         endOffset,
+			CanEdit:           pic.CanEdit,
+			CanDelete:         pic.CanDelete,
+			CanAssignPIC:      pic.CanAssignPIC,
+			NotifyOnChanges:   pic.NotifyOnChanges,
+			NotifyOnReminders: pic.NotifyOnReminders,
+		})
+	}
+	return pics, nil
+}
+
+// instantiate creates an event from a template starting on req.StartDate, then gives it the template's
+// PICs, setlists and run-sheets on the same days relative to its start
+func (s *eventTemplateService) instantiate(template *entity.EventTemplate, req *dto.InstantiateEventTemplateRequest) (*dto.InstantiateEventTemplateResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	startTime, endTime := template.StartTime, template.EndTime
+	if req.StartTime != "" {
+		newStart, err := time.Parse("15:04", req.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time format: %w", err)
+		}
+		oldStart, err := time.Parse("15:04", template.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template start time: %w", err)
+		}
+		oldEnd, err := time.Parse("15:04", template.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template end time: %w", err)
+		}
+		newEnd := newStart.Add(oldEnd.Sub(oldStart))
+		if newEnd.Day() != newStart.Day() {
+			return nil, fmt.Errorf("event starting at %s would end after midnight", req.StartTime)
+		}
+		startTime, endTime = req.StartTime, newEnd.Format("15:04")
+	}
+
+	createReq := &dto.CreateEventRequest{
+		Title:                template.Title,
+		BannerImage:          template.BannerImage,
+		Description:          template.Description,
+		Capacity:             template.Capacity,
+		Type:                 template.Type,
+		EventDate:            req.StartDate,
+		EventLocation:        template.EventLocation,
+		StartTime:            startTime,
+		EndTime:              endTime,
+		AllDay:               template.AllDay,
+		Timezone:             template.Timezone,
+		VenueID:              template.VenueID,
+		AllowVenueConflict:   req.AllowVenueConflict,
+		ChurchID:             template.ChurchID,
+		IsPublic:             template.IsPublic,
+		ExpectedParticipants: &template.ExpectedParticipants,
+		ExpectedAdults:       &template.ExpectedAdults,
+		ExpectedYouth:        &template.ExpectedYouth,
+		ExpectedKids:         &template.ExpectedKids,
+	}
+	if req.Title != "" {
+		createReq.Title = req.Title
+	}
+	if req.VenueID != nil {
+		createReq.VenueID = req.VenueID
+	}
+	if createReq.ChurchID == nil && createReq.VenueID == nil {
+		createReq.ChurchID = req.ChurchID
+	}
+	if template.RRule != "" {
+		rule, err := s.recurrenceGenerator.ParseRRule(template.RRule, recurrenceLocation(template.Timezone))
+		if err != nil {
+			return nil, err
+		}
+		if template.UntilOffset != nil {
+			until := startDate.AddDate(0, 0, *template.UntilOffset).Format("2006-01-02")
+			rule.Until = &until
+			rule.Count = nil
+		}
+		createReq.RecurrenceRule = rule
+	}
+
+	pics, unfilledRoles, err := s.assignPICs(template, req.PICs, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.eventService.CreateEvent(createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetByID(created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	content, skipped, err := s.eventContent(template, event, startDate)
+	if err == nil {
+		for i := range pics {
+			pics[i].EventID = event.ID
+		}
+		content.PICs = pics
+		if err = s.templateRepo.CreateEventContent(content); err != nil {
+			err = fmt.Errorf("failed to copy the template into the event: %w", err)
+		}
+	}
+	if err != nil {
+		// Leave no half-copied event behind
+		if deleteErr := s.eventService.DeleteEvent(event.ID); deleteErr != nil {
+			return nil, fmt.Errorf("%w (and the event could not be removed: %v)", err, deleteErr)
+		}
+		return nil, err
+	}
+
+	response, err := s.eventService.GetEvent(event.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.InstantiateEventTemplateResponse{
+		Event:         response,
+		UnfilledRoles: unfilledRoles,
+		Skipped:       skipped,
+	}, nil
+}
+
+// assignPICs gives every PIC role of the template the person assigned in the request, or its default
+// person, and lists the roles left without anyone
+func (s *eventTemplateService) assignPICs(template *entity.EventTemplate, assignments []dto.TemplatePICAssignment, startDate time.Time) ([]entity.EventPIC, []string, error) {
+	roles := make(map[string]bool, len(template.PICs))
+	for _, pic := range template.PICs {
+		roles[pic.Role] = true
+	}
+
+	assigned := make(map[string]uuid.UUID, len(assignments))
+	for _, assignment := range assignments {
+		if !roles[assignment.Role] {
+			return nil, nil, fmt.Errorf("template has no PIC role %q", assignment.Role)
+		}
+		assigned[assignment.Role] = assignment.PersonID
+	}
+
+	checked := make(map[uuid.UUID]bool)
+	pics := []entity.EventPIC{}
+	unfilledRoles := []string{}
+	for _, pic := range template.PICs {
+		personID, ok := assigned[pic.Role]
+		if !ok {
+			if pic.PersonID == nil {
+				unfilledRoles = append(unfilledRoles, pic.Role)
+				continue
+			}
+			personID = *pic.PersonID
+		}
+		if !checked[personID] {
+			if _, err := s.personRepo.GetByID(context.Background(), personID); err != nil {
+				return nil, nil, fmt.Errorf("person not found: %s", personID)
+			}
+			checked[personID] = true
+		}
+
+		var endDate *time.Time
+		if pic.EndOffset != nil {
+			date := startDate.AddDate(0, 0, *pic.EndOffset)
+			endDate = &date
+		}
+		pics = append(pics, entity.EventPIC{
+			PersonID:          personID,
+			Role:              pic.Role,
+			Description:       pic.Description,
+			IsActive:          true,
+			IsPrimary:         pic.IsPrimary,
+			StartDate:         startDate.AddDate(0, 0, pic.StartOffset),
+			EndDate:           endDate,
+			CanEdit:           pic.CanEdit,
+			CanDelete:         pic.CanDelete,
+			CanAssignPIC:      pic.CanAssignPIC,
+			NotifyOnChanges:   pic.NotifyOnChanges,
+			NotifyOnReminders: pic.NotifyOnReminders,
+		})
+	}
+	return pics, unfilledRoles, nil
+}
+
+// eventContent places the template's setlists and run-sheets on the occurrences of a new event. Days the
+// event does not occur on are skipped.
+func (s *eventTemplateService) eventContent(template *entity.EventTemplate, event *entity.Event, startDate time.Time) (*repository.EventTemplateContent, []dto.SkippedOccurrence, error) {
+	span := 0
+	for _, item := range template.SetlistItems {
+		if item.DayOffset > span {
+			span = item.DayOffset
+		}
+	}
+	for _, segment := range template.RundownSegments {
+		if segment.DayOffset != nil && *segment.DayOffset > span {
+			span = *segment.DayOffset
+		}
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeStart.AddDate(0, 0, span+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	occurs := make(map[string]bool, len(occurrences))
+	for _, occurrence := range occurrences {
+		occurs[occurrence.Date.Format("2006-01-02")] = true
+	}
+
+	skippedDays := make(map[string]bool)
+	onOccurrence := func(dayOffset int) (time.Time, bool) {
+		date := startDate.AddDate(0, 0, dayOffset)
+		key := date.Format("2006-01-02")
+		if occurs[key] {
+			return date, true
+		}
+		skippedDays[key] = true
+		return date, false
+	}
+
+	content := &repository.EventTemplateContent{}
+	for _, item := range template.SetlistItems {
+		date, ok := onOccurrence(item.DayOffset)
+		if !ok {
+			continue
+		}
+		content.SetlistItems = append(content.SetlistItems, entity.EventSetlistItem{
+			EventID:        event.ID,
+			OccurrenceDate: date,
+			Position:       item.Position,
+			LaguID:         item.LaguID,
+			PerformedKey:   item.PerformedKey,
+			SongLeaderID:   item.SongLeaderID,
+			Notes:          item.Notes,
+		})
+	}
+	for _, segment := range template.RundownSegments {
+		if segment.DayOffset == nil {
+			content.RundownTemplate = append(content.RundownTemplate, entity.EventRundownTemplateSegment{
+				EventID:     event.ID,
+				Position:    segment.Position,
+				Title:       segment.Title,
+				Type:        segment.Type,
+				StartOffset: segment.StartOffset,
+				Duration:    segment.Duration,
+				PersonID:    segment.PersonID,
+				Notes:       segment.Notes,
+			})
+			continue
+		}
+
+		date, ok := onOccurrence(*segment.DayOffset)
+		if !ok {
+			continue
+		}
+		content.RundownSegments = append(content.RundownSegments, entity.EventRundownSegment{
+			EventID:        event.ID,
+			OccurrenceDate: date,
+			Position:       segment.Position,
+			Title:          segment.Title,
+			Type:           segment.Type,
+			StartOffset:    segment.StartOffset,
+			Duration:       segment.Duration,
+			PersonID:       segment.PersonID,
+			Notes:          segment.Notes,
+		})
+	}
+
+	days := make([]string, 0, len(skippedDays))
+	for day := range skippedDays {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	skipped := make([]dto.SkippedOccurrence, len(days))
+	for i, day := range days {
+		skipped[i] = dto.SkippedOccurrence{
+			OccurrenceDate: day,
+			Reason:         "the new event does not occur on this day; its setlist and run-sheet were not copied",
+		}
+	}
+	return content, skipped, nil
+}
+
+// validateTemplate checks the times, timezone, recurrence and venue of a template before it is saved
+func (s *eventTemplateService) validateTemplate(template *entity.EventTemplate) error {
+	startTime, err := time.Parse("15:04", template.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid start time format: %w", err)
+	}
+	endTime, err := time.Parse("15:04", template.EndTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time format: %w", err)
+	}
+	if endTime.Before(startTime) {
+		return fmt.Errorf("end time must not be before start time")
+	}
+
+	loc, err := time.LoadLocation(template.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	if template.RRule != "" {
+		rule, err := s.recurrenceGenerator.ParseRRule(template.RRule, loc)
+		if err != nil {
+			return err
+		}
+		if rule.Until != nil {
+			return fmt.Errorf("a template's series ends untilOffset days after its first date; remove UNTIL from the RRULE")
+		}
+		if template.UntilOffset != nil && rule.Count != nil {
+			return fmt.Errorf("a series cannot have both COUNT and an end date")
+		}
+	} else if template.UntilOffset != nil {
+		return fmt.Errorf("untilOffset needs a recurrence rule")
+	}
+
+	if template.VenueID != nil {
+		if _, err := s.venueRepo.GetByID(*template.VenueID); err != nil {
+			return fmt.Errorf("venue not found: %w", err)
+		}
+	} else if template.EventLocation == "" {
+		return fmt.Errorf("event location or venue is required")
+	}
+	if template.ChurchID != nil {
+		count, err := s.eventRepo.CountChurches([]uuid.UUID{*template.ChurchID})
+		if err != nil {
+			return fmt.Errorf("failed to check church: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("church not found")
+		}
+	}
+	return nil
+}
+
+// templatePICs resolves the PIC roles of a template request; permissions left out come from the
+// predefined role, and a template has at most one primary PIC
+func (s *eventTemplateService) templatePICs(requests []dto.EventTemplatePICRequest) ([]entity.EventTemplatePIC, error) {
+	pics := make([]entity.EventTemplatePIC, 0, len(requests))
+	seen := make(map[string]bool)
+	primaries := 0
+	for _, req := range requests {
+		var role *entity.EventPICRole
+		var err error
+		if req.RoleID != nil {
+			role, err = s.eventPICRepo.GetRoleByID(*req.RoleID)
+			if err != nil {
+				return nil, fmt.Errorf("PIC role not found: %w", err)
+			}
+		} else {
+			role, err = s.eventPICRepo.GetRoleByName(req.Role)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to get PIC role: %w", err)
+			}
+		}
+
+		pic := entity.EventTemplatePIC{
+			Role:              req.Role,
+			Description:       req.Description,
+			PersonID:          req.PersonID,
+			IsPrimary:         req.IsPrimary,
+			StartOffset:       req.StartOffset,
+			EndOffset:         req.EndOffset,
+			NotifyOnChanges:   true,
+			NotifyOnReminders: true,
+		}
+		if role != nil {
+			pic.RoleID = &role.ID
+			pic.Role = role.Name
+			pic.CanEdit = role.DefaultCanEdit
+			pic.CanDelete = role.DefaultCanDelete
+			pic.CanAssignPIC = role.DefaultCanAssignPIC
+			if pic.Description == "" {
+				pic.Description = role.Description
+			}
+		}
+		if req.CanEdit != nil {
+			pic.CanEdit = *req.CanEdit
+		}
+		if req.CanDelete != nil {
+			pic.CanDelete = *req.CanDelete
+		}
+		if req.CanAssignPIC != nil {
+			pic.CanAssignPIC = *req.CanAssignPIC
+		}
+		if req.NotifyOnChanges != nil {
+			pic.NotifyOnChanges = *req.NotifyOnChanges
+		}
+		if req.NotifyOnReminders != nil {
+			pic.NotifyOnReminders = *req.NotifyOnReminders
+		}
+
+		if seen[pic.Role] {
+			return nil, fmt.Errorf("PIC role %q is listed more than once", pic.Role)
+		}
+		seen[pic.Role] = true
+		if pic.IsPrimary {
+			primaries++
+		}
+		if pic.EndOffset != nil && *pic.EndOffset < pic.StartOffset {
+			return nil, fmt.Errorf("PIC role %q ends before it starts", pic.Role)
+		}
+		if req.PersonID != nil {
+			if _, err := s.personRepo.GetByID(context.Background(), *req.PersonID); err != nil {
+				return nil, fmt.Errorf("person not found: %s", *req.PersonID)
+			}
+		}
+		pics = append(pics, pic)
+	}
+	if primaries > 1 {
+		return nil, fmt.Errorf("cannot assign multiple primary PICs")
+	}
+	return pics, nil
+}
+
+// calendarDate drops the time of day of a stored date
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts the calendar days from one date to another
+func daysBetween(from, to time.Time) int {
+	return int(calendarDate(to).Sub(calendarDate(from)).Hours() / 24)
+}
+
+func templateToResponse(template *entity.EventTemplate) *dto.EventTemplateResponse {
+	response := &dto.EventTemplateResponse{
+		ID:                   template.ID,
+		Name:                 template.Name,
+		SourceEventID:        template.SourceEventID,
+		Title:                template.Title,
+		BannerImage:          template.BannerImage,
+		Description:          template.Description,
+		Capacity:             template.Capacity,
+		Type:                 template.Type,
+		EventLocation:        template.EventLocation,
+		VenueID:              template.VenueID,
+		ChurchID:             template.ChurchID,
+		StartTime:            template.StartTime,
+		EndTime:              template.EndTime,
+		AllDay:               template.AllDay,
+		Timezone:             template.Timezone,
+		IsPublic:             template.IsPublic,
+		ExpectedParticipants: template.ExpectedParticipants,
+		ExpectedAdults:       template.ExpectedAdults,
+		ExpectedYouth:        template.ExpectedYouth,
+		ExpectedKids:         template.ExpectedKids,
+		RRule:                template.RRule,
+		UntilOffset:          template.UntilOffset,
+		CreatedAt:            template.CreatedAt,
+		UpdatedAt:            template.UpdatedAt,
+	}
+	if template.Venue != nil {
+		response.VenueName = template.Venue.Name
+	}
+
+	for _, pic := range template.PICs {
+		response.PICs = append(response.PICs, dto.EventTemplatePICResponse{
+			ID:                pic.ID,
+			RoleID:            pic.RoleID,
+			Role:              pic.Role,
+			Description:       pic.Description,
+			Person:            rundownPersonSummary(pic.Person),
+			IsPrimary:         pic.IsPrimary,
+			StartOffset:       pic.StartOffset,
+			EndOffset:         pic.EndOffset,
+			CanEdit:           pic.CanEdit,
+			CanDelete:         pic.CanDelete,
+			CanAssignPIC:      pic.CanAssignPIC,
+			NotifyOnChanges:   pic.NotifyOnChanges,
+			NotifyOnReminders: pic.NotifyOnReminders,
+		})
+	}
+	for _, item := range template.SetlistItems {
+		response.SetlistItems = append(response.SetlistItems, dto.EventTemplateSetlistItemResponse{
+			DayOffset:    item.DayOffset,
+			Position:     item.Position,
+			LaguID:       item.LaguID,
+			Judul:        item.Lagu.Judul,
+			Artis:        item.Lagu.Artis,
+			PerformedKey: performedKey(item.PerformedKey, item.Lagu.NadaDasar),
+			SongLeader:   songLeaderSummary(item.SongLeader),
+			Notes:        item.Notes,
+		})
+	}
+	for _, segment := range template.RundownSegments {
+		response.RundownSegments = append(response.RundownSegments, dto.EventTemplateRundownSegmentResponse{
+			DayOffset:   segment.DayOffset,
+			Position:    segment.Position,
+			Title:       segment.Title,
+			Type:        segment.Type,
+			StartOffset: segment.StartOffset,
+			Duration:    segment.Duration,
+			Person:      rundownPersonSummary(segment.Person),
+			Notes:       segment.Notes,
+		})
+	}
+	return response
+}
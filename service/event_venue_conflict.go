@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+// VenueConflictError is returned when an event would overlap other bookings of its venue
+type VenueConflictError struct {
+	Conflicts []dto.VenueConflictResponse
+}
+
+func (e *VenueConflictError) Error() string {
+	return fmt.Sprintf("venue is already booked: %d conflicting occurrence(s)", len(e.Conflicts))
+}
+
+// checkVenueBooking validates the venue of an event and, unless allowConflict is set, rejects the
+// event with a VenueConflictError when any of its upcoming occurrences overlaps another event there
+func (s *eventService) checkVenueBooking(event *entity.Event, allowConflict bool) error {
+	venue, err := s.venueRepo.GetByID(*event.VenueID)
+	if err != nil {
+		return fmt.Errorf("venue not found: %w", err)
+	}
+	if !venue.IsActive {
+		return fmt.Errorf("venue %s is not active", venue.Name)
+	}
+	if event.EventLocation == "" {
+		event.EventLocation = venue.Name
+	}
+	if allowConflict {
+		return nil
+	}
+
+	conflicts, err := s.findVenueConflicts(event, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &VenueConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// findVenueConflicts expands the event and every other event booked into its venue, from today up to the
+// occurrence index horizon, and returns the other events' occurrences that overlap one of the event's
+func (s *eventService) findVenueConflicts(event *entity.Event, now time.Time) ([]dto.VenueConflictResponse, error) {
+	loc := recurrenceLocation(event.Timezone)
+	today := now.In(loc)
+	windowStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+	windowEnd := now.AddDate(OccurrenceIndexYears, 0, 0)
+
+	occurrences, err := s.expandOccurrences(event, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	first := occurrences[0].Start
+	last := occurrences[0].End
+	for _, occurrence := range occurrences {
+		if occurrence.End.After(last) {
+			last = occurrence.End
+		}
+	}
+
+	others, err := s.venueRepo.GetEventsByVenueID(*event.VenueID, first.AddDate(0, 0, -1), last.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue bookings: %w", err)
+	}
+
+	var conflicts []dto.VenueConflictResponse
+	for i := range others {
+		other := &others[i]
+		if other.ID == event.ID {
+			continue
+		}
+
+		// Start early enough to catch an occurrence that began before the window and is still running
+		duration := other.EndDatetime.Sub(other.StartDatetime)
+		otherOccurrences, err := s.expandOccurrences(other, first.Add(-duration), last)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, theirs := range otherOccurrences {
+			for _, ours := range occurrences {
+				if ours.Start.Before(theirs.End) && theirs.Start.Before(ours.End) {
+					conflicts = append(conflicts, dto.VenueConflictResponse{
+						EventID:        other.ID,
+						EventTitle:     other.Title,
+						OccurrenceDate: theirs.Date.Format("2006-01-02"),
+						StartDatetime:  theirs.Start,
+						EndDatetime:    theirs.End,
+						ConflictsWith:  ours.Date.Format("2006-01-02"),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].StartDatetime.Before(conflicts[j].StartDatetime) })
+	return conflicts, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// GetLaguChart returns the chord chart of a song, transposed from NadaDasar to req.Key when given.
+// Songs without a separate chart use their lyrics, which may carry ChordPro chords themselves.
+func (s *laguService) GetLaguChart(id uuid.UUID, req *dto.LaguChartRequest) (*dto.LaguChartResponse, error) {
+	lagu, err := s.laguRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song: %w", err)
+	}
+	return transposeLaguChart(lagu, req)
+}
+
+// ExportChordPro writes a song as a ChordPro file, in req.Key when given
+func (s *laguService) ExportChordPro(id uuid.UUID, req *dto.LaguChartRequest) ([]byte, error) {
+	lagu, err := s.laguRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song: %w", err)
+	}
+
+	chart, err := transposeLaguChart(lagu, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The song's own fields replace whatever metadata directives the stored chart has
+	song, err := utils.ParseChordPro(chart.Chart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart: %w", err)
+	}
+	song.Title = lagu.Judul
+	song.Artist = lagu.Artis
+	song.Key = chart.Key
+	song.Year = lagu.TahunRilis
+
+	return []byte(utils.FormatChordPro(song)), nil
+}
+
+// ImportChordPro creates a song from a ChordPro file. The title, artist, key and year directives
+// fill the song's fields and the lyrics are taken from the chart.
+func (s *laguService) ImportChordPro(data []byte, req *dto.ImportChordProRequest) (*dto.LaguDetailResponse, error) {
+	song, err := utils.ParseChordPro(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ChordPro file: %w", err)
+	}
+	if song.Title == "" {
+		return nil, fmt.Errorf("invalid ChordPro file: no title directive")
+	}
+
+	return s.CreateLagu(&dto.CreateLaguRequest{
+		Judul:       song.Title,
+		Artis:       song.Artist,
+		YoutubeLink: req.YoutubeLink,
+		Genre:       req.Genre,
+		Chart:       song.Body,
+		NadaDasar:   song.Key,
+		TahunRilis:  song.Year,
+	})
+}
+
+// GetLaguLyrics renders a song as plain lyrics for projection, without chords or directives
+func (s *laguService) GetLaguLyrics(id uuid.UUID) (string, error) {
+	lagu, err := s.laguRepo.GetByID(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get song: %w", err)
+	}
+
+	if lagu.Chart != "" {
+		return utils.ChordProLyrics(lagu.Chart), nil
+	}
+	return utils.ChordProLyrics(lagu.Lirik), nil
+}
+
+func transposeLaguChart(lagu *entity.Lagu, req *dto.LaguChartRequest) (*dto.LaguChartResponse, error) {
+	source := lagu.Chart
+	if source == "" {
+		source = lagu.Lirik
+	}
+
+	originalKey := lagu.NadaDasar
+	if originalKey == "" {
+		key, err := normalizeOptionalKey(req.From)
+		if err != nil {
+			return nil, err
+		}
+		originalKey = key
+	}
+
+	response := &dto.LaguChartResponse{
+		LaguID:      lagu.ID,
+		Judul:       lagu.Judul,
+		OriginalKey: originalKey,
+		Key:         originalKey,
+		Accidentals: "sharp",
+		Chart:       source,
+	}
+	if utils.KeyUsesFlats(originalKey) {
+		response.Accidentals = "flat"
+	}
+	if req.Key == "" {
+		return response, nil
+	}
+
+	if originalKey == "" {
+		return nil, fmt.Errorf("song has no key to transpose from, give the from key")
+	}
+	key, err := utils.NormalizeKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	semitones, err := utils.KeyInterval(originalKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	flats := utils.KeyUsesFlats(key)
+	if req.Accidentals != "" {
+		flats = req.Accidentals == "flat"
+	}
+
+	response.Key = key
+	response.Semitones = semitones
+	response.Accidentals = "sharp"
+	if flats {
+		response.Accidentals = "flat"
+	}
+	response.Chart = utils.TransposeChordPro(source, semitones, flats)
+	return response, nil
+}
+
+// normalizeOptionalKey checks a key that may be left empty
+func normalizeOptionalKey(key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	return utils.NormalizeKey(key)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+// Song usage report periods
+const (
+	songUsagePeriodAll     = "all"
+	songUsagePeriodMonth   = "month"
+	songUsagePeriodQuarter = "quarter"
+	songUsagePeriodYear    = "year"
+)
+
+// GetSongUsageReport counts, per church and period, the occurrences each song was sung at, for
+// licensing reports. Setlists are kept per occurrence, so every occurrence of a recurring event
+// counts on its own; cancelled occurrences do not count.
+func (s *laguService) GetSongUsageReport(req *dto.SongUsageReportRequest) (*dto.SongUsageReportResponse, error) {
+	churchID, err := parseChurchFilter(req.ChurchID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format: %w", err)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	period := req.Period
+	if period == "" {
+		period = songUsagePeriodAll
+	}
+
+	usage, err := s.laguRepo.GetSongUsageOccurrences(repository.SongUsageFilters{
+		ChurchID: churchID,
+		From:     startDate,
+		To:       endDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song usage: %w", err)
+	}
+
+	// Occurrences come ordered by date, so the dates of every row stay in order
+	rows := make(map[string]*dto.SongUsageReportRow)
+	var keys []string
+	for _, use := range usage {
+		periodStart, periodEnd := songUsagePeriod(use.OccurrenceDate, period, startDate, endDate)
+		church := ""
+		if use.ChurchID != nil {
+			church = use.ChurchID.String()
+		}
+		key := church + "/" + periodStart.Format("2006-01-02") + "/" + use.LaguID.String()
+
+		row, ok := rows[key]
+		if !ok {
+			row = &dto.SongUsageReportRow{
+				ChurchID:    use.ChurchID,
+				ChurchName:  use.ChurchName,
+				PeriodStart: periodStart.Format("2006-01-02"),
+				PeriodEnd:   periodEnd.Format("2006-01-02"),
+				LaguID:      use.LaguID,
+				Judul:       use.Judul,
+				Artis:       use.Artis,
+				FirstUsedOn: use.OccurrenceDate.Format("2006-01-02"),
+				Dates:       []string{},
+			}
+			rows[key] = row
+			keys = append(keys, key)
+		}
+		row.TimesUsed++
+		row.LastUsedOn = use.OccurrenceDate.Format("2006-01-02")
+		row.Dates = append(row.Dates, row.LastUsedOn)
+	}
+
+	response := &dto.SongUsageReportResponse{
+		ChurchID:  churchID,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Period:    period,
+		Rows:      make([]dto.SongUsageReportRow, len(keys)),
+	}
+	for i, key := range keys {
+		response.Rows[i] = *rows[key]
+	}
+	sort.SliceStable(response.Rows, func(i, j int) bool {
+		a, b := &response.Rows[i], &response.Rows[j]
+		if a.ChurchName != b.ChurchName {
+			// Events of no church come last
+			if a.ChurchID == nil || b.ChurchID == nil {
+				return b.ChurchID == nil
+			}
+			return a.ChurchName < b.ChurchName
+		}
+		if a.PeriodStart != b.PeriodStart {
+			return a.PeriodStart < b.PeriodStart
+		}
+		if a.TimesUsed != b.TimesUsed {
+			return a.TimesUsed > b.TimesUsed
+		}
+		return a.Judul < b.Judul
+	})
+	return response, nil
+}
+
+// ExportSongUsageReport renders the song usage report as CSV, one line per church, period and song
+func (s *laguService) ExportSongUsageReport(req *dto.SongUsageReportRequest) ([]byte, error) {
+	report, err := s.GetSongUsageReport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"Church", "Period Start", "Period End", "Title", "Artist", "Times Used", "First Used", "Last Used", "Dates"})
+	for _, row := range report.Rows {
+		w.Write([]string{
+			row.ChurchName,
+			row.PeriodStart,
+			row.PeriodEnd,
+			row.Judul,
+			row.Artis,
+			strconv.Itoa(row.TimesUsed),
+			row.FirstUsedOn,
+			row.LastUsedOn,
+			strings.Join(row.Dates, " "),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// songUsagePeriod returns the first and last day of the period holding date, kept within the report's dates
+func songUsagePeriod(date time.Time, period string, startDate, endDate time.Time) (time.Time, time.Time) {
+	var first, last time.Time
+	switch period {
+	case songUsagePeriodMonth:
+		first = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(0, 1, -1)
+	case songUsagePeriodQuarter:
+		month := time.Month((int(date.Month())-1)/3*3 + 1)
+		first = time.Date(date.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(0, 3, -1)
+	case songUsagePeriodYear:
+		first = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(1, 0, -1)
+	default:
+		return startDate, endDate
+	}
+
+	if first.Before(startDate) {
+		first = startDate
+	}
+	if last.After(endDate) {
+		last = endDate
+	}
+	return first, last
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+type LaguService interface {
+	// Songs
+	CreateLagu(req *dto.CreateLaguRequest) (*dto.LaguDetailResponse, error)
+	GetLagu(id uuid.UUID) (*dto.LaguDetailResponse, error)
+	UpdateLagu(id uuid.UUID, req *dto.UpdateLaguRequest) (*dto.LaguDetailResponse, error)
+	DeleteLagu(id uuid.UUID) error
+	ListLagu(req *dto.LaguFilterRequest) (*dto.LaguListResponse, error)
+
+	// Chord charts
+	GetLaguChart(id uuid.UUID, req *dto.LaguChartRequest) (*dto.LaguChartResponse, error)
+	ExportChordPro(id uuid.UUID, req *dto.LaguChartRequest) ([]byte, error)
+	ImportChordPro(data []byte, req *dto.ImportChordProRequest) (*dto.LaguDetailResponse, error)
+	GetLaguLyrics(id uuid.UUID) (string, error)
+
+	// Tags
+	CreateTagLagu(req *dto.CreateTagLaguRequest) (*dto.TagLaguResponse, error)
+	GetTagLagu(id uuid.UUID) (*dto.TagLaguResponse, error)
+	UpdateTagLagu(id uuid.UUID, req *dto.UpdateTagLaguRequest) (*dto.TagLaguResponse, error)
+	DeleteTagLagu(id uuid.UUID) error
+	ListTagLagu(req *dto.TagLaguFilterRequest) (*dto.TagLaguListResponse, error)
+
+	// Occurrence setlists
+	GetEventSetlist(eventID uuid.UUID, req *dto.EventSetlistFilterRequest) (*dto.EventSetlistResponse, error)
+	SetEventSetlist(eventID uuid.UUID, req *dto.SetEventSetlistRequest) (*dto.EventSetlistResponse, error)
+	GetLaguHistory(laguID uuid.UUID, req *dto.LaguHistoryRequest) (*dto.LaguHistoryResponse, error)
+	GetRecentLagu(req *dto.RecentLaguRequest) (*dto.RecentLaguListResponse, error)
+	ExportEventSetlist(eventID uuid.UUID, req *dto.SetlistExportRequest) ([]byte, error)
+
+	// Usage reports
+	GetSongUsageReport(req *dto.SongUsageReportRequest) (*dto.SongUsageReportResponse, error)
+	ExportSongUsageReport(req *dto.SongUsageReportRequest) ([]byte, error)
+}
+
+type laguService struct {
+	laguRepo            repository.LaguRepository
+	eventRepo           repository.EventRepository
+	personRepo          repository.PersonRepository
+	recurrenceGenerator *RecurrenceGenerator
+}
+
+func NewLaguService(laguRepo repository.LaguRepository, eventRepo repository.EventRepository, personRepo repository.PersonRepository) LaguService {
+	return &laguService{
+		laguRepo:            laguRepo,
+		eventRepo:           eventRepo,
+		personRepo:          personRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+	}
+}
+
+// Songs
+func (s *laguService) CreateLagu(req *dto.CreateLaguRequest) (*dto.LaguDetailResponse, error) {
+	tags, err := s.resolveTags(req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	nadaDasar, err := normalizeOptionalKey(req.NadaDasar)
+	if err != nil {
+		return nil, err
+	}
+
+	lagu := &entity.Lagu{
+		Judul:       req.Judul,
+		Artis:       req.Artis,
+		YoutubeLink: req.YoutubeLink,
+		Genre:       req.Genre,
+		Lirik:       req.Lirik,
+		Chart:       req.Chart,
+		NadaDasar:   nadaDasar,
+		TahunRilis:  req.TahunRilis,
+		TagLagu:     tags,
+	}
+	if lagu.Lirik == "" {
+		lagu.Lirik = utils.ChordProLyrics(lagu.Chart)
+	}
+	if strings.TrimSpace(lagu.Lirik) == "" {
+		return nil, fmt.Errorf("lyrics must not be empty")
+	}
+	lagu.SearchText = utils.FoldSearchText(lagu.SearchableText())
+
+	if err := s.laguRepo.Create(lagu); err != nil {
+		return nil, fmt.Errorf("failed to create song: %w", err)
+	}
+
+	return s.GetLagu(lagu.ID)
+}
+
+func (s *laguService) GetLagu(id uuid.UUID) (*dto.LaguDetailResponse, error) {
+	lagu, err := s.laguRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song: %w", err)
+	}
+	return laguToResponse(lagu, true), nil
+}
+
+func (s *laguService) UpdateLagu(id uuid.UUID, req *dto.UpdateLaguRequest) (*dto.LaguDetailResponse, error) {
+	lagu, err := s.laguRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song: %w", err)
+	}
+
+	if req.Judul != nil {
+		lagu.Judul = *req.Judul
+	}
+	if req.Artis != nil {
+		lagu.Artis = *req.Artis
+	}
+	if req.YoutubeLink != nil {
+		lagu.YoutubeLink = *req.YoutubeLink
+	}
+	if req.Genre != nil {
+		lagu.Genre = *req.Genre
+	}
+	if req.Chart != nil {
+		lagu.Chart = *req.Chart
+		if req.Lirik == nil && lagu.Chart != "" {
+			lagu.Lirik = utils.ChordProLyrics(lagu.Chart)
+		}
+	}
+	if req.Lirik != nil {
+		lagu.Lirik = *req.Lirik
+	}
+	if strings.TrimSpace(lagu.Lirik) == "" {
+		return nil, fmt.Errorf("lyrics must not be empty")
+	}
+	if req.NadaDasar != nil {
+		nadaDasar, err := normalizeOptionalKey(*req.NadaDasar)
+		if err != nil {
+			return nil, err
+		}
+		lagu.NadaDasar = nadaDasar
+	}
+	if req.TahunRilis != nil {
+		lagu.TahunRilis = *req.TahunRilis
+	}
+	if req.TagIDs != nil {
+		tags, err := s.resolveTags(*req.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.laguRepo.ReplaceTags(lagu, tags); err != nil {
+			return nil, fmt.Errorf("failed to update song tags: %w", err)
+		}
+		lagu.TagLagu = tags
+	}
+	lagu.SearchText = utils.FoldSearchText(lagu.SearchableText())
+
+	if err := s.laguRepo.Update(lagu); err != nil {
+		return nil, fmt.Errorf("failed to update song: %w", err)
+	}
+
+	return s.GetLagu(lagu.ID)
+}
+
+func (s *laguService) DeleteLagu(id uuid.UUID) error {
+	if _, err := s.laguRepo.GetByID(id); err != nil {
+		return fmt.Errorf("failed to get song: %w", err)
+	}
+	if err := s.laguRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete song: %w", err)
+	}
+	return nil
+}
+
+func (s *laguService) ListLagu(req *dto.LaguFilterRequest) (*dto.LaguListResponse, error) {
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	filters := repository.LaguFilters{
+		Search: utils.FoldSearchText(req.Search),
+		Genre:  req.Genre,
+		Limit:  req.Limit,
+		Offset: (req.Page - 1) * req.Limit,
+	}
+	if req.TagID != "" {
+		tagID, err := uuid.Parse(req.TagID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag ID: %w", err)
+		}
+		filters.TagID = &tagID
+	}
+
+	songs, total, err := s.laguRepo.List(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list songs: %w", err)
+	}
+
+	// Lyrics and charts are left out of lists to keep pages small
+	responses := make([]dto.LaguDetailResponse, len(songs))
+	for i := range songs {
+		responses[i] = *laguToResponse(&songs[i], false)
+	}
+
+	return &dto.LaguListResponse{
+		Lagu:       responses,
+		TotalCount: int(total),
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+// Tags
+func (s *laguService) CreateTagLagu(req *dto.CreateTagLaguRequest) (*dto.TagLaguResponse, error) {
+	if _, err := s.laguRepo.GetTagByName(req.Nama); err == nil {
+		return nil, fmt.Errorf("tag %q already exists", req.Nama)
+	}
+
+	tag := &entity.TagLagu{Nama: req.Nama}
+	if err := s.laguRepo.CreateTag(tag); err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return tagLaguToResponse(tag), nil
+}
+
+func (s *laguService) GetTagLagu(id uuid.UUID) (*dto.TagLaguResponse, error) {
+	tag, err := s.laguRepo.GetTagByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return tagLaguToResponse(tag), nil
+}
+
+func (s *laguService) UpdateTagLagu(id uuid.UUID, req *dto.UpdateTagLaguRequest) (*dto.TagLaguResponse, error) {
+	tag, err := s.laguRepo.GetTagByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	if existing, err := s.laguRepo.GetTagByName(req.Nama); err == nil && existing.ID != id {
+		return nil, fmt.Errorf("tag %q already exists", req.Nama)
+	}
+	tag.Nama = req.Nama
+
+	if err := s.laguRepo.UpdateTag(tag); err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	s.refreshSearchText(tag.ID)
+	return tagLaguToResponse(tag), nil
+}
+
+func (s *laguService) DeleteTagLagu(id uuid.UUID) error {
+	if _, err := s.laguRepo.GetTagByID(id); err != nil {
+		return fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	// The songs are looked up before the tag is taken off them
+	songs, err := s.laguRepo.GetByTagID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get songs with tag: %w", err)
+	}
+
+	if err := s.laguRepo.DeleteTag(id); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	for i := range songs {
+		lagu := &songs[i]
+		remaining := lagu.TagLagu[:0]
+		for _, tag := range lagu.TagLagu {
+			if tag.ID != id {
+				remaining = append(remaining, tag)
+			}
+		}
+		lagu.TagLagu = remaining
+		s.saveSearchText(lagu)
+	}
+	return nil
+}
+
+func (s *laguService) ListTagLagu(req *dto.TagLaguFilterRequest) (*dto.TagLaguListResponse, error) {
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	tags, total, err := s.laguRepo.ListTags(repository.TagLaguFilters{
+		Search: req.Search,
+		Limit:  req.Limit,
+		Offset: (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	responses := make([]dto.TagLaguResponse, len(tags))
+	for i := range tags {
+		responses[i] = *tagLaguToResponse(&tags[i])
+	}
+
+	return &dto.TagLaguListResponse{
+		Tags:       responses,
+		TotalCount: int(total),
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+// resolveTags loads the tags to put on a song and fails on an unknown ID
+func (s *laguService) resolveTags(tagIDs []uuid.UUID) ([]entity.TagLagu, error) {
+	if len(tagIDs) == 0 {
+		return []entity.TagLagu{}, nil
+	}
+
+	tags, err := s.laguRepo.GetTagsByIDs(tagIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	for _, tagID := range tagIDs {
+		found := false
+		for _, tag := range tags {
+			if tag.ID == tagID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("tag not found: %s", tagID)
+		}
+	}
+	return tags, nil
+}
+
+// refreshSearchText recomputes the search text of every song carrying a renamed tag
+func (s *laguService) refreshSearchText(tagID uuid.UUID) {
+	songs, err := s.laguRepo.GetByTagID(tagID)
+	if err != nil {
+		log.Printf("song search: tag %s: %v", tagID, err)
+		return
+	}
+	for i := range songs {
+		s.saveSearchText(&songs[i])
+	}
+}
+
+// saveSearchText stores a song's recomputed search text. A failure is only logged: the change that
+// caused it is already saved, and the next edit of the song repairs it.
+func (s *laguService) saveSearchText(lagu *entity.Lagu) {
+	if err := s.laguRepo.UpdateSearchText(lagu.ID, utils.FoldSearchText(lagu.SearchableText())); err != nil {
+		log.Printf("song search: song %s: %v", lagu.ID, err)
+	}
+}
+
+func laguToResponse(lagu *entity.Lagu, withLirik bool) *dto.LaguDetailResponse {
+	tags := make([]dto.TagLaguResponse, len(lagu.TagLagu))
+	for i := range lagu.TagLagu {
+		tags[i] = *tagLaguToResponse(&lagu.TagLagu[i])
+	}
+
+	response := &dto.LaguDetailResponse{
+		ID:          lagu.ID,
+		Judul:       lagu.Judul,
+		Artis:       lagu.Artis,
+		YoutubeLink: lagu.YoutubeLink,
+		Genre:       lagu.Genre,
+		NadaDasar:   lagu.NadaDasar,
+		TahunRilis:  lagu.TahunRilis,
+		Tags:        tags,
+		CreatedAt:   lagu.CreatedAt,
+		UpdatedAt:   lagu.UpdatedAt,
+	}
+	if withLirik {
+		response.Lirik = lagu.Lirik
+		response.Chart = lagu.Chart
+	}
+	return response
+}
+
+func tagLaguToResponse(tag *entity.TagLagu) *dto.TagLaguResponse {
+	return &dto.TagLaguResponse{
+		ID:   tag.ID,
+		Nama: tag.Nama,
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+// rosterLookbackWeeks is how far back earlier roster places count toward a musician's load
+const rosterLookbackWeeks = 4
+
+// GenerateJadwal fills the instruments the event needs at every occurrence between the dates from the
+// musicians who said they are available. Places set by hand are kept and count toward what is needed;
+// occurrences whose roster is already published are skipped. Load is balanced across weeks: a musician
+// already playing that week, at this or any other event, comes after one who is not, then musicians
+// with fewer places over the last weeks come first. Those who said "mungkin" are only used when
+// nobody available is left.
+func (s *pemusikService) GenerateJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) (*dto.GenerateJadwalPemusikResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, endDate, err := parsePemusikRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	kebutuhan, err := s.pemusikRepo.GetKebutuhan(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get musician requirements: %w", err)
+	}
+	if len(kebutuhan) == 0 {
+		return nil, fmt.Errorf("event has no musician requirements")
+	}
+
+	loc := recurrenceLocation(event.Timezone)
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	occurrences, err := expandEventOccurrences(s.eventRepo, s.recurrenceGenerator, event, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	ketersediaan, err := s.pemusikRepo.GetKetersediaan(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	existing, err := s.pemusikRepo.GetJadwal(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+
+	availableByDate := make(map[string][]entity.KetersediaanPemusik)
+	var personIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, k := range ketersediaan {
+		if k.Ketersediaan == entity.KetersediaanTidakTersedia {
+			continue
+		}
+		key := k.OccurrenceDate.Format("2006-01-02")
+		availableByDate[key] = append(availableByDate[key], k)
+		if !seen[k.PersonID] {
+			seen[k.PersonID] = true
+			personIDs = append(personIDs, k.PersonID)
+		}
+	}
+
+	existingByDate := make(map[string][]entity.JadwalPemusik)
+	for _, place := range existing {
+		key := place.OccurrenceDate.Format("2006-01-02")
+		existingByDate[key] = append(existingByDate[key], place)
+	}
+
+	history, err := s.pemusikRepo.GetJadwalByPersonIDs(personIDs, startDate.AddDate(0, 0, -7*rosterLookbackWeeks), endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get musician load: %w", err)
+	}
+	load := newRosterLoad()
+	for _, place := range history {
+		// Generated drafts of this event in the range are about to be replaced
+		if place.EventId == eventID && isReplaceable(&place) && !place.OccurrenceDate.Before(startDate) {
+			continue
+		}
+		load.add(place.PersonID, place.OccurrenceDate)
+	}
+
+	response := &dto.GenerateJadwalPemusikResponse{
+		EventID:  eventID,
+		Unfilled: []dto.UnfilledAlatMusik{},
+		Skipped:  []dto.SkippedOccurrence{},
+	}
+
+	for _, occurrence := range occurrences {
+		date := occurrence.Date
+		key := date.Format("2006-01-02")
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+		places := existingByDate[key]
+		if rosterPublished(places) {
+			response.Skipped = append(response.Skipped, dto.SkippedOccurrence{OccurrenceDate: key, Reason: "roster already published"})
+			continue
+		}
+
+		assigned := make(map[uuid.UUID]bool)
+		need := make(map[string]int)
+		for _, k := range kebutuhan {
+			need[strings.ToLower(k.AlatMusik)] = k.Jumlah
+		}
+		for _, place := range places {
+			if isReplaceable(&place) {
+				continue
+			}
+			assigned[place.PersonID] = true
+			need[strings.ToLower(place.AlatMusik)]--
+		}
+
+		candidates := availableByDate[key]
+
+		// Scarce instruments go first, so a musician who plays several is kept for the one few others play
+		ordered := make([]entity.KebutuhanPemusik, len(kebutuhan))
+		copy(ordered, kebutuhan)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return countPlayers(candidates, ordered[i].AlatMusik) < countPlayers(candidates, ordered[j].AlatMusik)
+		})
+
+		var generated []entity.JadwalPemusik
+		for _, k := range ordered {
+			for missing := need[strings.ToLower(k.AlatMusik)]; missing > 0; missing-- {
+				pick := pickMusician(candidates, k.AlatMusik, assigned, load, date)
+				if pick == nil {
+					response.Unfilled = append(response.Unfilled, dto.UnfilledAlatMusik{OccurrenceDate: key, AlatMusik: k.AlatMusik, Missing: missing})
+					break
+				}
+
+				assigned[pick.PersonID] = true
+				load.add(pick.PersonID, date)
+				generated = append(generated, entity.JadwalPemusik{
+					EventId:        eventID,
+					OccurrenceDate: date,
+					PersonID:       pick.PersonID,
+					AlatMusik:      k.AlatMusik,
+					Status:         entity.JadwalPemusikStatusDraft,
+				})
+			}
+		}
+
+		if err := s.pemusikRepo.ReplaceGeneratedJadwal(eventID, date, generated); err != nil {
+			return nil, fmt.Errorf("failed to save roster for %s: %w", key, err)
+		}
+		if err := s.pemusikRepo.SyncTerjadwal(eventID, date); err != nil {
+			return nil, fmt.Errorf("failed to save roster for %s: %w", key, err)
+		}
+	}
+
+	jadwal, err := s.pemusikRepo.GetJadwal(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+	response.Jadwal = jadwalToResponses(jadwal)
+	return response, nil
+}
+
+// rosterLoad counts the roster places of musicians, in total and per ISO week
+type rosterLoad struct {
+	total  map[uuid.UUID]int
+	weekly map[string]int
+}
+
+func newRosterLoad() *rosterLoad {
+	return &rosterLoad{total: make(map[uuid.UUID]int), weekly: make(map[string]int)}
+}
+
+func (l *rosterLoad) add(personID uuid.UUID, date time.Time) {
+	l.total[personID]++
+	l.weekly[rosterWeekKey(personID, date)]++
+}
+
+func (l *rosterLoad) week(personID uuid.UUID, date time.Time) int {
+	return l.weekly[rosterWeekKey(personID, date)]
+}
+
+func rosterWeekKey(personID uuid.UUID, date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%s/%d-%d", personID, year, week)
+}
+
+// pickMusician returns the best musician for the instrument who is not on the roster yet, or nil
+func pickMusician(candidates []entity.KetersediaanPemusik, alatMusik string, assigned map[uuid.UUID]bool, load *rosterLoad, date time.Time) *entity.KetersediaanPemusik {
+	var best *entity.KetersediaanPemusik
+	for i := range candidates {
+		candidate := &candidates[i]
+		if assigned[candidate.PersonID] || !playsAlatMusik(candidate, alatMusik) {
+			continue
+		}
+		if best == nil || betterMusician(candidate, best, load, date) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+func betterMusician(a, b *entity.KetersediaanPemusik, load *rosterLoad, date time.Time) bool {
+	aMaybe := a.Ketersediaan == entity.KetersediaanMungkin
+	bMaybe := b.Ketersediaan == entity.KetersediaanMungkin
+	if aMaybe != bMaybe {
+		return !aMaybe
+	}
+	if aWeek, bWeek := load.week(a.PersonID, date), load.week(b.PersonID, date); aWeek != bWeek {
+		return aWeek < bWeek
+	}
+	if aTotal, bTotal := load.total[a.PersonID], load.total[b.PersonID]; aTotal != bTotal {
+		return aTotal < bTotal
+	}
+	if a.Person.Nama != b.Person.Nama {
+		return a.Person.Nama < b.Person.Nama
+	}
+	return a.PersonID.String() < b.PersonID.String()
+}
+
+func playsAlatMusik(ketersediaan *entity.KetersediaanPemusik, alatMusik string) bool {
+	for _, name := range alatMusikList(ketersediaan.AlatMusik) {
+		if strings.EqualFold(strings.TrimSpace(name), alatMusik) {
+			return true
+		}
+	}
+	return false
+}
+
+func countPlayers(candidates []entity.KetersediaanPemusik, alatMusik string) int {
+	count := 0
+	for i := range candidates {
+		if playsAlatMusik(&candidates[i], alatMusik) {
+			count++
+		}
+	}
+	return count
+}
+
+// isReplaceable reports whether generating the roster again may replace the place
+func isReplaceable(place *entity.JadwalPemusik) bool {
+	return !place.IsManual && place.Status == entity.JadwalPemusikStatusDraft
+}
+
+func rosterPublished(places []entity.JadwalPemusik) bool {
+	for _, place := range places {
+		if place.Status == entity.JadwalPemusikStatusPublished {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// maxPemusikRangeDays bounds the occurrences one roster request can cover
+const maxPemusikRangeDays = 366
+
+type PemusikService interface {
+	// Instruments every occurrence needs
+	GetKebutuhan(eventID uuid.UUID) (*dto.KebutuhanPemusikResponse, error)
+	SetKebutuhan(eventID uuid.UUID, req *dto.SetKebutuhanPemusikRequest) (*dto.KebutuhanPemusikResponse, error)
+
+	// Availability
+	SubmitKetersediaan(eventID uuid.UUID, req *dto.SubmitKetersediaanRequest) (*dto.KetersediaanPemusikResponse, error)
+	GetKetersediaan(eventID uuid.UUID, req *dto.PemusikRangeRequest) ([]dto.KetersediaanPemusikResponse, error)
+	DeleteKetersediaan(id uuid.UUID) error
+
+	// Roster
+	GenerateJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) (*dto.GenerateJadwalPemusikResponse, error)
+	GetJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) ([]dto.JadwalPemusikResponse, error)
+	AddJadwal(eventID uuid.UUID, req *dto.AddJadwalPemusikRequest) (*dto.JadwalPemusikResponse, error)
+	UpdateJadwal(id uuid.UUID, req *dto.UpdateJadwalPemusikRequest) (*dto.JadwalPemusikResponse, error)
+	DeleteJadwal(id uuid.UUID) error
+	PublishJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) (*dto.PublishJadwalPemusikResponse, error)
+}
+
+type pemusikService struct {
+	pemusikRepo         repository.PemusikRepository
+	eventRepo           repository.EventRepository
+	personRepo          repository.PersonRepository
+	userRepo            repository.UserRepository
+	notificationRepo    repository.NotificationRepository
+	recurrenceGenerator *RecurrenceGenerator
+	sendMail            func(to, subject, body string) error
+}
+
+func NewPemusikService(
+	pemusikRepo repository.PemusikRepository,
+	eventRepo repository.EventRepository,
+	personRepo repository.PersonRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+) PemusikService {
+	return &pemusikService{
+		pemusikRepo:         pemusikRepo,
+		eventRepo:           eventRepo,
+		personRepo:          personRepo,
+		userRepo:            userRepo,
+		notificationRepo:    notificationRepo,
+		recurrenceGenerator: NewRecurrenceGenerator(),
+		sendMail:            utils.SendMail,
+	}
+}
+
+// Instruments every occurrence needs
+func (s *pemusikService) GetKebutuhan(eventID uuid.UUID) (*dto.KebutuhanPemusikResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	kebutuhan, err := s.pemusikRepo.GetKebutuhan(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get musician requirements: %w", err)
+	}
+
+	response := &dto.KebutuhanPemusikResponse{EventID: eventID, Items: make([]dto.KebutuhanPemusikItem, len(kebutuhan))}
+	for i, k := range kebutuhan {
+		response.Items[i] = dto.KebutuhanPemusikItem{AlatMusik: k.AlatMusik, Jumlah: k.Jumlah}
+	}
+	return response, nil
+}
+
+func (s *pemusikService) SetKebutuhan(eventID uuid.UUID, req *dto.SetKebutuhanPemusikRequest) (*dto.KebutuhanPemusikResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	kebutuhan := make([]entity.KebutuhanPemusik, 0, len(req.Items))
+	seen := make(map[string]bool)
+	for _, item := range req.Items {
+		alatMusik := strings.TrimSpace(item.AlatMusik)
+		if alatMusik == "" {
+			return nil, fmt.Errorf("instrument name must not be empty")
+		}
+		if seen[strings.ToLower(alatMusik)] {
+			return nil, fmt.Errorf("instrument %s is listed more than once", alatMusik)
+		}
+		seen[strings.ToLower(alatMusik)] = true
+		kebutuhan = append(kebutuhan, entity.KebutuhanPemusik{AlatMusik: alatMusik, Jumlah: item.Jumlah})
+	}
+
+	if err := s.pemusikRepo.ReplaceKebutuhan(eventID, kebutuhan); err != nil {
+		return nil, fmt.Errorf("failed to save musician requirements: %w", err)
+	}
+	return s.GetKebutuhan(eventID)
+}
+
+// Availability
+func (s *pemusikService) SubmitKetersediaan(eventID uuid.UUID, req *dto.SubmitKetersediaanRequest) (*dto.KetersediaanPemusikResponse, error) {
+	_, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.personRepo.GetByID(context.Background(), req.PersonID); err != nil {
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	var alatMusik []string
+	for _, name := range req.AlatMusik {
+		if name = strings.TrimSpace(name); name != "" {
+			alatMusik = append(alatMusik, name)
+		}
+	}
+	if len(alatMusik) == 0 && req.Ketersediaan != entity.KetersediaanTidakTersedia {
+		return nil, fmt.Errorf("available musicians must list the instruments they can play")
+	}
+	alatMusikJSON, err := json.Marshal(alatMusik)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode instruments: %w", err)
+	}
+
+	ketersediaan := &entity.KetersediaanPemusik{
+		EventId:        eventID,
+		OccurrenceDate: occurrenceDate,
+		PersonID:       req.PersonID,
+		Ketersediaan:   req.Ketersediaan,
+		AlatMusik:      string(alatMusikJSON),
+		Notes:          req.Notes,
+	}
+	if err := s.pemusikRepo.SaveKetersediaan(ketersediaan); err != nil {
+		return nil, fmt.Errorf("failed to save availability: %w", err)
+	}
+	if err := s.pemusikRepo.SyncTerjadwal(eventID, occurrenceDate); err != nil {
+		return nil, fmt.Errorf("failed to save availability: %w", err)
+	}
+
+	saved, err := s.pemusikRepo.GetKetersediaanByID(ketersediaan.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	return ketersediaanToResponse(saved), nil
+}
+
+func (s *pemusikService) GetKetersediaan(eventID uuid.UUID, req *dto.PemusikRangeRequest) ([]dto.KetersediaanPemusikResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, endDate, err := parsePemusikRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ketersediaan, err := s.pemusikRepo.GetKetersediaan(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+
+	responses := make([]dto.KetersediaanPemusikResponse, len(ketersediaan))
+	for i := range ketersediaan {
+		responses[i] = *ketersediaanToResponse(&ketersediaan[i])
+	}
+	return responses, nil
+}
+
+func (s *pemusikService) DeleteKetersediaan(id uuid.UUID) error {
+	if _, err := s.pemusikRepo.GetKetersediaanByID(id); err != nil {
+		return fmt.Errorf("failed to get availability: %w", err)
+	}
+	if err := s.pemusikRepo.DeleteKetersediaan(id); err != nil {
+		return fmt.Errorf("failed to delete availability: %w", err)
+	}
+	return nil
+}
+
+// Roster
+func (s *pemusikService) GetJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) ([]dto.JadwalPemusikResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, endDate, err := parsePemusikRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	jadwal, err := s.pemusikRepo.GetJadwal(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+	return jadwalToResponses(jadwal), nil
+}
+
+// AddJadwal puts a musician on the roster by hand. On a roster that is already published the place
+// is published at once and the musician is told.
+func (s *pemusikService) AddJadwal(eventID uuid.UUID, req *dto.AddJadwalPemusikRequest) (*dto.JadwalPemusikResponse, error) {
+	event, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.personRepo.GetByID(context.Background(), req.PersonID); err != nil {
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	existing, err := s.pemusikRepo.GetJadwal(eventID, occurrenceDate, occurrenceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+	status := entity.JadwalPemusikStatusDraft
+	for _, jadwal := range existing {
+		if jadwal.PersonID == req.PersonID {
+			return nil, fmt.Errorf("person is already on the roster for %s", req.OccurrenceDate)
+		}
+		if jadwal.Status == entity.JadwalPemusikStatusPublished {
+			status = entity.JadwalPemusikStatusPublished
+		}
+	}
+
+	jadwal := &entity.JadwalPemusik{
+		EventId:        eventID,
+		OccurrenceDate: occurrenceDate,
+		PersonID:       req.PersonID,
+		AlatMusik:      strings.TrimSpace(req.AlatMusik),
+		Status:         status,
+		IsManual:       true,
+		Notes:          req.Notes,
+	}
+	if status == entity.JadwalPemusikStatusPublished {
+		now := time.Now()
+		jadwal.PublishedAt = &now
+	}
+	if err := s.pemusikRepo.CreateJadwal(jadwal); err != nil {
+		return nil, fmt.Errorf("failed to add to roster: %w", err)
+	}
+	if err := s.pemusikRepo.SyncTerjadwal(eventID, occurrenceDate); err != nil {
+		return nil, fmt.Errorf("failed to add to roster: %w", err)
+	}
+
+	saved, err := s.pemusikRepo.GetJadwalByID(jadwal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+	if status == entity.JadwalPemusikStatusPublished {
+		s.notifyMusicians(event, []entity.JadwalPemusik{*saved})
+	}
+	return jadwalToResponse(saved), nil
+}
+
+// UpdateJadwal changes a roster place by hand, so generating the roster again keeps it. A musician
+// newly put on a published roster is told.
+func (s *pemusikService) UpdateJadwal(id uuid.UUID, req *dto.UpdateJadwalPemusikRequest) (*dto.JadwalPemusikResponse, error) {
+	jadwal, err := s.pemusikRepo.GetJadwalByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster place: %w", err)
+	}
+
+	personChanged := req.PersonID != nil && *req.PersonID != jadwal.PersonID
+	if personChanged {
+		if _, err := s.personRepo.GetByID(context.Background(), *req.PersonID); err != nil {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+
+		existing, err := s.pemusikRepo.GetJadwal(jadwal.EventId, jadwal.OccurrenceDate, jadwal.OccurrenceDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get roster: %w", err)
+		}
+		for _, other := range existing {
+			if other.PersonID == *req.PersonID {
+				return nil, fmt.Errorf("person is already on the roster for %s", jadwal.OccurrenceDate.Format("2006-01-02"))
+			}
+		}
+		jadwal.PersonID = *req.PersonID
+	}
+	if req.AlatMusik != nil {
+		jadwal.AlatMusik = strings.TrimSpace(*req.AlatMusik)
+	}
+	if req.Notes != nil {
+		jadwal.Notes = *req.Notes
+	}
+	jadwal.IsManual = true
+
+	if err := s.pemusikRepo.UpdateJadwal(jadwal); err != nil {
+		return nil, fmt.Errorf("failed to update roster place: %w", err)
+	}
+	if err := s.pemusikRepo.SyncTerjadwal(jadwal.EventId, jadwal.OccurrenceDate); err != nil {
+		return nil, fmt.Errorf("failed to update roster place: %w", err)
+	}
+
+	saved, err := s.pemusikRepo.GetJadwalByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster place: %w", err)
+	}
+	if personChanged && saved.Status == entity.JadwalPemusikStatusPublished {
+		s.notifyMusicians(&saved.Event, []entity.JadwalPemusik{*saved})
+	}
+	return jadwalToResponse(saved), nil
+}
+
+func (s *pemusikService) DeleteJadwal(id uuid.UUID) error {
+	jadwal, err := s.pemusikRepo.GetJadwalByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get roster place: %w", err)
+	}
+	if err := s.pemusikRepo.DeleteJadwal(id); err != nil {
+		return fmt.Errorf("failed to delete roster place: %w", err)
+	}
+	if err := s.pemusikRepo.SyncTerjadwal(jadwal.EventId, jadwal.OccurrenceDate); err != nil {
+		return fmt.Errorf("failed to delete roster place: %w", err)
+	}
+	return nil
+}
+
+// PublishJadwal publishes the draft roster places between the dates and tells each musician once
+// about all of their new places
+func (s *pemusikService) PublishJadwal(eventID uuid.UUID, req *dto.PemusikRangeRequest) (*dto.PublishJadwalPemusikResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	startDate, endDate, err := parsePemusikRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	jadwal, err := s.pemusikRepo.GetJadwal(eventID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+
+	var drafts []entity.JadwalPemusik
+	var ids []uuid.UUID
+	for _, place := range jadwal {
+		if place.Status == entity.JadwalPemusikStatusDraft {
+			drafts = append(drafts, place)
+			ids = append(ids, place.ID)
+		}
+	}
+
+	if err := s.pemusikRepo.PublishJadwal(ids, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to publish roster: %w", err)
+	}
+
+	return &dto.PublishJadwalPemusikResponse{
+		EventID:   eventID,
+		Published: len(drafts),
+		Notified:  s.notifyMusicians(event, drafts),
+	}, nil
+}
+
+// notifyMusicians tells every musician about their places on the roster with an in-app notification
+// when they have a user account, and an email when they have an address. It returns how many
+// musicians were told; failures are only logged, as the roster itself is already saved.
+func (s *pemusikService) notifyMusicians(event *entity.Event, jadwal []entity.JadwalPemusik) int {
+	byPerson := make(map[uuid.UUID][]entity.JadwalPemusik)
+	var personIDs []uuid.UUID
+	for _, place := range jadwal {
+		if _, ok := byPerson[place.PersonID]; !ok {
+			personIDs = append(personIDs, place.PersonID)
+		}
+		byPerson[place.PersonID] = append(byPerson[place.PersonID], place)
+	}
+
+	title := fmt.Sprintf("Musician roster: %s", event.Title)
+	for _, personID := range personIDs {
+		places := byPerson[personID]
+		sort.Slice(places, func(i, j int) bool { return places[i].OccurrenceDate.Before(places[j].OccurrenceDate) })
+
+		lines := make([]string, len(places))
+		escaped := make([]string, len(places))
+		for i, place := range places {
+			lines[i] = fmt.Sprintf("%s: %s", place.OccurrenceDate.Format("Monday, 2 January 2006"), place.AlatMusik)
+			escaped[i] = "<li>" + html.EscapeString(lines[i]) + "</li>"
+		}
+
+		person := places[0].Person
+		if user, err := s.userRepo.GetByPersonID(context.Background(), personID); err == nil {
+			churchID := person.ChurchID
+			notification := &entity.Notification{
+				ID:       uuid.New(),
+				Title:    title,
+				Message:  "You are on the roster:\n" + strings.Join(lines, "\n"),
+				Type:     "info",
+				UserID:   user.ID,
+				ChurchID: &churchID,
+			}
+			if err := s.notificationRepo.Create(notification); err != nil {
+				log.Printf("musician roster: failed to notify %s: %v", personID, err)
+			}
+		}
+
+		if person.Email != "" {
+			body := fmt.Sprintf("<p>Hi %s,</p><p>You are on the musician roster of %s:</p><ul>%s</ul>",
+				html.EscapeString(person.Nama), html.EscapeString(event.Title), strings.Join(escaped, ""))
+			go func(to string) {
+				if err := s.sendMail(to, title, body); err != nil {
+					log.Printf("musician roster: failed to email %s: %v", to, err)
+				}
+			}(person.Email)
+		}
+	}
+	return len(personIDs)
+}
+
+// parsePemusikRange reads the dates of a roster request
+func parsePemusikRange(req *dto.PemusikRangeRequest) (time.Time, time.Time, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date format: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date must not be before start date")
+	}
+	if endDate.Sub(startDate) > maxPemusikRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range must not be longer than %d days", maxPemusikRangeDays)
+	}
+	return startDate, endDate, nil
+}
+
+// alatMusikList decodes the instruments of an availability
+func alatMusikList(value string) []string {
+	var alatMusik []string
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &alatMusik); err != nil {
+			return nil
+		}
+	}
+	return alatMusik
+}
+
+func pemusikPersonSummary(person *entity.Person) dto.PersonSummary {
+	return dto.PersonSummary{
+		ID:           person.ID,
+		Nama:         person.Nama,
+		Email:        person.Email,
+		NomorTelepon: person.NomorTelepon,
+		ChurchID:     person.ChurchID,
+	}
+}
+
+func ketersediaanToResponse(ketersediaan *entity.KetersediaanPemusik) *dto.KetersediaanPemusikResponse {
+	alatMusik := alatMusikList(ketersediaan.AlatMusik)
+	if alatMusik == nil {
+		alatMusik = []string{}
+	}
+	return &dto.KetersediaanPemusikResponse{
+		ID:             ketersediaan.ID,
+		EventID:        ketersediaan.EventId,
+		OccurrenceDate: ketersediaan.OccurrenceDate.Format("2006-01-02"),
+		PersonID:       ketersediaan.PersonID,
+		Person:         pemusikPersonSummary(&ketersediaan.Person),
+		Ketersediaan:   ketersediaan.Ketersediaan,
+		AlatMusik:      alatMusik,
+		IsTerjadwal:    ketersediaan.IsTerjadwal,
+		Notes:          ketersediaan.Notes,
+		CreatedAt:      ketersediaan.CreatedAt,
+		UpdatedAt:      ketersediaan.UpdatedAt,
+	}
+}
+
+func jadwalToResponse(jadwal *entity.JadwalPemusik) *dto.JadwalPemusikResponse {
+	return &dto.JadwalPemusikResponse{
+		ID:             jadwal.ID,
+		EventID:        jadwal.EventId,
+		OccurrenceDate: jadwal.OccurrenceDate.Format("2006-01-02"),
+		PersonID:       jadwal.PersonID,
+		Person:         pemusikPersonSummary(&jadwal.Person),
+		AlatMusik:      jadwal.AlatMusik,
+		Status:         jadwal.Status,
+		IsManual:       jadwal.IsManual,
+		Notes:          jadwal.Notes,
+		PublishedAt:    jadwal.PublishedAt,
+		CreatedAt:      jadwal.CreatedAt,
+		UpdatedAt:      jadwal.UpdatedAt,
+	}
+}
+
+func jadwalToResponses(jadwal []entity.JadwalPemusik) []dto.JadwalPemusikResponse {
+	responses := make([]dto.JadwalPemusikResponse, len(jadwal))
+	for i := range jadwal {
+		responses[i] = *jadwalToResponse(&jadwal[i])
+	}
+	return responses
+}
@@ -18,56 +18,61 @@ func NewRecurrenceGenerator() *RecurrenceGenerator {
 	return &RecurrenceGenerator{}
 }
 
-// GenerateOccurrences generates event occurrences for a given date range
+// GenerateOccurrences generates event occurrences for a given date range.
+// Occurrences are computed on the event's wall clock in Event.Timezone, so "every Sunday 09:00" stays at 09:00
+// local time across DST changes. The returned times are instants in the event's zone, and startDate/endDate
+// are compared as instants (both inclusive).
 func (rg *RecurrenceGenerator) GenerateOccurrences(
 	event *entity.Event,
 	rule *entity.RecurrenceRule,
 	startDate, endDate time.Time,
 	exceptions []entity.RecurrenceException,
 ) ([]time.Time, error) {
+	loc := recurrenceLocation(event.Timezone)
 	if rule == nil {
-		return []time.Time{event.StartDatetime}, nil
+		return []time.Time{wallClockIn(event.StartDatetime, loc)}, nil
 	}
 
-	var occurrences []time.Time
-	current := event.StartDatetime
+	// Candidates are generated on the UTC-labelled wall clock, which has no DST gaps or repeats,
+	// from the series start so that intervals and COUNT keep their phase
+	seriesStart := time.Date(event.StartDatetime.Year(), event.StartDatetime.Month(), event.StartDatetime.Day(),
+		event.StartDatetime.Hour(), event.StartDatetime.Minute(), event.StartDatetime.Second(), 0, time.UTC)
+	localEnd := endDate.In(loc)
+	generateUntil := time.Date(localEnd.Year(), localEnd.Month(), localEnd.Day(), 23, 59, 59, 0, time.UTC)
 
-	// Adjust current to start boundary
-	if current.Before(startDate) {
-		current = startDate
-		// Adjust to the next valid occurrence based on the rule
-		current = rg.adjustToNextValidOccurrence(current, event.StartDatetime, rule)
+	// UNTIL is stored as a calendar date and is inclusive of that whole day
+	if rule.Until != nil {
+		untilEnd := time.Date(rule.Until.Year(), rule.Until.Month(), rule.Until.Day(), 23, 59, 59, 0, time.UTC)
+		if untilEnd.Before(generateUntil) {
+			generateUntil = untilEnd
+		}
 	}
 
-	// Generate occurrences based on frequency
+	var candidates []time.Time
 	switch strings.ToUpper(rule.Frequency) {
 	case "DAILY":
-		occurrences = rg.generateDailyOccurrences(current, endDate, rule, event.StartDatetime)
+		candidates = rg.generateDailyOccurrences(seriesStart, generateUntil, rule, seriesStart)
 	case "WEEKLY":
-		occurrences = rg.generateWeeklyOccurrences(current, endDate, rule, event.StartDatetime)
+		candidates = rg.generateWeeklyOccurrences(seriesStart, generateUntil, rule, seriesStart)
 	case "MONTHLY":
-		occurrences = rg.generateMonthlyOccurrences(current, endDate, rule, event.StartDatetime)
+		candidates = rg.generateMonthlyOccurrences(seriesStart, generateUntil, rule, seriesStart)
 	case "YEARLY":
-		occurrences = rg.generateYearlyOccurrences(current, endDate, rule, event.StartDatetime)
+		candidates = rg.generateYearlyOccurrences(seriesStart, generateUntil, rule, seriesStart)
 	default:
 		return nil, fmt.Errorf("unsupported frequency: %s", rule.Frequency)
 	}
 
-	// Apply count limitation if specified
-	if rule.Count != nil && len(occurrences) > *rule.Count {
-		occurrences = occurrences[:*rule.Count]
+	// Apply count limitation if specified; skipped exceptions still count, as in RFC 5545
+	if rule.Count != nil && len(candidates) > *rule.Count {
+		candidates = candidates[:*rule.Count]
 	}
 
-	// Apply until date limitation if specified
-	if rule.Until != nil {
-		var filteredOccurrences []time.Time
-		for _, occ := range occurrences {
-			if occ.After(*rule.Until) {
-				break
-			}
-			filteredOccurrences = append(filteredOccurrences, occ)
-		}
-		occurrences = filteredOccurrences
+	// Extra dates (RDATE) are not limited by COUNT
+	candidates = rg.mergeRDates(candidates, rg.RDateStarts(event, rule))
+
+	occurrences := make([]time.Time, 0, len(candidates))
+	for _, candidate := range candidates {
+		occurrences = append(occurrences, wallClockIn(candidate, loc))
 	}
 
 	// Apply exceptions
@@ -176,13 +181,9 @@ func (rg *RecurrenceGenerator) generateMonthlyOccurrences(start, end time.Time,
 		originalStart.Hour(), originalStart.Minute(), originalStart.Second(),
 		originalStart.Nanosecond(), originalStart.Location())
 
-	// Advance to start month if needed
-	for current.Before(start) {
-		current = current.AddDate(0, interval, 0)
-	}
-
 	for current.Before(end) || current.Equal(end) {
 		monthOccurrences := rg.generateMonthOccurrences(current, rule, originalStart)
+		sortTimes(monthOccurrences)
 		for _, occ := range monthOccurrences {
 			if (occ.Equal(start) || occ.After(start)) && (occ.Equal(end) || occ.Before(end)) {
 				occurrences = append(occurrences, occ)
@@ -276,6 +277,7 @@ func (rg *RecurrenceGenerator) generateYearlyOccurrences(start, end time.Time, r
 		}
 
 		yearOccurrences := rg.generateYearOccurrences(currentYear, rule, originalStart)
+		sortTimes(yearOccurrences)
 		for _, occ := range yearOccurrences {
 			if (occ.Equal(start) || occ.After(start)) && (occ.Equal(end) || occ.Before(end)) {
 				occurrences = append(occurrences, occ)
@@ -317,10 +319,26 @@ func (rg *RecurrenceGenerator) generateYearOccurrences(year int, rule *entity.Re
 
 // Helper functions
 
-func (rg *RecurrenceGenerator) adjustToNextValidOccurrence(current, originalStart time.Time, rule *entity.RecurrenceRule) time.Time {
-	// This would contain logic to adjust current time to next valid occurrence
-	// For now, return current as is
-	return current
+// mergeRDates adds the extra dates to the rule's candidates. A series has one occurrence per day, so an
+// extra date on a day the rule already covers replaces that occurrence, which lets it move the time.
+func (rg *RecurrenceGenerator) mergeRDates(candidates, rdates []time.Time) []time.Time {
+	if len(rdates) == 0 {
+		return candidates
+	}
+
+	extra := make(map[string]bool, len(rdates))
+	for _, rdate := range rdates {
+		extra[rdate.Format("2006-01-02")] = true
+	}
+	merged := make([]time.Time, 0, len(candidates)+len(rdates))
+	for _, candidate := range candidates {
+		if !extra[candidate.Format("2006-01-02")] {
+			merged = append(merged, candidate)
+		}
+	}
+	merged = append(merged, rdates...)
+	sortTimes(merged)
+	return merged
 }
 
 func (rg *RecurrenceGenerator) applyExceptions(occurrences []time.Time, exceptions []entity.RecurrenceException) []time.Time {
@@ -421,13 +439,48 @@ func (rg *RecurrenceGenerator) getWeekdayOccurrencesInMonth(monthStart time.Time
 	}
 
 	// Sort occurrences by date
-	sort.Slice(occurrences, func(i, j int) bool {
-		return occurrences[i].Before(occurrences[j])
-	})
+	sortTimes(occurrences)
 
 	return occurrences
 }
 
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+}
+
+// recurrenceLocation resolves the event's IANA timezone, falling back to UTC
+func recurrenceLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// wallClockIn reads a stored UTC-labelled wall-clock time as local time in loc.
+// Times that fall into a DST gap are moved forward by the length of the gap.
+func wallClockIn(wall time.Time, loc *time.Location) time.Time {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+	wallUTC := wallClockOf(wall)
+	if wallClockOf(t).Equal(wallUTC) {
+		return t
+	}
+
+	// The local time does not exist; RFC 5545 reads it with the UTC offset in effect before the gap
+	_, offsetBefore := t.Add(-24 * time.Hour).Zone()
+	return wallUTC.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+}
+
+// wallClockOf is the inverse of wallClockIn
+func wallClockOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -457,6 +510,14 @@ func (rg *RecurrenceGenerator) ValidateRecurrenceRule(rule *entity.RecurrenceRul
 		return fmt.Errorf("interval must be greater than 0")
 	}
 
+	// Validate the end of the series; RFC 5545 allows COUNT or UNTIL, not both
+	if rule.Count != nil && *rule.Count < 1 {
+		return fmt.Errorf("count must be greater than 0")
+	}
+	if rule.Count != nil && rule.Until != nil {
+		return fmt.Errorf("count and until cannot be used together")
+	}
+
 	// Validate weekdays
 	weekdays := rg.jsonToStringSlice(rule.ByWeekday)
 	for _, weekday := range weekdays {
@@ -497,21 +558,49 @@ func (rg *RecurrenceGenerator) ValidateRecurrenceRule(rule *entity.RecurrenceRul
 		}
 	}
 
+	// Validate extra dates; UNTIL ends the whole series, so none may come after it
+	if rule.RDates != "" {
+		var rdates []string
+		if err := json.Unmarshal([]byte(rule.RDates), &rdates); err != nil {
+			return fmt.Errorf("invalid additional dates: %w", err)
+		}
+		normalized, err := rg.NormalizeRDates(rdates)
+		if err != nil {
+			return err
+		}
+		if rule.Until != nil && len(normalized) > 0 {
+			if last := normalized[len(normalized)-1]; last[:10] > rule.Until.Format("2006-01-02") {
+				return fmt.Errorf("additional date %s is after the until date", last)
+			}
+		}
+	}
+
 	return nil
 }
 
-// GetNextOccurrence gets the next occurrence after a given date
+// GetNextOccurrence gets the first occurrence strictly after the given instant
 func (rg *RecurrenceGenerator) GetNextOccurrence(event *entity.Event, rule *entity.RecurrenceRule, after time.Time) (*time.Time, error) {
 	if rule == nil {
-		if event.StartDatetime.After(after) {
-			return &event.StartDatetime, nil
+		start := wallClockIn(event.StartDatetime, recurrenceLocation(event.Timezone))
+		if start.After(after) {
+			return &start, nil
 		}
 		return nil, nil
 	}
 
-	// Generate occurrences for a reasonable range (next year)
+	// Generate occurrences for a reasonable range (next year), or up to the next extra date when the
+	// rule itself has ended
 	endDate := after.AddDate(1, 0, 0)
-	occurrences, err := rg.GenerateOccurrences(event, rule, after.AddDate(0, 0, 1), endDate, nil)
+	loc := recurrenceLocation(event.Timezone)
+	for _, rdate := range rg.RDateStarts(event, rule) {
+		if start := wallClockIn(rdate, loc); start.After(after) {
+			if start.After(endDate) {
+				endDate = start
+			}
+			break
+		}
+	}
+	occurrences, err := rg.GenerateOccurrences(event, rule, after.Add(time.Nanosecond), endDate, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -523,6 +612,35 @@ func (rg *RecurrenceGenerator) GetNextOccurrence(event *entity.Event, rule *enti
 	return nil, nil
 }
 
+// OccurrenceOn returns the start of the event's occurrence on the given calendar date in the event's zone,
+// or nil when the event does not occur that day or the occurrence was skipped. Overridden start times are applied.
+func (rg *RecurrenceGenerator) OccurrenceOn(event *entity.Event, date time.Time, exceptions []entity.RecurrenceException) (*time.Time, error) {
+	loc := recurrenceLocation(event.Timezone)
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	occurrences, err := rg.GenerateOccurrences(event, event.RecurrenceRule, dayStart, dayEnd, exceptions)
+	if err != nil {
+		return nil, err
+	}
+	if event.RecurrenceRule == nil && len(occurrences) == 1 && occurrences[0].Format("2006-01-02") != dayStart.Format("2006-01-02") {
+		return nil, nil
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	start := occurrences[0]
+	dateKey := date.Format("2006-01-02")
+	for _, exception := range exceptions {
+		if exception.ExceptionDate.Format("2006-01-02") == dateKey && exception.OverrideStart != nil {
+			start = wallClockIn(*exception.OverrideStart, loc)
+		}
+	}
+
+	return &start, nil
+}
+
 // Helper functions to convert JSON strings to slices
 func (rg *RecurrenceGenerator) jsonToStringSlice(jsonStr string) []string {
 	if jsonStr == "" {
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+)
+
+// FormatRRule serializes a recurrence rule as an RFC 5545 RRULE value (without the "RRULE:" prefix).
+// Until is stored as a calendar date, so it is emitted as the end of that day in loc converted to UTC.
+func (rg *RecurrenceGenerator) FormatRRule(rule *entity.RecurrenceRule, loc *time.Location) string {
+	if rule == nil {
+		return ""
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	parts := []string{"FREQ=" + strings.ToUpper(rule.Frequency)}
+
+	if rule.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rule.Interval))
+	}
+	if rule.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*rule.Count))
+	}
+	if rule.Until != nil {
+		endOfDay := time.Date(rule.Until.Year(), rule.Until.Month(), rule.Until.Day(), 23, 59, 59, 0, loc)
+		parts = append(parts, "UNTIL="+endOfDay.UTC().Format("20060102T150405Z"))
+	}
+
+	if weekdays := rg.jsonToStringSlice(rule.ByWeekday); len(weekdays) > 0 {
+		upper := make([]string, len(weekdays))
+		for i, wd := range weekdays {
+			upper[i] = strings.ToUpper(wd)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(upper, ","))
+	}
+	if monthDays := rg.jsonToInt64Slice(rule.ByMonthDay); len(monthDays) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInt64(monthDays))
+	}
+	if yearDays := rg.jsonToInt64Slice(rule.ByYearDay); len(yearDays) > 0 {
+		parts = append(parts, "BYYEARDAY="+joinInt64(yearDays))
+	}
+	if months := rg.jsonToInt64Slice(rule.ByMonth); len(months) > 0 {
+		parts = append(parts, "BYMONTH="+joinInt64(months))
+	}
+	if setPos := rg.jsonToInt64Slice(rule.BySetPos); len(setPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInt64(setPos))
+	}
+	if rule.WeekStart != "" && strings.ToUpper(rule.WeekStart) != "MO" {
+		parts = append(parts, "WKST="+strings.ToUpper(rule.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinInt64(values []int64) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = fmt.Sprintf("%d", v)
+	}
+	return strings.Join(strs, ",")
+}
+
+// RRuleError points at the RRULE part that could not be parsed or is not supported
+type RRuleError struct {
+	Part    string
+	Message string
+}
+
+func (e *RRuleError) Error() string {
+	return fmt.Sprintf("RRULE part %q: %s", e.Part, e.Message)
+}
+
+// ParseRRule converts an RFC 5545 RRULE value (with or without the "RRULE:" prefix) into a recurrence rule request.
+// UNTIL date-times are converted into loc and stored as calendar dates, like the rest of the API.
+func (rg *RecurrenceGenerator) ParseRRule(value string, loc *time.Location) (*dto.CreateRecurrenceRuleRequest, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 6 && strings.EqualFold(value[:6], "RRULE:") {
+		value = value[6:]
+	}
+	if value == "" {
+		return nil, &RRuleError{Part: "FREQ", Message: "rule is empty"}
+	}
+
+	req := &dto.CreateRecurrenceRuleRequest{}
+	var byDayOrdinals []int64
+	seen := make(map[string]string)
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, found := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		if !found || val == "" {
+			return nil, &RRuleError{Part: part, Message: "expected NAME=VALUE"}
+		}
+		if _, duplicate := seen[key]; duplicate {
+			return nil, &RRuleError{Part: part, Message: "part is specified more than once"}
+		}
+		seen[key] = part
+
+		switch key {
+		case "FREQ":
+			freq := strings.ToUpper(val)
+			switch freq {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				req.Frequency = freq
+			case "SECONDLY", "MINUTELY", "HOURLY":
+				return nil, &RRuleError{Part: part, Message: "sub-daily frequencies are not supported"}
+			default:
+				return nil, &RRuleError{Part: part, Message: "unknown frequency"}
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, &RRuleError{Part: part, Message: "interval must be a positive integer"}
+			}
+			req.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, &RRuleError{Part: part, Message: "count must be a positive integer"}
+			}
+			req.Count = &count
+		case "UNTIL":
+			until, err := rg.parseRRuleUntil(val, loc)
+			if err != nil {
+				return nil, &RRuleError{Part: part, Message: err.Error()}
+			}
+			req.Until = &until
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				item = strings.ToUpper(strings.TrimSpace(item))
+				if len(item) < 2 {
+					return nil, &RRuleError{Part: part, Message: fmt.Sprintf("invalid weekday %q", item)}
+				}
+				day := item[len(item)-2:]
+				if _, ok := rg.parseWeekday(day); !ok {
+					return nil, &RRuleError{Part: part, Message: fmt.Sprintf("invalid weekday %q", item)}
+				}
+				if ordinal := item[:len(item)-2]; ordinal != "" {
+					n, err := strconv.ParseInt(ordinal, 10, 64)
+					if err != nil || n == 0 || n < -53 || n > 53 {
+						return nil, &RRuleError{Part: part, Message: fmt.Sprintf("invalid weekday ordinal %q", item)}
+					}
+					byDayOrdinals = append(byDayOrdinals, n)
+				}
+				req.ByWeekday = append(req.ByWeekday, day)
+			}
+		case "BYMONTHDAY":
+			days, err := parseRRuleIntList(val, -31, 31)
+			if err != nil {
+				return nil, &RRuleError{Part: part, Message: err.Error()}
+			}
+			req.ByMonthDay = days
+		case "BYMONTH":
+			months, err := parseRRuleIntList(val, 1, 12)
+			if err != nil {
+				return nil, &RRuleError{Part: part, Message: err.Error()}
+			}
+			req.ByMonth = months
+		case "BYYEARDAY":
+			yearDays, err := parseRRuleIntList(val, -366, 366)
+			if err != nil {
+				return nil, &RRuleError{Part: part, Message: err.Error()}
+			}
+			req.ByYearDay = yearDays
+		case "BYSETPOS":
+			positions, err := parseRRuleIntList(val, -366, 366)
+			if err != nil {
+				return nil, &RRuleError{Part: part, Message: err.Error()}
+			}
+			req.BySetPos = positions
+		case "WKST":
+			day := strings.ToUpper(val)
+			if _, ok := rg.parseWeekday(day); !ok {
+				return nil, &RRuleError{Part: part, Message: "invalid week start day"}
+			}
+			req.WeekStart = day
+		case "BYHOUR", "BYMINUTE", "BYSECOND", "BYWEEKNO":
+			return nil, &RRuleError{Part: part, Message: "part is not supported"}
+		default:
+			return nil, &RRuleError{Part: part, Message: "unknown RRULE part"}
+		}
+	}
+
+	if req.Frequency == "" {
+		return nil, &RRuleError{Part: "FREQ", Message: "FREQ is required"}
+	}
+	if req.Count != nil && req.Until != nil {
+		return nil, &RRuleError{Part: seen["UNTIL"], Message: "COUNT and UNTIL cannot be used together"}
+	}
+
+	// The generator models "2nd Sunday" as BYDAY=SU;BYSETPOS=2, so ordinals are only
+	// representable when a single weekday is used and no explicit BYSETPOS is given
+	if len(byDayOrdinals) > 0 {
+		if req.Frequency != "MONTHLY" && req.Frequency != "YEARLY" {
+			return nil, &RRuleError{Part: seen["BYDAY"], Message: "weekday ordinals are only allowed for MONTHLY and YEARLY rules"}
+		}
+		if len(byDayOrdinals) != len(req.ByWeekday) || len(req.ByWeekday) != 1 {
+			return nil, &RRuleError{Part: seen["BYDAY"], Message: "weekday ordinals are only supported for a single weekday"}
+		}
+		if len(req.BySetPos) > 0 {
+			return nil, &RRuleError{Part: seen["BYSETPOS"], Message: "BYSETPOS cannot be combined with weekday ordinals"}
+		}
+		req.BySetPos = byDayOrdinals
+	}
+
+	if len(req.ByWeekday) > 0 && req.Frequency == "DAILY" {
+		return nil, &RRuleError{Part: seen["BYDAY"], Message: "BYDAY is only supported for WEEKLY, MONTHLY and YEARLY rules"}
+	}
+	if len(req.ByWeekday) > 0 && len(req.ByMonthDay) > 0 {
+		return nil, &RRuleError{Part: seen["BYMONTHDAY"], Message: "BYMONTHDAY cannot be combined with BYDAY"}
+	}
+	if len(req.BySetPos) > 0 && req.Frequency != "MONTHLY" && req.Frequency != "YEARLY" {
+		return nil, &RRuleError{Part: seen["BYSETPOS"], Message: "BYSETPOS is only supported for MONTHLY and YEARLY rules"}
+	}
+	if len(req.BySetPos) > 0 && len(req.ByWeekday) == 0 {
+		return nil, &RRuleError{Part: seen["BYSETPOS"], Message: "BYSETPOS requires BYDAY"}
+	}
+	if len(req.ByMonthDay) > 0 && req.Frequency != "MONTHLY" && req.Frequency != "YEARLY" {
+		return nil, &RRuleError{Part: seen["BYMONTHDAY"], Message: "BYMONTHDAY is only supported for MONTHLY and YEARLY rules"}
+	}
+	if len(req.ByMonth) > 0 && req.Frequency != "YEARLY" {
+		return nil, &RRuleError{Part: seen["BYMONTH"], Message: "BYMONTH is only supported for YEARLY rules"}
+	}
+
+	if req.Interval == 0 {
+		req.Interval = 1
+	}
+
+	return req, nil
+}
+
+func (rg *RecurrenceGenerator) parseRRuleUntil(value string, loc *time.Location) (string, error) {
+	switch {
+	case len(value) == len("20060102"):
+		parsed, err := time.Parse("20060102", value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date")
+		}
+		return parsed.Format("2006-01-02"), nil
+	case strings.HasSuffix(value, "Z"):
+		parsed, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return "", fmt.Errorf("invalid UTC date-time")
+		}
+		return parsed.In(loc).Format("2006-01-02"), nil
+	default:
+		parsed, err := time.ParseInLocation("20060102T150405", value, loc)
+		if err != nil {
+			return "", fmt.Errorf("invalid date-time")
+		}
+		return parsed.Format("2006-01-02"), nil
+	}
+}
+
+func parseRRuleIntList(value string, min, max int64) ([]int64, error) {
+	var result []int64
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", item)
+		}
+		if n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("value %d is out of range", n)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// Extra dates (RDATE) are stored on the event's wall clock, either as a date at the event's start time
+// or as a date and time of their own. Each keeps the event's duration.
+const (
+	rdateDateFormat     = "2006-01-02"
+	rdateDateTimeFormat = "2006-01-02T15:04"
+)
+
+// NormalizeRDates validates extra dates and returns them sorted in their stored form. A series has one
+// occurrence per day, so a day may only be given once.
+func (rg *RecurrenceGenerator) NormalizeRDates(values []string) ([]string, error) {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		start, hasTime, err := parseRDate(value)
+		if err != nil {
+			return nil, err
+		}
+		day := start.Format(rdateDateFormat)
+		if seen[day] {
+			return nil, fmt.Errorf("additional date %s is given more than once", day)
+		}
+		seen[day] = true
+		if hasTime {
+			normalized = append(normalized, start.Format(rdateDateTimeFormat))
+		} else {
+			normalized = append(normalized, day)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// RDateStarts returns the UTC-labelled wall-clock starts of the rule's extra dates that belong to the
+// series: from the event's first day up to UNTIL, sorted
+func (rg *RecurrenceGenerator) RDateStarts(event *entity.Event, rule *entity.RecurrenceRule) []time.Time {
+	if rule == nil || rule.RDates == "" {
+		return nil
+	}
+
+	firstDay := event.StartDatetime.Format(rdateDateFormat)
+	var starts []time.Time
+	for _, value := range rg.jsonToStringSlice(rule.RDates) {
+		start, hasTime, err := parseRDate(value)
+		if err != nil {
+			continue
+		}
+		day := start.Format(rdateDateFormat)
+		if day < firstDay || (rule.Until != nil && day > rule.Until.Format(rdateDateFormat)) {
+			continue
+		}
+		if !hasTime || event.AllDay {
+			start = time.Date(start.Year(), start.Month(), start.Day(),
+				event.StartDatetime.Hour(), event.StartDatetime.Minute(), event.StartDatetime.Second(), 0, time.UTC)
+		}
+		starts = append(starts, start)
+	}
+	sortTimes(starts)
+	return starts
+}
+
+// ScheduledStartOn returns the UTC-labelled wall-clock start the series gives the occurrence on a date,
+// before any override: the time of an extra date on that day, otherwise the event's start time
+func (rg *RecurrenceGenerator) ScheduledStartOn(event *entity.Event, date time.Time) time.Time {
+	day := date.Format(rdateDateFormat)
+	for _, start := range rg.RDateStarts(event, event.RecurrenceRule) {
+		if start.Format(rdateDateFormat) == day {
+			return start
+		}
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(),
+		event.StartDatetime.Hour(), event.StartDatetime.Minute(), event.StartDatetime.Second(), 0, time.UTC)
+}
+
+// parseRDate reads a stored or requested extra date and reports whether it carries its own start time
+func parseRDate(value string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if start, err := time.Parse(rdateDateFormat, value); err == nil {
+		return start, false, nil
+	}
+	if start, err := time.Parse(rdateDateTimeFormat, value); err == nil {
+		return start, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid additional date %q, expected YYYY-MM-DD or YYYY-MM-DDTHH:MM", value)
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// Setlist export formats
+const (
+	SetlistExportText       = "text"
+	SetlistExportOpenLyrics = "openlyrics"
+	SetlistExportHTML       = "html"
+)
+
+// exportedSong is one song of an exported setlist with its lyrics split into slides
+type exportedSong struct {
+	Position int
+	Key      string // key it is sung in
+	Lagu     *entity.Lagu
+	Sheet    *utils.LyricSheet
+	Slides   []exportedSlide
+}
+
+// exportedSlide is one slide in the order it is shown; Label is set on the first slide of a stanza
+type exportedSlide struct {
+	Label string
+	Lines []string
+}
+
+// ExportEventSetlist exports the setlist of an occurrence in the order it is sung, with the lyrics
+// of every song split into slides: as plain text with slides separated by blank lines, as a zip of
+// OpenLyrics files, one per song, or as a printable HTML lyrics sheet
+func (s *laguService) ExportEventSetlist(eventID uuid.UUID, req *dto.SetlistExportRequest) ([]byte, error) {
+	event, occurrenceDate, _, err := resolveEventOccurrence(s.eventRepo, s.recurrenceGenerator, eventID, req.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.laguRepo.GetOccurrenceSetlist(eventID, occurrenceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setlist: %w", err)
+	}
+
+	slideLines := req.SlideLines
+	if slideLines <= 0 {
+		slideLines = utils.DefaultSlideLines
+	}
+
+	songs := make([]exportedSong, len(items))
+	for i := range items {
+		item := &items[i]
+		sheet := utils.SplitLyricSheet(utils.ChordProLyrics(item.Lagu.Lirik), slideLines)
+		songs[i] = exportedSong{
+			Position: item.Position,
+			Key:      performedKey(item.PerformedKey, item.Lagu.NadaDasar),
+			Lagu:     &item.Lagu,
+			Sheet:    sheet,
+			Slides:   orderedSlides(sheet),
+		}
+	}
+
+	title := fmt.Sprintf("%s, %s", event.Title, occurrenceDate.Format("Monday, 2 January 2006"))
+	switch req.Format {
+	case SetlistExportOpenLyrics:
+		return setlistOpenLyricsZip(songs)
+	case SetlistExportHTML:
+		return setlistHTML(title, songs)
+	default:
+		return setlistText(title, songs), nil
+	}
+}
+
+// orderedSlides lists the slides of a song in the order its stanzas are sung, repeats included
+func orderedSlides(sheet *utils.LyricSheet) []exportedSlide {
+	var slides []exportedSlide
+	for _, name := range sheet.Order {
+		section := sheet.Section(name)
+		if section == nil {
+			continue
+		}
+		for i, lines := range section.Slides {
+			slide := exportedSlide{Lines: lines}
+			if i == 0 {
+				slide.Label = section.Label
+			}
+			slides = append(slides, slide)
+		}
+	}
+	return slides
+}
+
+func setlistText(title string, songs []exportedSong) []byte {
+	var builder strings.Builder
+	builder.WriteString(title)
+	builder.WriteString("\n")
+
+	for _, song := range songs {
+		builder.WriteString("\n\n")
+		builder.WriteString(songHeading(&song))
+		builder.WriteString("\n")
+		for _, slide := range song.Slides {
+			builder.WriteString("\n")
+			if slide.Label != "" {
+				fmt.Fprintf(&builder, "[%s]\n", slide.Label)
+			}
+			builder.WriteString(strings.Join(slide.Lines, "\n"))
+			builder.WriteString("\n")
+		}
+	}
+	return []byte(builder.String())
+}
+
+var fileNameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+func setlistOpenLyricsZip(songs []exportedSong) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, song := range songs {
+		document, err := utils.FormatOpenLyrics(&utils.OpenLyricsSong{
+			Title:    song.Lagu.Judul,
+			Artist:   song.Lagu.Artis,
+			Key:      song.Key,
+			Released: song.Lagu.TahunRilis,
+			Sheet:    song.Sheet,
+			Modified: song.Lagu.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write OpenLyrics for %s: %w", song.Lagu.Judul, err)
+		}
+
+		name := strings.Trim(fileNameUnsafe.ReplaceAllString(utils.FoldSearchText(song.Lagu.Judul), "-"), "-")
+		file, err := archive.Create(fmt.Sprintf("%02d-%s.xml", song.Position, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write zip: %w", err)
+		}
+		if _, err := file.Write(document); err != nil {
+			return nil, fmt.Errorf("failed to write zip: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write zip: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var setlistSheetTemplate = template.Must(template.New("setlist").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; margin: 2em; color: #000; }
+h1 { font-size: 1.4em; margin-bottom: 1em; }
+h2 { font-size: 1.15em; margin: 1.5em 0 0.2em; }
+.slide { margin: 0 0 0.8em; break-inside: avoid; page-break-inside: avoid; }
+.label { font-size: 0.8em; font-weight: bold; text-transform: uppercase; color: #555; }
+.song { break-inside: avoid-page; }
+@media print { body { margin: 0; } .song + .song { break-before: page; page-break-before: always; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Songs}}<section class="song">
+<h2>{{.Heading}}</h2>
+{{range .Slides}}<div class="slide">{{if .Label}}<div class="label">{{.Label}}</div>{{end}}{{range $i, $line := .Lines}}{{if $i}}<br>{{end}}{{$line}}{{end}}</div>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+func setlistHTML(title string, songs []exportedSong) ([]byte, error) {
+	type htmlSong struct {
+		Heading string
+		Slides  []exportedSlide
+	}
+	data := struct {
+		Title string
+		Songs []htmlSong
+	}{Title: title, Songs: make([]htmlSong, len(songs))}
+	for i := range songs {
+		data.Songs[i] = htmlSong{Heading: songHeading(&songs[i]), Slides: songs[i].Slides}
+	}
+
+	var buf bytes.Buffer
+	if err := setlistSheetTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to write lyrics sheet: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// songHeading is the numbered title line of a song, e.g. "2. Bapa Engkau Sungguh Baik - Artist (G)"
+func songHeading(song *exportedSong) string {
+	heading := fmt.Sprintf("%d. %s", song.Position, song.Lagu.Judul)
+	if song.Lagu.Artis != "" {
+		heading += " - " + song.Lagu.Artis
+	}
+	if song.Key != "" {
+		heading += fmt.Sprintf(" (%s)", song.Key)
+	}
+	return heading
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+)
+
+type VenueService interface {
+	CreateVenue(req *dto.CreateVenueRequest) (*dto.VenueResponse, error)
+	GetVenue(id uuid.UUID) (*dto.VenueResponse, error)
+	UpdateVenue(id uuid.UUID, req *dto.UpdateVenueRequest) (*dto.VenueResponse, error)
+	DeleteVenue(id uuid.UUID) error
+	ListVenues(req *dto.VenueFilterRequest) (*dto.VenueListResponse, error)
+}
+
+type venueService struct {
+	venueRepo repository.VenueRepository
+}
+
+func NewVenueService(venueRepo repository.VenueRepository) VenueService {
+	return &venueService{venueRepo: venueRepo}
+}
+
+func (s *venueService) CreateVenue(req *dto.CreateVenueRequest) (*dto.VenueResponse, error) {
+	if _, err := s.venueRepo.GetByChurchAndName(req.ChurchID, req.Name); err == nil {
+		return nil, fmt.Errorf("a venue named %q already exists at this church", req.Name)
+	}
+
+	venue := &entity.Venue{
+		ChurchID:    req.ChurchID,
+		Name:        req.Name,
+		Description: req.Description,
+		Capacity:    req.Capacity,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		venue.IsActive = *req.IsActive
+	}
+
+	if err := s.venueRepo.Create(venue); err != nil {
+		return nil, fmt.Errorf("failed to create venue: %w", err)
+	}
+
+	return s.GetVenue(venue.ID)
+}
+
+func (s *venueService) GetVenue(id uuid.UUID) (*dto.VenueResponse, error) {
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return venueToResponse(venue), nil
+}
+
+func (s *venueService) UpdateVenue(id uuid.UUID, req *dto.UpdateVenueRequest) (*dto.VenueResponse, error) {
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if req.Name != nil && *req.Name != venue.Name {
+		if _, err := s.venueRepo.GetByChurchAndName(venue.ChurchID, *req.Name); err == nil {
+			return nil, fmt.Errorf("a venue named %q already exists at this church", *req.Name)
+		}
+		venue.Name = *req.Name
+	}
+	if req.Description != nil {
+		venue.Description = *req.Description
+	}
+	if req.Capacity != nil {
+		venue.Capacity = *req.Capacity
+	}
+	if req.IsActive != nil {
+		venue.IsActive = *req.IsActive
+	}
+
+	if err := s.venueRepo.Update(venue); err != nil {
+		return nil, fmt.Errorf("failed to update venue: %w", err)
+	}
+
+	return venueToResponse(venue), nil
+}
+
+// DeleteVenue refuses to delete a venue that events still reference; deactivate it instead
+func (s *venueService) DeleteVenue(id uuid.UUID) error {
+	if _, err := s.venueRepo.GetByID(id); err != nil {
+		return fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	count, err := s.venueRepo.CountEvents(id)
+	if err != nil {
+		return fmt.Errorf("failed to count events: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("venue is still booked by %d event(s); deactivate it instead", count)
+	}
+
+	if err := s.venueRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+	return nil
+}
+
+func (s *venueService) ListVenues(req *dto.VenueFilterRequest) (*dto.VenueListResponse, error) {
+	filters := repository.VenueFilters{
+		IsActive: req.IsActive,
+		Search:   req.Search,
+	}
+	if req.ChurchID != "" {
+		churchID, err := uuid.Parse(req.ChurchID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid church ID: %w", err)
+		}
+		filters.ChurchID = &churchID
+	}
+
+	// Set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	filters.Limit = req.Limit
+	filters.Offset = (req.Page - 1) * req.Limit
+
+	venues, total, err := s.venueRepo.List(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list venues: %w", err)
+	}
+
+	responses := make([]dto.VenueResponse, len(venues))
+	for i := range venues {
+		responses[i] = *venueToResponse(&venues[i])
+	}
+
+	return &dto.VenueListResponse{
+		Venues:     responses,
+		TotalCount: int(total),
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+func venueToResponse(venue *entity.Venue) *dto.VenueResponse {
+	return &dto.VenueResponse{
+		ID:          venue.ID,
+		ChurchID:    venue.ChurchID,
+		ChurchName:  venue.Church.Name,
+		Name:        venue.Name,
+		Description: venue.Description,
+		Capacity:    venue.Capacity,
+		IsActive:    venue.IsActive,
+		CreatedAt:   venue.CreatedAt,
+		UpdatedAt:   venue.UpdatedAt,
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zemetia/en-indo-be/dto"
-	"github.com/zemetia/en-indo-be/entity"
 	"github.com/zemetia/en-indo-be/repository"
 	"github.com/zemetia/en-indo-be/service"
 )
@@ -17,7 +16,7 @@ func TestEventPICService_BasicOperations(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 	eventPICService := service.NewEventPICService(eventPICRepo, eventRepo)
 
 	// Create a test event first
@@ -149,7 +148,7 @@ func TestEventPICService_AdvancedOperations(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 	eventPICService := service.NewEventPICService(eventPICRepo, eventRepo)
 
 	// Create a test event
@@ -239,7 +238,7 @@ func TestEventPICService_AdvancedOperations(t *testing.T) {
 			CanAssignPIC: true,
 		}
 
-		pic, err := eventPICService.CreateEventPIC(testEvent.ID, picReq, createdBy)
+		_, err := eventPICService.CreateEventPIC(testEvent.ID, picReq, createdBy)
 		require.NoError(t, err)
 
 		// Transfer PIC role
@@ -288,7 +287,7 @@ func TestEventPICService_AdvancedOperations(t *testing.T) {
 		require.NoError(t, err)
 
 		// Get expiring PICs within 30 days
-		expiringPICs, err := eventPICService.GetExpiringPICs(30)
+		expiringPICs, err := eventPICService.GetExpiringPICs(30, nil)
 		require.NoError(t, err)
 
 		// Should find our PIC
@@ -372,7 +371,7 @@ func TestEventPICPermissions(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 	eventPICService := service.NewEventPICService(eventPICRepo, eventRepo)
 
 	// Create test event
@@ -440,7 +439,7 @@ func TestEventWithPICCreation(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 
 	createdBy := uuid.New()
 	personID1 := uuid.New()
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+// publicOccurrenceRepo counts index queries and can run a hook while a page is being built
+type publicOccurrenceRepo struct {
+	repository.EventOccurrenceRepository
+	calls  int
+	onList func()
+}
+
+func (r *publicOccurrenceRepo) List(filters repository.EventOccurrenceFilters) ([]entity.EventOccurrence, int64, error) {
+	r.calls++
+	if r.onList != nil {
+		r.onList()
+	}
+	return nil, 0, nil
+}
+
+// publicEventServiceStub only records the change listener the public feed subscribes
+type publicEventServiceStub struct {
+	service.EventService
+	listeners []func(eventID uuid.UUID)
+}
+
+func (s *publicEventServiceStub) OnEventChange(listener func(eventID uuid.UUID)) {
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *publicEventServiceStub) change() {
+	for _, listener := range s.listeners {
+		listener(uuid.New())
+	}
+}
+
+func TestPublicEventService_Cache(t *testing.T) {
+	t.Run("Pages are served from the cache until an event changes", func(t *testing.T) {
+		repo := &publicOccurrenceRepo{}
+		events := &publicEventServiceStub{}
+		publicService := service.NewPublicEventService(repo, events)
+
+		_, err := publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+		_, err = publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, repo.calls)
+
+		events.change()
+		_, err = publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.calls)
+	})
+
+	t.Run("A page built during a change is not cached", func(t *testing.T) {
+		repo := &publicOccurrenceRepo{}
+		events := &publicEventServiceStub{}
+		publicService := service.NewPublicEventService(repo, events)
+
+		repo.onList = events.change
+		_, err := publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+
+		repo.onList = nil
+		_, err = publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+		_, err = publicService.ListPublicEvents(&dto.PublicEventFilterRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.calls)
+	})
+}
@@ -31,7 +31,7 @@ func TestRecurrenceGenerator_BasicFunctionality(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "WEEKLY",
 			Interval:  1,
-			ByWeekday: []string{"MO"},
+			ByWeekday: `["MO"]`,
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -60,7 +60,7 @@ func TestRecurrenceGenerator_BasicFunctionality(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "WEEKLY",
 			Interval:  2, // Every 2 weeks
-			ByWeekday: []string{"WE"},
+			ByWeekday: `["WE"]`,
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -90,7 +90,7 @@ func TestRecurrenceGenerator_BasicFunctionality(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency:  "MONTHLY",
 			Interval:   1,
-			ByMonthDay: []int64{15},
+			ByMonthDay: `[15]`,
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -119,8 +119,8 @@ func TestRecurrenceGenerator_BasicFunctionality(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "MONTHLY",
 			Interval:  1,
-			ByWeekday: []string{"MO"},
-			BySetPos:  []int64{2}, // 2nd occurrence
+			ByWeekday: `["MO"]`,
+			BySetPos:  `[2]`, // 2nd occurrence
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -154,7 +154,7 @@ func TestRecurrenceGenerator_EdgeCases(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "WEEKLY",
 			Interval:  1,
-			ByWeekday: []string{"MO"},
+			ByWeekday: `["MO"]`,
 			Count:     &count,
 		}
 
@@ -178,15 +178,15 @@ func TestRecurrenceGenerator_EdgeCases(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "WEEKLY",
 			Interval:  1,
-			ByWeekday: []string{"MO"},
+			ByWeekday: `["MO"]`,
 			Until:     &untilDate,
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
 		require.NoError(t, err)
 
-		// Should only include occurrences until Jan 15 (Jan 1, 8)
-		assert.Len(t, occurrences, 2)
+		// UNTIL is inclusive, so Jan 15 is the last occurrence (Jan 1, 8, 15)
+		assert.Len(t, occurrences, 3)
 
 		for _, occ := range occurrences {
 			assert.True(t, occ.Before(untilDate) || occ.Equal(untilDate))
@@ -205,7 +205,7 @@ func TestRecurrenceGenerator_EdgeCases(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "WEEKLY",
 			Interval:  1,
-			ByWeekday: []string{"MO"},
+			ByWeekday: `["MO"]`,
 		}
 
 		// Skip January 8th occurrence
@@ -236,9 +236,9 @@ func TestRecurrenceGenerator_Validation(t *testing.T) {
 	t.Run("Valid rules", func(t *testing.T) {
 		validRules := []*entity.RecurrenceRule{
 			{Frequency: "DAILY", Interval: 1},
-			{Frequency: "WEEKLY", Interval: 2, ByWeekday: []string{"MO", "WE", "FR"}},
-			{Frequency: "MONTHLY", Interval: 1, ByMonthDay: []int64{1, 15}},
-			{Frequency: "YEARLY", Interval: 1, ByMonth: []int64{3, 6, 9, 12}},
+			{Frequency: "WEEKLY", Interval: 2, ByWeekday: `["MO","WE","FR"]`},
+			{Frequency: "MONTHLY", Interval: 1, ByMonthDay: `[1,15]`},
+			{Frequency: "YEARLY", Interval: 1, ByMonth: `[3,6,9,12]`},
 		}
 
 		for _, rule := range validRules {
@@ -251,9 +251,9 @@ func TestRecurrenceGenerator_Validation(t *testing.T) {
 		invalidRules := []*entity.RecurrenceRule{
 			{Frequency: "INVALID", Interval: 1},
 			{Frequency: "WEEKLY", Interval: 0},
-			{Frequency: "WEEKLY", Interval: 1, ByWeekday: []string{"XX"}},
-			{Frequency: "MONTHLY", Interval: 1, ByMonthDay: []int64{32}},
-			{Frequency: "YEARLY", Interval: 1, ByMonth: []int64{13}},
+			{Frequency: "WEEKLY", Interval: 1, ByWeekday: `["XX"]`},
+			{Frequency: "MONTHLY", Interval: 1, ByMonthDay: `[32]`},
+			{Frequency: "YEARLY", Interval: 1, ByMonth: `[13]`},
 		}
 
 		for _, rule := range invalidRules {
@@ -267,7 +267,7 @@ func TestEventService_RecurrenceOperations(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 
 	t.Run("Create recurring event", func(t *testing.T) {
 		req := &dto.CreateEventRequest{
@@ -301,6 +301,35 @@ func TestEventService_RecurrenceOperations(t *testing.T) {
 		eventService.DeleteEvent(response.ID)
 	})
 
+	t.Run("Create recurring event with extra dates", func(t *testing.T) {
+		req := &dto.CreateEventRequest{
+			Title:         "Prayer Meeting",
+			EventDate:     "2025-04-02",
+			StartTime:     "19:00",
+			EndTime:       "20:00",
+			EventLocation: "Chapel",
+			Type:          "ibadah",
+			Timezone:      "Asia/Jakarta",
+			RecurrenceRule: &dto.CreateRecurrenceRuleRequest{
+				RRule:  "RRULE:FREQ=WEEKLY;BYDAY=WE",
+				RDates: []string{"2025-04-18T10:00", "2025-04-17"},
+			},
+		}
+
+		response, err := eventService.CreateEvent(req)
+		require.NoError(t, err)
+		require.NotNil(t, response.RecurrenceRule)
+
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=WE", response.RecurrenceRule.RRule)
+		assert.Equal(t, []string{"2025-04-17", "2025-04-18T10:00"}, response.RecurrenceRule.RDates)
+
+		eventService.DeleteEvent(response.ID)
+
+		req.RecurrenceRule.RDates = []string{"2025-03-28"}
+		_, err = eventService.CreateEvent(req)
+		assert.Error(t, err, "extra dates before the event date are rejected")
+	})
+
 	t.Run("Validate recurrence rule", func(t *testing.T) {
 		validRule := &dto.CreateRecurrenceRuleRequest{
 			Frequency: "MONTHLY",
@@ -326,7 +355,7 @@ func TestEventService_ThreeTierModifications(t *testing.T) {
 	db := SetUpDatabaseConnection()
 	eventRepo := repository.NewEventRepository(db)
 	eventPICRepo := repository.NewEventPICRepository(db)
-	eventService := service.NewEventService(eventRepo, eventPICRepo)
+	eventService := service.NewEventService(eventRepo, eventPICRepo, repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
 
 	// Create a recurring event for testing
 	createReq := &dto.CreateEventRequest{
@@ -481,8 +510,8 @@ func TestRecurrenceGenerator_ComplexPatterns(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency: "MONTHLY",
 			Interval:  1,
-			ByWeekday: []string{"FR"},
-			BySetPos:  []int64{-1}, // Last occurrence
+			ByWeekday: `["FR"]`,
+			BySetPos:  `[-1]`, // Last occurrence
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -514,7 +543,7 @@ func TestRecurrenceGenerator_ComplexPatterns(t *testing.T) {
 		rule := &entity.RecurrenceRule{
 			Frequency:  "MONTHLY",
 			Interval:   3, // Every 3 months
-			ByMonthDay: []int64{15},
+			ByMonthDay: `[15]`,
 		}
 
 		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, endDate, nil)
@@ -530,3 +559,422 @@ func TestRecurrenceGenerator_ComplexPatterns(t *testing.T) {
 		}
 	})
 }
+
+func TestRecurrenceGenerator_Timezones(t *testing.T) {
+	generator := service.NewRecurrenceGenerator()
+
+	t.Run("Weekly Sunday 09:00 Asia/Jayapura stays at 09:00 local", func(t *testing.T) {
+		jayapura, err := time.LoadLocation("Asia/Jayapura")
+		require.NoError(t, err)
+
+		// Stored times are the wall clock in the event timezone
+		startDate := time.Date(2024, 1, 7, 9, 0, 0, 0, time.UTC) // Sunday
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(2 * time.Hour),
+			Timezone:      "Asia/Jayapura",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["SU"]`,
+		}
+
+		rangeStart := time.Date(2024, 1, 1, 0, 0, 0, 0, jayapura)
+		rangeEnd := time.Date(2024, 1, 31, 23, 59, 59, 0, jayapura)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Jan 7, 14, 21, 28
+		assert.Len(t, occurrences, 4)
+		for _, occ := range occurrences {
+			local := occ.In(jayapura)
+			assert.Equal(t, time.Sunday, local.Weekday())
+			assert.Equal(t, 9, local.Hour())
+			// WIT is UTC+9
+			assert.Equal(t, 0, occ.UTC().Hour())
+		}
+	})
+
+	t.Run("WIB event viewed in WIT", func(t *testing.T) {
+		jakarta, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+		jayapura, err := time.LoadLocation("Asia/Jayapura")
+		require.NoError(t, err)
+
+		startDate := time.Date(2024, 1, 7, 9, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Asia/Jakarta",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["SU"]`,
+		}
+
+		rangeStart := time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta)
+		rangeEnd := time.Date(2024, 1, 14, 23, 59, 59, 0, jakarta)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 2)
+		for _, occ := range occurrences {
+			assert.Equal(t, 9, occ.In(jakarta).Hour())
+			assert.Equal(t, 11, occ.In(jayapura).Hour())
+		}
+	})
+
+	t.Run("Spring forward keeps the local start time", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		// DST starts on Sunday 10 March 2024 in New York
+		startDate := time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(90 * time.Minute),
+			Timezone:      "America/New_York",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["SU"]`,
+		}
+
+		rangeStart := time.Date(2024, 3, 1, 0, 0, 0, 0, newYork)
+		rangeEnd := time.Date(2024, 3, 17, 23, 59, 59, 0, newYork)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Mar 3 (EST), Mar 10 and Mar 17 (EDT)
+		require.Len(t, occurrences, 3)
+		for _, occ := range occurrences {
+			assert.Equal(t, 9, occ.In(newYork).Hour())
+		}
+		assert.Equal(t, 14, occurrences[0].UTC().Hour())
+		assert.Equal(t, 13, occurrences[1].UTC().Hour())
+		assert.Equal(t, 6*24*time.Hour+23*time.Hour, occurrences[1].Sub(occurrences[0]))
+	})
+
+	t.Run("Fall back keeps the local start time", func(t *testing.T) {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		// DST ends on Sunday 27 October 2024 in Berlin
+		startDate := time.Date(2024, 10, 25, 9, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Europe/Berlin",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "DAILY",
+			Interval:  1,
+		}
+
+		rangeStart := time.Date(2024, 10, 25, 0, 0, 0, 0, berlin)
+		rangeEnd := time.Date(2024, 10, 29, 23, 59, 59, 0, berlin)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 5)
+		for i, occ := range occurrences {
+			local := occ.In(berlin)
+			assert.Equal(t, 25+i, local.Day())
+			assert.Equal(t, 9, local.Hour())
+		}
+		assert.Equal(t, 25*time.Hour, occurrences[2].Sub(occurrences[1]))
+	})
+
+	t.Run("Start time inside the DST gap moves forward", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		// 02:30 does not exist on 10 March 2024 in New York
+		startDate := time.Date(2024, 3, 9, 2, 30, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "America/New_York",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "DAILY",
+			Interval:  1,
+		}
+
+		rangeStart := time.Date(2024, 3, 9, 0, 0, 0, 0, newYork)
+		rangeEnd := time.Date(2024, 3, 11, 23, 59, 59, 0, newYork)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 3)
+		gapDay := occurrences[1].In(newYork)
+		assert.Equal(t, 10, gapDay.Day())
+		assert.Equal(t, 3, gapDay.Hour())
+		assert.Equal(t, 30, gapDay.Minute())
+		assert.Equal(t, 2, occurrences[2].In(newYork).Hour())
+	})
+
+	t.Run("Until date is inclusive in the event timezone", func(t *testing.T) {
+		jakarta, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+
+		// 20:00 WIB is 13:00 UTC on the same day; Until is stored as a calendar date
+		startDate := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+		untilDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Asia/Jakarta",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["MO"]`,
+			Until:     &untilDate,
+		}
+
+		rangeStart := time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta)
+		rangeEnd := time.Date(2024, 12, 31, 23, 59, 59, 0, jakarta)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Jan 1, 8, 15
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, 15, occurrences[2].In(jakarta).Day())
+	})
+
+	t.Run("Range boundaries are local days", func(t *testing.T) {
+		jayapura, err := time.LoadLocation("Asia/Jayapura")
+		require.NoError(t, err)
+
+		// Sunday 07:00 WIT is still Saturday in UTC
+		startDate := time.Date(2024, 1, 7, 7, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Asia/Jayapura",
+		}
+
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["SU"]`,
+		}
+
+		rangeStart := time.Date(2024, 1, 14, 0, 0, 0, 0, jayapura)
+		rangeEnd := time.Date(2024, 1, 14, 23, 59, 59, 0, jayapura)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, time.Sunday, occurrences[0].In(jayapura).Weekday())
+		assert.Equal(t, time.Saturday, occurrences[0].UTC().Weekday())
+	})
+
+	t.Run("Count is measured from the series start", func(t *testing.T) {
+		startDate := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Asia/Makassar",
+		}
+
+		count := 3
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["MO"]`,
+			Count:     &count,
+		}
+
+		makassar, err := time.LoadLocation("Asia/Makassar")
+		require.NoError(t, err)
+		rangeStart := time.Date(2024, 1, 10, 0, 0, 0, 0, makassar)
+		rangeEnd := time.Date(2024, 12, 31, 23, 59, 59, 0, makassar)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Only the third occurrence (Jan 15) is left inside the range
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, 15, occurrences[0].In(makassar).Day())
+	})
+}
+
+func TestRecurrenceGenerator_RDates(t *testing.T) {
+	generator := service.NewRecurrenceGenerator()
+
+	// Weekly prayer meeting on Wednesdays 19:00, Holy Week 2025 is April 13-20
+	startDate := time.Date(2025, 4, 2, 19, 0, 0, 0, time.UTC)
+	event := &entity.Event{
+		StartDatetime: startDate,
+		EndDatetime:   startDate.Add(time.Hour),
+	}
+
+	t.Run("Extra dates are added to the rule's dates", func(t *testing.T) {
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			RDates:    `["2025-04-17","2025-04-18T10:00"]`,
+		}
+
+		rangeStart := time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC)
+		rangeEnd := time.Date(2025, 4, 20, 23, 59, 59, 0, time.UTC)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, time.Date(2025, 4, 16, 19, 0, 0, 0, time.UTC), occurrences[0])
+		assert.Equal(t, time.Date(2025, 4, 17, 19, 0, 0, 0, time.UTC), occurrences[1]) // event's start time
+		assert.Equal(t, time.Date(2025, 4, 18, 10, 0, 0, 0, time.UTC), occurrences[2]) // own start time
+	})
+
+	t.Run("Extra date on a rule day replaces that occurrence", func(t *testing.T) {
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			RDates:    `["2025-04-16T17:00"]`,
+		}
+
+		rangeStart := time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC)
+		rangeEnd := time.Date(2025, 4, 16, 23, 59, 59, 0, time.UTC)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, 17, occurrences[0].Hour())
+	})
+
+	t.Run("Count does not limit extra dates", func(t *testing.T) {
+		count := 2
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			Count:     &count,
+			RDates:    `["2025-04-18"]`,
+		}
+
+		rangeEnd := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+		occurrences, err := generator.GenerateOccurrences(event, rule, startDate, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Apr 2 and 9 from the rule, then Good Friday
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, 18, occurrences[2].Day())
+	})
+
+	t.Run("Extra dates outside the series are ignored", func(t *testing.T) {
+		until := time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC)
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			Until:     &until,
+			RDates:    `["2025-03-28","2025-04-18"]`,
+		}
+
+		rangeStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		rangeEnd := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		// Apr 2, 9 and 16; before the first date and after UNTIL are left out
+		assert.Len(t, occurrences, 3)
+	})
+
+	t.Run("Skipped exception removes an extra date", func(t *testing.T) {
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			RDates:    `["2025-04-17"]`,
+		}
+		exceptions := []entity.RecurrenceException{
+			{ExceptionDate: time.Date(2025, 4, 17, 0, 0, 0, 0, time.UTC), IsSkipped: true},
+		}
+
+		rangeStart := time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC)
+		rangeEnd := time.Date(2025, 4, 20, 23, 59, 59, 0, time.UTC)
+		occurrences, err := generator.GenerateOccurrences(event, rule, rangeStart, rangeEnd, exceptions)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, 16, occurrences[0].Day())
+	})
+
+	t.Run("Extra dates keep the event's wall clock", func(t *testing.T) {
+		jakartaEvent := &entity.Event{
+			StartDatetime: startDate,
+			EndDatetime:   startDate.Add(time.Hour),
+			Timezone:      "Asia/Jakarta",
+		}
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			RDates:    `["2025-04-18T10:00"]`,
+		}
+
+		jakarta, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+		rangeStart := time.Date(2025, 4, 18, 0, 0, 0, 0, jakarta)
+		rangeEnd := time.Date(2025, 4, 18, 23, 59, 59, 0, jakarta)
+		occurrences, err := generator.GenerateOccurrences(jakartaEvent, rule, rangeStart, rangeEnd, nil)
+		require.NoError(t, err)
+
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, 10, occurrences[0].In(jakarta).Hour())
+		assert.Equal(t, 3, occurrences[0].UTC().Hour())
+	})
+
+	t.Run("Next occurrence can be an extra date after the rule ends", func(t *testing.T) {
+		count := 1
+		rule := &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["WE"]`,
+			Count:     &count,
+			RDates:    `["2027-04-16"]`,
+		}
+
+		next, err := generator.GetNextOccurrence(event, rule, startDate)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, time.Date(2027, 4, 16, 19, 0, 0, 0, time.UTC), *next)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		until := time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC)
+
+		assert.NoError(t, generator.ValidateRecurrenceRule(&entity.RecurrenceRule{
+			Frequency: "WEEKLY", Interval: 1, RDates: `["2025-04-17","2025-04-18T10:00"]`,
+		}))
+
+		invalidRules := []*entity.RecurrenceRule{
+			{Frequency: "WEEKLY", Interval: 1, RDates: `["17-04-2025"]`},
+			{Frequency: "WEEKLY", Interval: 1, RDates: `["2025-04-18T25:00"]`},
+			{Frequency: "WEEKLY", Interval: 1, RDates: `["2025-04-18","2025-04-18T10:00"]`},
+			{Frequency: "WEEKLY", Interval: 1, RDates: `["2025-04-18"]`, Until: &until},
+		}
+		for _, rule := range invalidRules {
+			assert.Error(t, generator.ValidateRecurrenceRule(rule), "Rule should be invalid: %+v", rule)
+		}
+	})
+
+	t.Run("Normalize sorts extra dates", func(t *testing.T) {
+		normalized, err := generator.NormalizeRDates([]string{"2025-04-18T10:00", " 2025-04-17 "})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-04-17", "2025-04-18T10:00"}, normalized)
+	})
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+func TestEventRegistration_WaitlistPromotionOrder(t *testing.T) {
+	db := SetUpDatabaseConnection()
+	eventRepo := repository.NewEventRepository(db)
+	visitorRepo := repository.NewVisitorRepository(db)
+	eventService := service.NewEventService(eventRepo, repository.NewEventPICRepository(db), repository.NewEventOccurrenceRepository(db), repository.NewVenueRepository(db), nil)
+	registrationService := service.NewEventRegistrationService(repository.NewEventRegistrationRepository(db), eventRepo, repository.NewPersonRepository(db), visitorRepo)
+
+	occurrenceDate := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	testEvent, err := eventService.CreateEvent(&dto.CreateEventRequest{
+		Title:         "Test Event for Waitlist",
+		EventDate:     occurrenceDate,
+		StartTime:     "10:00",
+		EndTime:       "12:00",
+		EventLocation: "Test Location",
+		Type:          "event",
+		Timezone:      "Asia/Jakarta",
+		Capacity:      1,
+	})
+	require.NoError(t, err)
+	defer eventService.DeleteEvent(testEvent.ID)
+
+	// One seat, then three registrants on the waitlist in the order they registered
+	registrations := make([]*dto.EventRegistrationResponse, 4)
+	for i := range registrations {
+		visitor := &entity.Visitor{Name: fmt.Sprintf("Waitlist Visitor %d", i+1)}
+		require.NoError(t, visitorRepo.Create(context.Background(), visitor))
+		defer db.Delete(visitor)
+
+		registrations[i], err = registrationService.Register(testEvent.ID, &dto.CreateEventRegistrationRequest{
+			OccurrenceDate: occurrenceDate,
+			VisitorID:      &visitor.ID,
+		})
+		require.NoError(t, err)
+		defer db.Unscoped().Delete(&entity.EventRegistration{}, "id = ?", registrations[i].ID)
+
+		// Registrations are ordered by creation time; keep them apart
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, entity.EventRegistrationStatusRegistered, registrations[0].Status)
+	for i, registration := range registrations[1:] {
+		assert.Equal(t, entity.EventRegistrationStatusWaitlisted, registration.Status)
+		assert.Equal(t, i+1, registration.WaitlistPosition)
+	}
+
+	t.Run("Cancelling a seat promotes the first on the waitlist", func(t *testing.T) {
+		cancelled, err := registrationService.CancelRegistration(registrations[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, cancelled.Promoted)
+		assert.Equal(t, registrations[1].ID, cancelled.Promoted.ID)
+		assert.Equal(t, entity.EventRegistrationStatusRegistered, cancelled.Promoted.Status)
+
+		// The rest move up
+		third, err := registrationService.GetRegistration(registrations[2].ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, third.WaitlistPosition)
+		fourth, err := registrationService.GetRegistration(registrations[3].ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, fourth.WaitlistPosition)
+	})
+
+	t.Run("New seats are filled in waitlist order", func(t *testing.T) {
+		capacity := 2
+		_, err := eventService.UpdateEvent(testEvent.ID, &dto.UpdateEventRequest{Capacity: &capacity})
+		require.NoError(t, err)
+
+		promoted, err := registrationService.FillFromWaitlist(testEvent.ID, occurrenceDate)
+		require.NoError(t, err)
+		require.Len(t, promoted, 1)
+		assert.Equal(t, registrations[2].ID, promoted[0].ID)
+
+		fourth, err := registrationService.GetRegistration(registrations[3].ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.EventRegistrationStatusWaitlisted, fourth.Status)
+		assert.Equal(t, 1, fourth.WaitlistPosition)
+	})
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"gorm.io/gorm"
+)
+
+// reminderEventRepo serves a single recurring event to the reminder scan
+type reminderEventRepo struct {
+	repository.EventRepository
+	event *entity.Event
+}
+
+func (r *reminderEventRepo) GetEventsWithRecurrenceInRange(startDate, endDate time.Time) ([]entity.Event, error) {
+	return []entity.Event{*r.event}, nil
+}
+
+func (r *reminderEventRepo) GetByDateRange(startDate, endDate time.Time, filters repository.EventFilters) ([]entity.Event, error) {
+	return nil, nil
+}
+
+func (r *reminderEventRepo) GetRecurrenceExceptions(eventID uuid.UUID) ([]entity.RecurrenceException, error) {
+	return nil, nil
+}
+
+// reminderLogRepo records the reminders claimed
+type reminderLogRepo struct {
+	claimed []entity.EventReminderLog
+}
+
+func (r *reminderLogRepo) Claim(log *entity.EventReminderLog) (bool, error) {
+	log.ID = uuid.New()
+	r.claimed = append(r.claimed, *log)
+	return true, nil
+}
+
+func (r *reminderLogRepo) Update(log *entity.EventReminderLog) error {
+	return nil
+}
+
+// reminderUserRepo has no accounts, so reminders go out by email only
+type reminderUserRepo struct {
+	repository.UserRepository
+}
+
+func (r *reminderUserRepo) GetByPersonID(ctx context.Context, personID uuid.UUID) (*entity.User, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestEventReminderService_EarlyMorningOccurrence(t *testing.T) {
+	// Dawn prayer every Monday at 04:30 WIB (UTC+7), stored on its wall clock
+	start := time.Date(2025, 2, 24, 4, 30, 0, 0, time.UTC)
+	person := entity.Person{ID: uuid.New(), Nama: "Andi"}
+	event := &entity.Event{
+		ID:            uuid.New(),
+		Title:         "Doa Subuh",
+		EventDate:     start,
+		StartDatetime: start,
+		EndDatetime:   start.Add(time.Hour),
+		Timezone:      "Asia/Jakarta",
+		RecurrenceRule: &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["MO"]`,
+		},
+		EventPICs: []entity.EventPIC{{
+			ID:                uuid.New(),
+			PersonID:          person.ID,
+			Person:            person,
+			IsActive:          true,
+			NotifyOnReminders: true,
+			StartDate:         start,
+		}},
+	}
+
+	logRepo := &reminderLogRepo{}
+	reminderService := service.NewEventReminderService(logRepo, &reminderEventRepo{event: event}, &reminderUserRepo{}, nil, []time.Duration{24 * time.Hour})
+
+	// 05:00 WIB on Sunday; the 24 hour window ends at 05:00 WIB on Monday, after the 04:30 occurrence
+	now := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+	sent, err := reminderService.SendDueReminders(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.Len(t, logRepo.claimed, 1)
+	reminder := logRepo.claimed[0]
+	assert.Equal(t, "2025-03-03", reminder.OccurrenceDate.Format("2006-01-02"))
+	assert.True(t, reminder.OccurrenceStart.Equal(time.Date(2025, 3, 2, 21, 30, 0, 0, time.UTC)))
+	assert.Equal(t, 24*60, reminder.OffsetMinutes)
+	assert.Equal(t, person.ID, reminder.PersonID)
+}
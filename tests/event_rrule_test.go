@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+func TestRRule_ErrorPart(t *testing.T) {
+	generator := service.NewRecurrenceGenerator()
+
+	tests := []struct {
+		name  string
+		rrule string
+		part  string
+	}{
+		{"Empty rule", "RRULE:", "FREQ"},
+		{"Missing FREQ", "INTERVAL=2", "FREQ"},
+		{"Sub-daily frequency", "FREQ=HOURLY", "FREQ=HOURLY"},
+		{"Unknown frequency", "FREQ=SOMETIMES", "FREQ=SOMETIMES"},
+		{"Part without value", "FREQ=DAILY;COUNT", "COUNT"},
+		{"Duplicate part", "FREQ=DAILY;FREQ=WEEKLY", "FREQ=WEEKLY"},
+		{"Zero interval", "FREQ=WEEKLY;INTERVAL=0", "INTERVAL=0"},
+		{"Non-numeric count", "FREQ=WEEKLY;COUNT=ten", "COUNT=ten"},
+		{"Malformed until", "FREQ=WEEKLY;UNTIL=2025-12-31", "UNTIL=2025-12-31"},
+		{"COUNT with UNTIL", "FREQ=WEEKLY;COUNT=5;UNTIL=20251231", "UNTIL=20251231"},
+		{"Invalid weekday", "FREQ=WEEKLY;BYDAY=MO,XY", "BYDAY=MO,XY"},
+		{"BYDAY on a daily rule", "FREQ=DAILY;BYDAY=MO", "BYDAY=MO"},
+		{"Weekday ordinal on a weekly rule", "FREQ=WEEKLY;BYDAY=2SU", "BYDAY=2SU"},
+		{"Ordinals on several weekdays", "FREQ=MONTHLY;BYDAY=1MO,2TU", "BYDAY=1MO,2TU"},
+		{"Ordinal with BYSETPOS", "FREQ=MONTHLY;BYDAY=2SU;BYSETPOS=1", "BYSETPOS=1"},
+		{"BYSETPOS without BYDAY", "FREQ=MONTHLY;BYSETPOS=1", "BYSETPOS=1"},
+		{"Month day out of range", "FREQ=MONTHLY;BYMONTHDAY=32", "BYMONTHDAY=32"},
+		{"BYMONTHDAY with BYDAY", "FREQ=MONTHLY;BYDAY=SU;BYMONTHDAY=1", "BYMONTHDAY=1"},
+		{"BYMONTH on a weekly rule", "FREQ=WEEKLY;BYMONTH=3", "BYMONTH=3"},
+		{"Invalid week start", "FREQ=WEEKLY;WKST=XX", "WKST=XX"},
+		{"Unsupported part", "FREQ=DAILY;BYHOUR=9", "BYHOUR=9"},
+		{"Unknown part", "FREQ=DAILY;FOO=1", "FOO=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generator.ParseRRule(tt.rrule, time.UTC)
+			require.Error(t, err)
+
+			var rruleErr *service.RRuleError
+			require.True(t, errors.As(err, &rruleErr), "expected an RRuleError, got %v", err)
+			assert.Equal(t, tt.part, rruleErr.Part)
+		})
+	}
+}
+
+func TestRRule_RoundTrip(t *testing.T) {
+	generator := service.NewRecurrenceGenerator()
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		rrule    string
+		loc      *time.Location
+		expected string
+	}{
+		{"Daily", "FREQ=DAILY", time.UTC, "FREQ=DAILY"},
+		{"Every other week on several days", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR", time.UTC, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR"},
+		{"Weekly with COUNT", "FREQ=WEEKLY;COUNT=10;BYDAY=SU", time.UTC, "FREQ=WEEKLY;COUNT=10;BYDAY=SU"},
+		{"Monthly with UNTIL", "FREQ=MONTHLY;UNTIL=20251231T235959Z;BYMONTHDAY=1,15", time.UTC, "FREQ=MONTHLY;UNTIL=20251231T235959Z;BYMONTHDAY=1,15"},
+		{"UNTIL in the event's zone", "FREQ=WEEKLY;UNTIL=20251231T165959Z;BYDAY=TH", jakarta, "FREQ=WEEKLY;UNTIL=20251231T165959Z;BYDAY=TH"},
+		{"UNTIL as a date", "FREQ=DAILY;UNTIL=20251231", time.UTC, "FREQ=DAILY;UNTIL=20251231T235959Z"},
+		{"Second Sunday", "FREQ=MONTHLY;BYDAY=SU;BYSETPOS=2", time.UTC, "FREQ=MONTHLY;BYDAY=SU;BYSETPOS=2"},
+		{"Weekday ordinal becomes BYSETPOS", "RRULE:freq=monthly;byday=-1FR", time.UTC, "FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1"},
+		{"Christmas", "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25", time.UTC, "FREQ=YEARLY;BYMONTHDAY=25;BYMONTH=12"},
+		{"Year day", "FREQ=YEARLY;BYYEARDAY=100,-1", time.UTC, "FREQ=YEARLY;BYYEARDAY=100,-1"},
+		{"Default interval and week start are dropped", "FREQ=WEEKLY;INTERVAL=1;BYDAY=TU;WKST=MO", time.UTC, "FREQ=WEEKLY;BYDAY=TU"},
+		{"Week start", "FREQ=WEEKLY;BYDAY=TU;WKST=SU", time.UTC, "FREQ=WEEKLY;BYDAY=TU;WKST=SU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := generator.ParseRRule(tt.rrule, tt.loc)
+			require.NoError(t, err)
+
+			rule := recurrenceRuleFromRequest(t, req)
+			require.NoError(t, generator.ValidateRecurrenceRule(rule))
+
+			formatted := generator.FormatRRule(rule, tt.loc)
+			assert.Equal(t, tt.expected, formatted)
+
+			// The formatted rule is stable: parsing and formatting it again gives the same value
+			reparsed, err := generator.ParseRRule(formatted, tt.loc)
+			require.NoError(t, err)
+			assert.Equal(t, formatted, generator.FormatRRule(recurrenceRuleFromRequest(t, reparsed), tt.loc))
+		})
+	}
+}
+
+func TestRRule_CountWithUntil(t *testing.T) {
+	generator := service.NewRecurrenceGenerator()
+	count := 5
+	until := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	rule := &entity.RecurrenceRule{Frequency: "WEEKLY", Interval: 1, Count: &count, Until: &until}
+	assert.Error(t, generator.ValidateRecurrenceRule(rule))
+
+	rule.Until = nil
+	assert.NoError(t, generator.ValidateRecurrenceRule(rule))
+}
+
+// recurrenceRuleFromRequest stores a parsed rule the way the event service does
+func recurrenceRuleFromRequest(t *testing.T, req *dto.CreateRecurrenceRuleRequest) *entity.RecurrenceRule {
+	t.Helper()
+
+	toJSON := func(value interface{}, empty bool) string {
+		if empty {
+			return ""
+		}
+		data, err := json.Marshal(value)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	rule := &entity.RecurrenceRule{
+		Frequency:  req.Frequency,
+		Interval:   req.Interval,
+		ByWeekday:  toJSON(req.ByWeekday, len(req.ByWeekday) == 0),
+		ByMonthDay: toJSON(req.ByMonthDay, len(req.ByMonthDay) == 0),
+		ByMonth:    toJSON(req.ByMonth, len(req.ByMonth) == 0),
+		BySetPos:   toJSON(req.BySetPos, len(req.BySetPos) == 0),
+		ByYearDay:  toJSON(req.ByYearDay, len(req.ByYearDay) == 0),
+		WeekStart:  req.WeekStart,
+		Count:      req.Count,
+	}
+	if req.Until != nil {
+		until, err := time.Parse("2006-01-02", *req.Until)
+		require.NoError(t, err)
+		rule.Until = &until
+	}
+	return rule
+}
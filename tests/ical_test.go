@@ -0,0 +1,429 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// icalLines joins content lines the way ICalWriter ends them
+func icalLines(lines ...string) string {
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func TestICalWriter_Output(t *testing.T) {
+	w := utils.NewICalWriter()
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Line("DTSTART;TZID=Asia/Jakarta", "20250302T090000")
+	w.Line("SUMMARY", utils.ICalEscapeText("Prayer; worship, and fellowship"))
+	w.Line("END", "VCALENDAR")
+
+	expected := icalLines(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"DTSTART;TZID=Asia/Jakarta:20250302T090000",
+		`SUMMARY:Prayer\; worship\, and fellowship`,
+		"END:VCALENDAR",
+	)
+	assert.Equal(t, expected, string(w.Bytes()))
+}
+
+func TestICalFoldLine(t *testing.T) {
+	t.Run("Lines up to 75 octets are kept", func(t *testing.T) {
+		line := strings.Repeat("a", 75)
+		assert.Equal(t, line, utils.ICalFoldLine(line))
+	})
+
+	t.Run("Longer lines continue with a space", func(t *testing.T) {
+		line := strings.Repeat("a", 75) + strings.Repeat("b", 74) + "c"
+		expected := strings.Repeat("a", 75) + "\r\n " + strings.Repeat("b", 74) + "\r\n c"
+		assert.Equal(t, expected, utils.ICalFoldLine(line))
+	})
+
+	t.Run("Multi-byte characters are not split", func(t *testing.T) {
+		// "é" is two octets, so the 38th one would end at octet 77
+		line := "X" + strings.Repeat("é", 40)
+		expected := "X" + strings.Repeat("é", 37) + "\r\n " + strings.Repeat("é", 3)
+		assert.Equal(t, expected, utils.ICalFoldLine(line))
+	})
+
+	t.Run("Folded lines unfold to the original value", func(t *testing.T) {
+		description := strings.Repeat("Ibadah raya bersama jemaat Every Nation, semua datang! ", 5)
+		w := utils.NewICalWriter()
+		w.Line("BEGIN", "VCALENDAR")
+		w.Line("DESCRIPTION", utils.ICalEscapeText(description))
+		w.Line("END", "VCALENDAR")
+
+		for _, physical := range strings.Split(strings.TrimSuffix(string(w.Bytes()), "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(physical), 75)
+		}
+
+		calendar, err := utils.ParseICal(w.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, description, utils.ICalUnescapeText(calendar.PropertyValue("DESCRIPTION")))
+	})
+}
+
+func TestICalEscapeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"Plain text", "Sunday Service", "Sunday Service"},
+		{"Semicolon and comma", "Jakarta; Bandung, Surabaya", `Jakarta\; Bandung\, Surabaya`},
+		{"Backslash", `C:\events`, `C:\\events`},
+		{"LF newline", "line 1\nline 2", `line 1\nline 2`},
+		{"CRLF newline", "line 1\r\nline 2", `line 1\nline 2`},
+		{"CR newline", "line 1\rline 2", `line 1\nline 2`},
+		{"Escaped escape", `\n`, `\\n`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			escaped := utils.ICalEscapeText(tt.value)
+			assert.Equal(t, tt.expected, escaped)
+			assert.Equal(t, strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(tt.value), utils.ICalUnescapeText(escaped))
+		})
+	}
+}
+
+func TestICalWriteTimezone(t *testing.T) {
+	t.Run("Zone with daylight saving transitions", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		w := utils.NewICalWriter()
+		utils.ICalWriteTimezone(w, loc,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			time.Date(2025, 12, 31, 23, 59, 59, 0, loc))
+
+		expected := icalLines(
+			"BEGIN:VTIMEZONE",
+			"TZID:America/New_York",
+			"BEGIN:STANDARD",
+			"DTSTART:20250101T000000",
+			"TZOFFSETFROM:-0500",
+			"TZOFFSETTO:-0500",
+			"TZNAME:EST",
+			"END:STANDARD",
+			"BEGIN:DAYLIGHT",
+			"DTSTART:20250309T020000",
+			"TZOFFSETFROM:-0500",
+			"TZOFFSETTO:-0400",
+			"TZNAME:EDT",
+			"END:DAYLIGHT",
+			"BEGIN:STANDARD",
+			"DTSTART:20251102T020000",
+			"TZOFFSETFROM:-0400",
+			"TZOFFSETTO:-0500",
+			"TZNAME:EST",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		)
+		assert.Equal(t, expected, string(w.Bytes()))
+	})
+
+	t.Run("Zone without transitions", func(t *testing.T) {
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+
+		w := utils.NewICalWriter()
+		utils.ICalWriteTimezone(w, loc,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			time.Date(2025, 12, 31, 23, 59, 59, 0, loc))
+
+		expected := icalLines(
+			"BEGIN:VTIMEZONE",
+			"TZID:Asia/Jakarta",
+			"BEGIN:STANDARD",
+			"DTSTART:20250101T000000",
+			"TZOFFSETFROM:+0700",
+			"TZOFFSETTO:+0700",
+			"TZNAME:WIB",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		)
+		assert.Equal(t, expected, string(w.Bytes()))
+	})
+}
+
+// icalEventRepo serves a single event and its exceptions to the iCal export
+type icalEventRepo struct {
+	repository.EventRepository
+	event      *entity.Event
+	exceptions []entity.RecurrenceException
+}
+
+func (r *icalEventRepo) GetByID(id uuid.UUID) (*entity.Event, error) {
+	return r.event, nil
+}
+
+func (r *icalEventRepo) GetRecurrenceExceptions(eventID uuid.UUID) ([]entity.RecurrenceException, error) {
+	return r.exceptions, nil
+}
+
+func TestEventICalService_ExportExceptions(t *testing.T) {
+	eventID := uuid.MustParse("6f1c1d2e-8a4b-4c3d-9e5f-0a1b2c3d4e5f")
+	until := time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC) // 09:00 on the event's wall clock
+	overrideStart := time.Date(2025, 3, 16, 10, 0, 0, 0, time.UTC)
+	overrideEnd := time.Date(2025, 3, 16, 12, 0, 0, 0, time.UTC)
+
+	repo := &icalEventRepo{
+		event: &entity.Event{
+			ID:            eventID,
+			Title:         "Sunday Service, Jakarta",
+			Type:          "ibadah",
+			EventDate:     start,
+			EventLocation: "Main Hall",
+			StartDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			Timezone:      "Asia/Jakarta",
+			IsPublic:      true,
+			RecurrenceRule: &entity.RecurrenceRule{
+				Frequency: "WEEKLY",
+				Interval:  1,
+				ByWeekday: `["SU"]`,
+				WeekStart: "MO",
+				Until:     &until,
+			},
+			Timestamp: entity.Timestamp{
+				CreatedAt: time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		exceptions: []entity.RecurrenceException{
+			{
+				EventID:       eventID,
+				ExceptionDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+				IsSkipped:     true,
+			},
+			{
+				EventID:       eventID,
+				ExceptionDate: time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC),
+				OverrideStart: &overrideStart,
+				OverrideEnd:   &overrideEnd,
+				Notes:         "Combined service, bring chairs",
+			},
+		},
+	}
+
+	icalService := service.NewEventICalService(repo, nil)
+	data, err := icalService.ExportEvent(eventID)
+	require.NoError(t, err)
+
+	eventProperties := []string{
+		"UID:6f1c1d2e-8a4b-4c3d-9e5f-0a1b2c3d4e5f@en-indo-be",
+		"DTSTAMP:20250201T030000Z",
+		"CREATED:20250101T030000Z",
+		"LAST-MODIFIED:20250201T030000Z",
+		`SUMMARY:Sunday Service\, Jakarta`,
+		"LOCATION:Main Hall",
+		"CATEGORIES:ibadah",
+		"CLASS:PUBLIC",
+	}
+
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Every Nation Indonesia//Events//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Sunday Service\, Jakarta`,
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Jakarta",
+		"BEGIN:STANDARD",
+		"DTSTART:20250302T000000",
+		"TZOFFSETFROM:+0700",
+		"TZOFFSETTO:+0700",
+		"TZNAME:WIB",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+	)
+	lines = append(lines, eventProperties...)
+	lines = append(lines,
+		"DTSTART;TZID=Asia/Jakarta:20250302T090000",
+		"DTEND;TZID=Asia/Jakarta:20250302T110000",
+		"RRULE:FREQ=WEEKLY;UNTIL=20250330T165959Z;BYDAY=SU",
+		"EXDATE;TZID=Asia/Jakarta:20250309T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+	)
+	lines = append(lines, eventProperties...)
+	lines = append(lines,
+		"RECURRENCE-ID;TZID=Asia/Jakarta:20250316T090000",
+		"DTSTART;TZID=Asia/Jakarta:20250316T100000",
+		"DTEND;TZID=Asia/Jakarta:20250316T120000",
+		`COMMENT:Combined service\, bring chairs`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	assert.Equal(t, icalLines(lines...), string(data))
+}
+
+func TestParseICal(t *testing.T) {
+	t.Run("Components, parameters and folded lines", func(t *testing.T) {
+		data := "BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"UID:abc@example.com\r\n" +
+			"DTSTART;TZID=\"Asia/Jakarta\":20250302T090000\r\n" +
+			"DESCRIPTION:Ibadah raya\\, semua\r\n" +
+			"  datang\r\n" +
+			"EXDATE;TZID=Asia/Jakarta:20250309T090000\r\n" +
+			"EXDATE;TZID=Asia/Jakarta:20250316T090000\r\n" +
+			"BEGIN:VALARM\r\n" +
+			"TRIGGER:-PT15M\r\n" +
+			"END:VALARM\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+
+		calendar, err := utils.ParseICal([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, "VCALENDAR", calendar.Name)
+		assert.Equal(t, "2.0", calendar.PropertyValue("VERSION"))
+
+		vevents := calendar.ComponentsNamed("VEVENT")
+		require.Len(t, vevents, 1)
+		vevent := vevents[0]
+
+		dtstart := vevent.Property("DTSTART")
+		require.NotNil(t, dtstart)
+		assert.Equal(t, "Asia/Jakarta", dtstart.Params["TZID"])
+		assert.Equal(t, "20250302T090000", dtstart.Value)
+		assert.Equal(t, "Ibadah raya, semua datang", utils.ICalUnescapeText(vevent.PropertyValue("DESCRIPTION")))
+		assert.Len(t, vevent.PropertiesNamed("EXDATE"), 2)
+		require.Len(t, vevent.ComponentsNamed("VALARM"), 1)
+		assert.Equal(t, "-PT15M", vevent.ComponentsNamed("VALARM")[0].PropertyValue("TRIGGER"))
+	})
+
+	t.Run("LF line endings and lower-case names", func(t *testing.T) {
+		data := "begin:vcalendar\nversion:2.0\nend:vcalendar\n"
+		calendar, err := utils.ParseICal([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, "2.0", calendar.PropertyValue("VERSION"))
+	})
+
+	t.Run("Quoted parameter values may contain colons", func(t *testing.T) {
+		data := "BEGIN:VCALENDAR\r\nATTENDEE;CN=\"Pastor: John\":mailto:john@example.com\r\nEND:VCALENDAR\r\n"
+		calendar, err := utils.ParseICal([]byte(data))
+		require.NoError(t, err)
+		attendee := calendar.Property("ATTENDEE")
+		require.NotNil(t, attendee)
+		assert.Equal(t, "Pastor: John", attendee.Params["CN"])
+		assert.Equal(t, "mailto:john@example.com", attendee.Value)
+	})
+
+	invalid := []struct {
+		name string
+		data string
+	}{
+		{"Not a calendar", "BEGIN:VEVENT\r\nEND:VEVENT\r\n"},
+		{"Missing END", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{"END without BEGIN", "BEGIN:VCALENDAR\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{"Property outside a component", "VERSION:2.0\r\nBEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"},
+		{"Line without a colon", "BEGIN:VCALENDAR\r\nVERSION\r\nEND:VCALENDAR\r\n"},
+		{"Parameter without a value", "BEGIN:VCALENDAR\r\nDTSTART;TZID:20250302\r\nEND:VCALENDAR\r\n"},
+		{"Two calendars", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\nBEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"},
+		{"Empty document", ""},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.ParseICal([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestICalParseDateTime(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		value    string
+		params   map[string]string
+		expected time.Time
+		allDay   bool
+	}{
+		{"Date", "20250302", map[string]string{"VALUE": "DATE"}, time.Date(2025, 3, 2, 0, 0, 0, 0, jakarta), true},
+		{"Date without VALUE", "20250302", map[string]string{}, time.Date(2025, 3, 2, 0, 0, 0, 0, jakarta), true},
+		{"Floating date-time", "20250302T090000", map[string]string{}, time.Date(2025, 3, 2, 9, 0, 0, 0, jakarta), false},
+		{"UTC date-time", "20250302T020000Z", map[string]string{}, time.Date(2025, 3, 2, 9, 0, 0, 0, jakarta), false},
+		{"Date-time with TZID", "20250309T090000", map[string]string{"TZID": "America/New_York"}, time.Date(2025, 3, 9, 9, 0, 0, 0, newYork), false},
+		{"UTC ignores TZID", "20250302T020000Z", map[string]string{"TZID": "America/New_York"}, time.Date(2025, 3, 2, 9, 0, 0, 0, jakarta), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, allDay, err := utils.ICalParseDateTime(tt.value, tt.params, jakarta)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(parsed), "expected %s, got %s", tt.expected, parsed)
+			assert.Equal(t, tt.expected.Location().String(), parsed.Location().String())
+			assert.Equal(t, tt.allDay, allDay)
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		value  string
+		params map[string]string
+	}{
+		{"Unknown TZID", "20250302T090000", map[string]string{"TZID": "Mars/Olympus"}},
+		{"Malformed date", "20251302", map[string]string{"VALUE": "DATE"}},
+		{"Malformed UTC date-time", "20250302T0900Z", map[string]string{}},
+		{"Malformed date-time", "2025-03-02T09:00", map[string]string{}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := utils.ICalParseDateTime(tt.value, tt.params, jakarta)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestICalParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"PT45S", 45 * time.Second},
+		{"P1D", 24 * time.Hour},
+		{"P1DT2H", 26 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"+PT15M", 15 * time.Minute},
+		{"-PT15M", -15 * time.Minute},
+		{"-P1W", -7 * 24 * time.Hour},
+		{"P0D", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, err := utils.ICalParseDuration(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+
+	for _, value := range []string{"", "1H", "P", "PT", "P1DT", "PTH", "P1H", "PT1D", "P1W2", "P1X"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := utils.ICalParseDuration(value)
+			assert.Error(t, err)
+		})
+	}
+}
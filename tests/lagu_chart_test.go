@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+// chartLaguRepo serves a single song to the chart endpoints
+type chartLaguRepo struct {
+	repository.LaguRepository
+	lagu *entity.Lagu
+}
+
+func (r *chartLaguRepo) GetByID(id uuid.UUID) (*entity.Lagu, error) {
+	return r.lagu, nil
+}
+
+func TestLaguChart_TransposeSpelling(t *testing.T) {
+	tests := []struct {
+		name        string
+		nadaDasar   string
+		chart       string
+		req         dto.LaguChartRequest
+		key         string
+		accidentals string
+		expected    string
+	}{
+		{
+			name:        "Up to a flat key",
+			nadaDasar:   "G",
+			chart:       "{key: G}\n[G]Amazing [C]grace, how [D7]sweet the [Em]sound [G/B]",
+			req:         dto.LaguChartRequest{Key: "Bb"},
+			key:         "Bb",
+			accidentals: "flat",
+			expected:    "{key: Bb}\n[Bb]Amazing [Eb]grace, how [F7]sweet the [Gm]sound [Bb/D]",
+		},
+		{
+			name:        "Up to a sharp key",
+			nadaDasar:   "G",
+			chart:       "[G]Amazing [C]grace, how [D7]sweet the [Em]sound [G/B]",
+			req:         dto.LaguChartRequest{Key: "E"},
+			key:         "E",
+			accidentals: "sharp",
+			expected:    "[E]Amazing [A]grace, how [B7]sweet the [C#m]sound [E/G#]",
+		},
+		{
+			name:        "Sharp source into a flat key",
+			nadaDasar:   "D",
+			chart:       "[D]Holy [G]holy [A]holy [Bm]Lord [F#m]God",
+			req:         dto.LaguChartRequest{Key: "Eb"},
+			key:         "Eb",
+			accidentals: "flat",
+			expected:    "[Eb]Holy [Ab]holy [Bb]holy [Cm]Lord [Gm]God",
+		},
+		{
+			name:        "Flat source into a sharp key",
+			nadaDasar:   "Bb",
+			chart:       "[Bb]Great is [Eb]Thy [F]faith[Gm]fulness [Bb/D]",
+			req:         dto.LaguChartRequest{Key: "A"},
+			key:         "A",
+			accidentals: "sharp",
+			expected:    "[A]Great is [D]Thy [E]faith[F#m]fulness [A/C#]",
+		},
+		{
+			name:        "Minor key",
+			nadaDasar:   "Am",
+			chart:       "[Am]Kyrie [F]eleison [G]Christe [E7]eleison",
+			req:         dto.LaguChartRequest{Key: "Dm"},
+			key:         "Dm",
+			accidentals: "flat",
+			expected:    "[Dm]Kyrie [Bb]eleison [C]Christe [A7]eleison",
+		},
+		{
+			name:        "Key without a signature is renamed",
+			nadaDasar:   "G",
+			chart:       "[G]Amazing [C]grace",
+			req:         dto.LaguChartRequest{Key: "A#"},
+			key:         "Bb",
+			accidentals: "flat",
+			expected:    "[Bb]Amazing [Eb]grace",
+		},
+		{
+			name:        "Requested accidentals win over the key",
+			nadaDasar:   "G",
+			chart:       "[G]Amazing [C]grace [G/B]",
+			req:         dto.LaguChartRequest{Key: "Bb", Accidentals: "sharp"},
+			key:         "Bb",
+			accidentals: "sharp",
+			expected:    "[A#]Amazing [D#]grace [A#/D]",
+		},
+		{
+			name:        "Text that is not a chord is kept",
+			nadaDasar:   "C",
+			chart:       "[N.C.]Hallelujah [C]amen",
+			req:         dto.LaguChartRequest{Key: "Db"},
+			key:         "Db",
+			accidentals: "flat",
+			expected:    "[N.C.]Hallelujah [Db]amen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &chartLaguRepo{lagu: &entity.Lagu{ID: uuid.New(), NadaDasar: tt.nadaDasar, Chart: tt.chart}}
+			laguService := service.NewLaguService(repo, nil, nil)
+
+			response, err := laguService.GetLaguChart(repo.lagu.ID, &tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, response.Key)
+			assert.Equal(t, tt.accidentals, response.Accidentals)
+			assert.Equal(t, tt.expected, response.Chart)
+		})
+	}
+
+	t.Run("Transposing back restores the chart", func(t *testing.T) {
+		chart := "[Bb]Great is [Eb]Thy [F]faith[Gm]fulness [Bb/D]"
+		repo := &chartLaguRepo{lagu: &entity.Lagu{ID: uuid.New(), NadaDasar: "Bb", Chart: chart}}
+		laguService := service.NewLaguService(repo, nil, nil)
+
+		up, err := laguService.GetLaguChart(repo.lagu.ID, &dto.LaguChartRequest{Key: "D"})
+		require.NoError(t, err)
+
+		repo.lagu.NadaDasar = up.Key
+		repo.lagu.Chart = up.Chart
+		back, err := laguService.GetLaguChart(repo.lagu.ID, &dto.LaguChartRequest{Key: "Bb"})
+		require.NoError(t, err)
+		assert.Equal(t, chart, back.Chart)
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		repo := &chartLaguRepo{lagu: &entity.Lagu{ID: uuid.New(), NadaDasar: "G", Chart: "[G]Amazing"}}
+		laguService := service.NewLaguService(repo, nil, nil)
+
+		_, err := laguService.GetLaguChart(repo.lagu.ID, &dto.LaguChartRequest{Key: "H"})
+		assert.Error(t, err)
+	})
+}
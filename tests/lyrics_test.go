@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/utils"
+)
+
+// lyricLines returns n numbered lyric lines starting at first
+func lyricLines(first, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Line %d", first+i)
+	}
+	return lines
+}
+
+func TestSplitLyricSheet_SlideBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    int
+		maxLines int
+		sizes    []int
+	}{
+		{"Fits on one slide", 4, 4, []int{4}},
+		{"Shorter than a slide", 3, 4, []int{3}},
+		{"One line over", 5, 4, []int{2, 3}},
+		{"Exactly two slides", 8, 4, []int{4, 4}},
+		{"Uneven split", 10, 4, []int{3, 3, 4}},
+		{"Nine lines", 9, 4, []int{3, 3, 3}},
+		{"One line per slide", 3, 1, []int{1, 1, 1}},
+		{"Default slide size", 6, 0, []int{3, 3}},
+		{"Largest slides", 13, utils.MaxSlideLines, []int{6, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := lyricLines(1, tt.lines)
+			sheet := utils.SplitLyricSheet(strings.Join(lines, "\n"), tt.maxLines)
+			require.Len(t, sheet.Sections, 1)
+
+			var sizes []int
+			var joined []string
+			for _, slide := range sheet.Sections[0].Slides {
+				sizes = append(sizes, len(slide))
+				joined = append(joined, slide...)
+			}
+			assert.Equal(t, tt.sizes, sizes)
+			// Slides keep every line, in order
+			assert.Equal(t, lines, joined)
+		})
+	}
+}
+
+func TestSplitLyricSheet_Stanzas(t *testing.T) {
+	t.Run("Slides never cross a blank line", func(t *testing.T) {
+		lyrics := strings.Join(lyricLines(1, 2), "\n") + "\n\n\n" + strings.Join(lyricLines(3, 2), "\r\n")
+		sheet := utils.SplitLyricSheet(lyrics, 4)
+
+		require.Len(t, sheet.Sections, 2)
+		assert.Equal(t, [][]string{{"Line 1", "Line 2"}}, sheet.Sections[0].Slides)
+		assert.Equal(t, [][]string{{"Line 3", "Line 4"}}, sheet.Sections[1].Slides)
+		assert.Equal(t, []string{"v1", "v2"}, sheet.Order)
+	})
+
+	t.Run("Label lines start a stanza and are not projected", func(t *testing.T) {
+		lyrics := "Bait 1:\nLine 1\nLine 2\n\n[Reff]\nLine 3\nLine 4\n\nBridge\nLine 5"
+		sheet := utils.SplitLyricSheet(lyrics, 4)
+
+		require.Len(t, sheet.Sections, 3)
+		assert.Equal(t, "v1", sheet.Sections[0].Name)
+		assert.Equal(t, "Bait 1", sheet.Sections[0].Label)
+		assert.Equal(t, [][]string{{"Line 1", "Line 2"}}, sheet.Sections[0].Slides)
+		assert.Equal(t, "c1", sheet.Sections[1].Name)
+		assert.Equal(t, "Reff", sheet.Sections[1].Label)
+		assert.Equal(t, [][]string{{"Line 3", "Line 4"}}, sheet.Sections[1].Slides)
+		assert.Equal(t, "b1", sheet.Sections[2].Name)
+		assert.Equal(t, [][]string{{"Line 5"}}, sheet.Sections[2].Slides)
+	})
+
+	t.Run("A repeated chorus is kept once", func(t *testing.T) {
+		lyrics := "Verse 1\nLine 1\n\nChorus\nLine 2\nLine 3\n\nVerse 2\nLine 4\n\nChorus\nLine 2\nLine 3\n\nChorus"
+		sheet := utils.SplitLyricSheet(lyrics, 4)
+
+		require.Len(t, sheet.Sections, 3)
+		assert.Equal(t, []string{"v1", "c1", "v2", "c1", "c1"}, sheet.Order)
+	})
+
+	t.Run("A long stanza is split evenly", func(t *testing.T) {
+		lyrics := "Chorus\n" + strings.Join(lyricLines(1, 6), "\n")
+		sheet := utils.SplitLyricSheet(lyrics, 4)
+
+		require.Len(t, sheet.Sections, 1)
+		assert.Equal(t, [][]string{lyricLines(1, 3), lyricLines(4, 3)}, sheet.Sections[0].Slides)
+	})
+
+	t.Run("Empty lyrics have no slides", func(t *testing.T) {
+		sheet := utils.SplitLyricSheet(" \n\n \n", 4)
+		assert.Empty(t, sheet.Sections)
+		assert.Empty(t, sheet.Order)
+	})
+}
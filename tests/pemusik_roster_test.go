@@ -0,0 +1,282 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zemetia/en-indo-be/dto"
+	"github.com/zemetia/en-indo-be/entity"
+	"github.com/zemetia/en-indo-be/repository"
+	"github.com/zemetia/en-indo-be/service"
+)
+
+// rosterEventRepo serves a single recurring event without exceptions
+type rosterEventRepo struct {
+	repository.EventRepository
+	event *entity.Event
+}
+
+func (r *rosterEventRepo) GetByID(id uuid.UUID) (*entity.Event, error) {
+	return r.event, nil
+}
+
+func (r *rosterEventRepo) GetRecurrenceExceptions(eventID uuid.UUID) ([]entity.RecurrenceException, error) {
+	return nil, nil
+}
+
+// rosterPemusikRepo keeps requirements, availability and roster places in memory
+type rosterPemusikRepo struct {
+	repository.PemusikRepository
+	kebutuhan    []entity.KebutuhanPemusik
+	ketersediaan []entity.KetersediaanPemusik
+	jadwal       []entity.JadwalPemusik
+}
+
+func (r *rosterPemusikRepo) GetKebutuhan(eventID uuid.UUID) ([]entity.KebutuhanPemusik, error) {
+	return r.kebutuhan, nil
+}
+
+func (r *rosterPemusikRepo) GetKetersediaan(eventID uuid.UUID, from, to time.Time) ([]entity.KetersediaanPemusik, error) {
+	var result []entity.KetersediaanPemusik
+	for _, k := range r.ketersediaan {
+		if k.EventId == eventID && !k.OccurrenceDate.Before(from) && !k.OccurrenceDate.After(to) {
+			result = append(result, k)
+		}
+	}
+	return result, nil
+}
+
+func (r *rosterPemusikRepo) GetJadwal(eventID uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error) {
+	var result []entity.JadwalPemusik
+	for _, place := range r.jadwal {
+		if place.EventId == eventID && !place.OccurrenceDate.Before(from) && !place.OccurrenceDate.After(to) {
+			result = append(result, place)
+		}
+	}
+	return result, nil
+}
+
+func (r *rosterPemusikRepo) GetJadwalByPersonIDs(personIDs []uuid.UUID, from, to time.Time) ([]entity.JadwalPemusik, error) {
+	wanted := make(map[uuid.UUID]bool)
+	for _, id := range personIDs {
+		wanted[id] = true
+	}
+	var result []entity.JadwalPemusik
+	for _, place := range r.jadwal {
+		if wanted[place.PersonID] && !place.OccurrenceDate.Before(from) && !place.OccurrenceDate.After(to) {
+			result = append(result, place)
+		}
+	}
+	return result, nil
+}
+
+func (r *rosterPemusikRepo) ReplaceGeneratedJadwal(eventID uuid.UUID, occurrenceDate time.Time, jadwal []entity.JadwalPemusik) error {
+	var kept []entity.JadwalPemusik
+	for _, place := range r.jadwal {
+		generated := !place.IsManual && place.Status == entity.JadwalPemusikStatusDraft
+		if place.EventId == eventID && place.OccurrenceDate.Equal(occurrenceDate) && generated {
+			continue
+		}
+		kept = append(kept, place)
+	}
+	for _, place := range jadwal {
+		place.ID = uuid.New()
+		kept = append(kept, place)
+	}
+	r.jadwal = kept
+	return nil
+}
+
+func (r *rosterPemusikRepo) SyncTerjadwal(eventID uuid.UUID, occurrenceDate time.Time) error {
+	return nil
+}
+
+type rosterMusician struct {
+	id        uuid.UUID
+	nama      string
+	alatMusik []string
+}
+
+func newRosterMusician(nama string, alatMusik ...string) rosterMusician {
+	return rosterMusician{id: uuid.New(), nama: nama, alatMusik: alatMusik}
+}
+
+// rosterSundays are the occurrences of the weekly service used by the tests
+var rosterSundays = []time.Time{
+	time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+	time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+	time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC),
+	time.Date(2025, 3, 23, 0, 0, 0, 0, time.UTC),
+	time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC),
+	time.Date(2025, 4, 6, 0, 0, 0, 0, time.UTC),
+}
+
+func newRosterService(t *testing.T, kebutuhan map[string]int) (service.PemusikService, *rosterPemusikRepo, uuid.UUID) {
+	start := time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)
+	event := &entity.Event{
+		ID:            uuid.New(),
+		Title:         "Sunday Service",
+		EventDate:     start,
+		StartDatetime: start,
+		EndDatetime:   start.Add(2 * time.Hour),
+		Timezone:      "Asia/Jakarta",
+		RecurrenceRule: &entity.RecurrenceRule{
+			Frequency: "WEEKLY",
+			Interval:  1,
+			ByWeekday: `["SU"]`,
+		},
+	}
+
+	pemusikRepo := &rosterPemusikRepo{}
+	for alatMusik, jumlah := range kebutuhan {
+		pemusikRepo.kebutuhan = append(pemusikRepo.kebutuhan, entity.KebutuhanPemusik{EventID: event.ID, AlatMusik: alatMusik, Jumlah: jumlah})
+	}
+
+	pemusikService := service.NewPemusikService(pemusikRepo, &rosterEventRepo{event: event}, nil, nil, nil)
+	return pemusikService, pemusikRepo, event.ID
+}
+
+func (r *rosterPemusikRepo) available(t *testing.T, eventID uuid.UUID, musician rosterMusician, ketersediaan string, dates ...time.Time) {
+	alatMusik, err := json.Marshal(musician.alatMusik)
+	require.NoError(t, err)
+	for _, date := range dates {
+		r.ketersediaan = append(r.ketersediaan, entity.KetersediaanPemusik{
+			ID:             uuid.New(),
+			PersonID:       musician.id,
+			Person:         entity.Person{ID: musician.id, Nama: musician.nama},
+			Ketersediaan:   ketersediaan,
+			AlatMusik:      string(alatMusik),
+			EventId:        eventID,
+			OccurrenceDate: date,
+		})
+	}
+}
+
+// rosterPlaces counts each musician's places and lists who plays on each date
+func rosterPlaces(jadwal []dto.JadwalPemusikResponse) (map[uuid.UUID]int, map[string][]uuid.UUID) {
+	counts := make(map[uuid.UUID]int)
+	byDate := make(map[string][]uuid.UUID)
+	for _, place := range jadwal {
+		counts[place.PersonID]++
+		byDate[place.OccurrenceDate] = append(byDate[place.OccurrenceDate], place.PersonID)
+	}
+	return counts, byDate
+}
+
+func TestPemusikRoster_FairSpread(t *testing.T) {
+	rangeReq := &dto.PemusikRangeRequest{StartDate: "2025-03-02", EndDate: "2025-04-06"}
+
+	t.Run("Places are spread evenly across available musicians", func(t *testing.T) {
+		pemusikService, repo, eventID := newRosterService(t, map[string]int{"gitar": 1})
+		musicians := []rosterMusician{
+			newRosterMusician("Andi", "gitar"),
+			newRosterMusician("Budi", "gitar"),
+			newRosterMusician("Citra", "gitar"),
+		}
+		for _, musician := range musicians {
+			repo.available(t, eventID, musician, entity.KetersediaanTersedia, rosterSundays...)
+		}
+
+		response, err := pemusikService.GenerateJadwal(eventID, rangeReq)
+		require.NoError(t, err)
+		assert.Empty(t, response.Unfilled)
+
+		counts, byDate := rosterPlaces(response.Jadwal)
+		require.Len(t, byDate, len(rosterSundays))
+		for _, musician := range musicians {
+			assert.Equal(t, 2, counts[musician.id], "%s should play twice in six weeks", musician.nama)
+		}
+		// Nobody plays two Sundays in a row while someone else is free
+		for i := 1; i < len(rosterSundays); i++ {
+			previous := byDate[rosterSundays[i-1].Format("2006-01-02")]
+			current := byDate[rosterSundays[i].Format("2006-01-02")]
+			assert.NotEqual(t, previous, current, "same musician on %s and the week before", rosterSundays[i].Format("2006-01-02"))
+		}
+	})
+
+	t.Run("Places at other events that week count", func(t *testing.T) {
+		pemusikService, repo, eventID := newRosterService(t, map[string]int{"gitar": 1})
+		andi := newRosterMusician("Andi", "gitar")
+		budi := newRosterMusician("Budi", "gitar")
+		repo.available(t, eventID, andi, entity.KetersediaanTersedia, rosterSundays[0])
+		repo.available(t, eventID, budi, entity.KetersediaanTersedia, rosterSundays[0])
+
+		// Andi already plays the Wednesday prayer meeting of that week
+		repo.jadwal = append(repo.jadwal, entity.JadwalPemusik{
+			ID:             uuid.New(),
+			PersonID:       andi.id,
+			EventId:        uuid.New(),
+			OccurrenceDate: time.Date(2025, 2, 26, 0, 0, 0, 0, time.UTC),
+			AlatMusik:      "gitar",
+			Status:         entity.JadwalPemusikStatusPublished,
+		})
+
+		response, err := pemusikService.GenerateJadwal(eventID, &dto.PemusikRangeRequest{StartDate: "2025-03-02", EndDate: "2025-03-02"})
+		require.NoError(t, err)
+		require.Len(t, response.Jadwal, 1)
+		assert.Equal(t, budi.id, response.Jadwal[0].PersonID)
+	})
+
+	t.Run("Musicians who said maybe are used last", func(t *testing.T) {
+		pemusikService, repo, eventID := newRosterService(t, map[string]int{"gitar": 1})
+		andi := newRosterMusician("Andi", "gitar")
+		budi := newRosterMusician("Budi", "gitar")
+		repo.available(t, eventID, andi, entity.KetersediaanTersedia, rosterSundays...)
+		repo.available(t, eventID, budi, entity.KetersediaanMungkin, rosterSundays...)
+
+		response, err := pemusikService.GenerateJadwal(eventID, rangeReq)
+		require.NoError(t, err)
+
+		counts, _ := rosterPlaces(response.Jadwal)
+		assert.Equal(t, len(rosterSundays), counts[andi.id])
+		assert.Zero(t, counts[budi.id])
+	})
+
+	t.Run("Scarce instruments are filled first", func(t *testing.T) {
+		pemusikService, repo, eventID := newRosterService(t, map[string]int{"gitar": 1, "keyboard": 1})
+		// Andi sorts first by name and plays both; Budi only plays guitar
+		andi := newRosterMusician("Andi", "gitar", "keyboard")
+		budi := newRosterMusician("Budi", "gitar")
+		repo.available(t, eventID, andi, entity.KetersediaanTersedia, rosterSundays[0])
+		repo.available(t, eventID, budi, entity.KetersediaanTersedia, rosterSundays[0])
+
+		response, err := pemusikService.GenerateJadwal(eventID, &dto.PemusikRangeRequest{StartDate: "2025-03-02", EndDate: "2025-03-02"})
+		require.NoError(t, err)
+		assert.Empty(t, response.Unfilled)
+
+		alatMusik := make(map[uuid.UUID]string)
+		for _, place := range response.Jadwal {
+			alatMusik[place.PersonID] = place.AlatMusik
+		}
+		assert.Equal(t, "keyboard", alatMusik[andi.id])
+		assert.Equal(t, "gitar", alatMusik[budi.id])
+	})
+
+	t.Run("Places set by hand are kept and count toward the load", func(t *testing.T) {
+		pemusikService, repo, eventID := newRosterService(t, map[string]int{"gitar": 1})
+		andi := newRosterMusician("Andi", "gitar")
+		budi := newRosterMusician("Budi", "gitar")
+		repo.available(t, eventID, andi, entity.KetersediaanTersedia, rosterSundays[:2]...)
+		repo.available(t, eventID, budi, entity.KetersediaanTersedia, rosterSundays[:2]...)
+		repo.jadwal = append(repo.jadwal, entity.JadwalPemusik{
+			ID:             uuid.New(),
+			PersonID:       budi.id,
+			EventId:        eventID,
+			OccurrenceDate: rosterSundays[0],
+			AlatMusik:      "gitar",
+			Status:         entity.JadwalPemusikStatusDraft,
+			IsManual:       true,
+		})
+
+		response, err := pemusikService.GenerateJadwal(eventID, &dto.PemusikRangeRequest{StartDate: "2025-03-02", EndDate: "2025-03-09"})
+		require.NoError(t, err)
+
+		_, byDate := rosterPlaces(response.Jadwal)
+		assert.Equal(t, []uuid.UUID{budi.id}, byDate["2025-03-02"])
+		assert.Equal(t, []uuid.UUID{andi.id}, byDate["2025-03-09"])
+	})
+}
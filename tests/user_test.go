@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/zemetia/en-indo-be/constants"
 	"github.com/zemetia/en-indo-be/controller"
 	"github.com/zemetia/en-indo-be/entity"
 	"github.com/zemetia/en-indo-be/repository"
@@ -21,11 +22,13 @@ func SetUpRoutes() *gin.Engine {
 
 func SetupControllerUser() controller.UserController {
 	var (
-		db             = SetUpDatabaseConnection()
-		userRepo       = repository.NewUserRepository(db)
-		jwtService     = service.NewJWTService()
-		userService    = service.NewUserService(userRepo, jwtService)
-		userController = controller.NewUserController(userService)
+		db              = SetUpDatabaseConnection()
+		userRepo        = repository.NewUserRepository(db)
+		personRepo      = repository.NewPersonRepository(db)
+		documentService = service.NewDocumentService(constants.BASE_URL, constants.UPLOAD_PATH)
+		jwtService      = service.NewJWTService()
+		userService     = service.NewUserService(userRepo, personRepo, documentService, jwtService)
+		userController  = controller.NewUserController(userService)
 	)
 
 	return userController
@@ -35,11 +38,9 @@ func InsertTestUser() ([]entity.User, error) {
 	db := SetUpDatabaseConnection()
 	users := []entity.User{
 		{
-			Name:  "admin",
 			Email: "admin1234@gmail.com",
 		},
 		{
-			Name:  "user",
 			Email: "user1234@gmail.com",
 		},
 	}
@@ -56,7 +57,7 @@ func InsertTestUser() ([]entity.User, error) {
 func Test_GetAllUser_OK(t *testing.T) {
 	r := SetUpRoutes()
 	userController := SetupControllerUser()
-	r.GET("/api/user", userController.GetAllUser)
+	r.GET("/api/user", userController.GetAll)
 
 	expectedUsers, err := InsertTestUser()
 	if err != nil {
@@ -85,7 +86,7 @@ func Test_GetAllUser_OK(t *testing.T) {
 	for _, expectedUser := range expectedUsers {
 		found := false
 		for _, actualUser := range actualUsers {
-			if expectedUser.Name == actualUser.Name && expectedUser.Email == actualUser.Email {
+			if expectedUser.Email == actualUser.Email {
 				found = true
 				break
 			}
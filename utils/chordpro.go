@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ChordProSong is a ChordPro (https://www.chordpro.org) chart split into its metadata directives and
+// the chart body that is left
+type ChordProSong struct {
+	Title  string
+	Artist string
+	Key    string
+	Year   int
+	Body   string
+}
+
+var (
+	chordProDirective = regexp.MustCompile(`^\{\s*([A-Za-z_-]+)\s*(?::\s*(.*?))?\s*\}$`)
+	chordProChord     = regexp.MustCompile(`\[([^\]]*)\]`)
+	chordPattern      = regexp.MustCompile(`^([A-G])([#b]?)([^/]*)(?:/([A-G])([#b]?))?$`)
+)
+
+// Pitch classes of the note names, C = 0
+var notePitch = map[string]int{"C": 0, "D": 2, "E": 4, "F": 5, "G": 7, "A": 9, "B": 11}
+
+var (
+	sharpNotes = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+	flatNotes  = []string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+)
+
+// Key names as they are written, with the keys spelled with flats
+var (
+	majorKeys = map[string]bool{
+		"C": false, "G": false, "D": false, "A": false, "E": false, "B": false, "F#": false, "C#": false,
+		"F": true, "Bb": true, "Eb": true, "Ab": true, "Db": true, "Gb": true, "Cb": true,
+	}
+	minorKeys = map[string]bool{
+		"Am": false, "Em": false, "Bm": false, "F#m": false, "C#m": false, "G#m": false, "D#m": false, "A#m": false,
+		"Dm": true, "Gm": true, "Cm": true, "Fm": true, "Bbm": true, "Ebm": true, "Abm": true,
+	}
+	// The spelling used for a key given in a spelling no key signature has, e.g. A# becomes Bb
+	majorKeyNames = []string{"C", "Db", "D", "Eb", "E", "F", "F#", "G", "Ab", "A", "Bb", "B"}
+	minorKeyNames = []string{"Cm", "C#m", "Dm", "Ebm", "Em", "Fm", "F#m", "Gm", "G#m", "Am", "Bbm", "Bm"}
+)
+
+// ParseChordPro splits the title, artist, key and year directives off a ChordPro chart
+func ParseChordPro(text string) (*ChordProSong, error) {
+	song := &ChordProSong{}
+	var body []string
+
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	for _, line := range strings.Split(text, "\n") {
+		match := chordProDirective.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			body = append(body, line)
+			continue
+		}
+
+		value := match[2]
+		switch strings.ToLower(match[1]) {
+		case "title", "t":
+			song.Title = value
+		case "artist":
+			song.Artist = value
+		case "key":
+			song.Key = value
+		case "year":
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid year %q", value)
+			}
+			song.Year = year
+		default:
+			body = append(body, line)
+		}
+	}
+
+	song.Body = strings.Trim(strings.Join(body, "\n"), "\n")
+	if strings.TrimSpace(song.Body) == "" {
+		return nil, fmt.Errorf("chart has no lyrics")
+	}
+	return song, nil
+}
+
+// FormatChordPro writes the metadata back as directives in front of the body
+func FormatChordPro(song *ChordProSong) string {
+	var builder strings.Builder
+	if song.Title != "" {
+		fmt.Fprintf(&builder, "{title: %s}\n", song.Title)
+	}
+	if song.Artist != "" {
+		fmt.Fprintf(&builder, "{artist: %s}\n", song.Artist)
+	}
+	if song.Key != "" {
+		fmt.Fprintf(&builder, "{key: %s}\n", song.Key)
+	}
+	if song.Year != 0 {
+		fmt.Fprintf(&builder, "{year: %d}\n", song.Year)
+	}
+	if builder.Len() > 0 {
+		builder.WriteString("\n")
+	}
+	builder.WriteString(song.Body)
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// ChordProLyrics renders a chart as lyrics only, for projection: chords, directives and comments are
+// dropped, as are lines that only held chords, and sections stay separated by one blank line
+func ChordProLyrics(chart string) string {
+	var lines []string
+	blank := false
+
+	chart = strings.ReplaceAll(strings.ReplaceAll(chart, "\r\n", "\n"), "\r", "\n")
+	for _, line := range strings.Split(chart, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || chordProDirective.MatchString(trimmed) {
+			continue
+		}
+
+		if trimmed == "" {
+			blank = len(lines) > 0
+			continue
+		}
+
+		lyrics := strings.Join(strings.Fields(chordProChord.ReplaceAllString(line, "")), " ")
+		if lyrics == "" {
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, lyrics)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// NormalizeKey checks a key such as "G", "Bb" or "F#m" and returns it as a key signature is named,
+// e.g. "A#" becomes "Bb"
+func NormalizeKey(key string) (string, error) {
+	pitch, minor, err := parseKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if minor {
+		if _, ok := minorKeys[key]; ok {
+			return key, nil
+		}
+		return minorKeyNames[pitch], nil
+	}
+	if _, ok := majorKeys[key]; ok {
+		return key, nil
+	}
+	return majorKeyNames[pitch], nil
+}
+
+// KeyUsesFlats reports whether chords in a normalized key are spelled with flats
+func KeyUsesFlats(key string) bool {
+	if strings.HasSuffix(key, "m") {
+		return minorKeys[key]
+	}
+	return majorKeys[key]
+}
+
+// KeyInterval is the number of semitones up from one key to another, 0 to 11
+func KeyInterval(from, to string) (int, error) {
+	fromPitch, _, err := parseKey(from)
+	if err != nil {
+		return 0, err
+	}
+	toPitch, _, err := parseKey(to)
+	if err != nil {
+		return 0, err
+	}
+	return ((toPitch-fromPitch)%12 + 12) % 12, nil
+}
+
+// TransposeChord moves a chord such as "F#m7/C#" up by semitones. Text that is not a chord,
+// like "N.C.", is returned unchanged.
+func TransposeChord(chord string, semitones int, flats bool) string {
+	match := chordPattern.FindStringSubmatch(chord)
+	if match == nil {
+		return chord
+	}
+
+	transposed := transposeNote(match[1]+match[2], semitones, flats) + match[3]
+	if match[4] != "" {
+		transposed += "/" + transposeNote(match[4]+match[5], semitones, flats)
+	}
+	return transposed
+}
+
+// TransposeChordPro moves every chord of a chart, and its key directive, up by semitones
+func TransposeChordPro(chart string, semitones int, flats bool) string {
+	lines := strings.Split(chart, "\n")
+	for i, line := range lines {
+		if match := chordProDirective.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			if strings.ToLower(match[1]) == "key" {
+				lines[i] = "{key: " + TransposeChord(match[2], semitones, flats) + "}"
+			}
+			continue
+		}
+
+		lines[i] = chordProChord.ReplaceAllStringFunc(line, func(chord string) string {
+			return "[" + TransposeChord(chord[1:len(chord)-1], semitones, flats) + "]"
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+func transposeNote(note string, semitones int, flats bool) string {
+	pitch := ((notePitch[note[:1]]+accidental(note[1:])+semitones)%12 + 12) % 12
+	if flats {
+		return flatNotes[pitch]
+	}
+	return sharpNotes[pitch]
+}
+
+func accidental(value string) int {
+	switch value {
+	case "#":
+		return 1
+	case "b":
+		return -1
+	default:
+		return 0
+	}
+}
+
+// parseKey reads a key as a root note, optionally sharp or flat, and an optional "m" for minor
+func parseKey(key string) (int, bool, error) {
+	minor := strings.HasSuffix(key, "m")
+	root := strings.TrimSuffix(key, "m")
+	if len(root) == 0 || len(root) > 2 {
+		return 0, false, fmt.Errorf("invalid key %q", key)
+	}
+
+	pitch, ok := notePitch[root[:1]]
+	if !ok || (len(root) == 2 && root[1] != '#' && root[1] != 'b') {
+		return 0, false, fmt.Errorf("invalid key %q", key)
+	}
+	return ((pitch+accidental(root[1:]))%12 + 12) % 12, minor, nil
+}
@@ -0,0 +1,437 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ICalDateFormat      = "20060102"
+	ICalLocalTimeFormat = "20060102T150405"
+	ICalUTCTimeFormat   = "20060102T150405Z"
+
+	// RFC 5545 section 3.1: lines should not be longer than 75 octets
+	icalMaxLineOctets = 75
+)
+
+// ICalWriter builds an iCalendar (RFC 5545) document with CRLF line endings and line folding
+type ICalWriter struct {
+	builder strings.Builder
+}
+
+func NewICalWriter() *ICalWriter {
+	return &ICalWriter{}
+}
+
+// Line writes a content line, name may include parameters (e.g. "DTSTART;TZID=Asia/Jakarta")
+func (w *ICalWriter) Line(name string, value string) {
+	w.builder.WriteString(ICalFoldLine(name + ":" + value))
+	w.builder.WriteString("\r\n")
+}
+
+func (w *ICalWriter) Bytes() []byte {
+	return []byte(w.builder.String())
+}
+
+// ICalEscapeText escapes a TEXT value (backslash, semicolon, comma and newlines)
+func ICalEscapeText(value string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+		"\r", "\\n",
+	)
+	return replacer.Replace(value)
+}
+
+// ICalFoldLine splits a content line into 75-octet chunks without breaking UTF-8 sequences
+func ICalFoldLine(line string) string {
+	if len(line) <= icalMaxLineOctets {
+		return line
+	}
+
+	var folded strings.Builder
+	limit := icalMaxLineOctets
+	current := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if current+size > limit {
+			folded.WriteString("\r\n ")
+			// Continuation lines start with a space which counts towards the limit
+			limit = icalMaxLineOctets - 1
+			current = 0
+		}
+		folded.WriteRune(r)
+		current += size
+	}
+	return folded.String()
+}
+
+// ICalFormatOffset formats a UTC offset in seconds as +HHMM / -HHMM
+func ICalFormatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, (offset%3600)/60)
+}
+
+// ICalWriteTimezone writes a VTIMEZONE component for loc that covers every transition between from and to
+func ICalWriteTimezone(w *ICalWriter, loc *time.Location, from, to time.Time) {
+	from = from.In(loc)
+	to = to.In(loc)
+
+	w.Line("BEGIN", "VTIMEZONE")
+	w.Line("TZID", loc.String())
+
+	// Initial observance so that every DTSTART in range is covered
+	name, offset := from.Zone()
+	writeTimezoneObservance(w, from.IsDST(), from, offset, offset, name)
+
+	// Walk the range day by day and narrow down every offset change to the minute
+	previous := from
+	for day := from.AddDate(0, 0, 1); !day.After(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		_, prevOffset := previous.Zone()
+		_, dayOffset := day.Zone()
+		if prevOffset != dayOffset {
+			transition := findOffsetTransition(previous, day)
+			toName, toOffset := transition.Zone()
+			// DTSTART of an observance is expressed in the offset in effect before the transition
+			localStart := transition.UTC().Add(time.Duration(prevOffset) * time.Second)
+			writeTimezoneObservance(w, transition.IsDST(), localStart, prevOffset, toOffset, toName)
+		}
+		previous = day
+	}
+
+	w.Line("END", "VTIMEZONE")
+}
+
+func writeTimezoneObservance(w *ICalWriter, isDST bool, start time.Time, offsetFrom, offsetTo int, name string) {
+	component := "STANDARD"
+	if isDST {
+		component = "DAYLIGHT"
+	}
+
+	w.Line("BEGIN", component)
+	w.Line("DTSTART", start.Format(ICalLocalTimeFormat))
+	w.Line("TZOFFSETFROM", ICalFormatOffset(offsetFrom))
+	w.Line("TZOFFSETTO", ICalFormatOffset(offsetTo))
+	if name != "" {
+		w.Line("TZNAME", ICalEscapeText(name))
+	}
+	w.Line("END", component)
+}
+
+// findOffsetTransition binary searches the first instant in (before, after] that has after's offset
+func findOffsetTransition(before, after time.Time) time.Time {
+	_, targetOffset := after.Zone()
+	for after.Sub(before) > time.Minute {
+		mid := before.Add(after.Sub(before) / 2)
+		if _, offset := mid.Zone(); offset == targetOffset {
+			after = mid
+		} else {
+			before = mid
+		}
+	}
+	return after.Truncate(time.Minute)
+}
+
+// ICalProperty is a parsed content line, parameter names are upper-cased
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ICalComponent is a BEGIN/END block with its properties and nested components
+type ICalComponent struct {
+	Name       string
+	Properties []ICalProperty
+	Components []*ICalComponent
+}
+
+// Property returns the first property with the given name or nil
+func (c *ICalComponent) Property(name string) *ICalProperty {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// PropertyValue returns the value of the first property with the given name or an empty string
+func (c *ICalComponent) PropertyValue(name string) string {
+	if prop := c.Property(name); prop != nil {
+		return prop.Value
+	}
+	return ""
+}
+
+// PropertiesNamed returns every property with the given name, e.g. repeated EXDATE lines
+func (c *ICalComponent) PropertiesNamed(name string) []ICalProperty {
+	var props []ICalProperty
+	for _, prop := range c.Properties {
+		if prop.Name == name {
+			props = append(props, prop)
+		}
+	}
+	return props
+}
+
+// ComponentsNamed returns the direct child components with the given name
+func (c *ICalComponent) ComponentsNamed(name string) []*ICalComponent {
+	var components []*ICalComponent
+	for _, component := range c.Components {
+		if component.Name == name {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// ParseICal parses an iCalendar document and returns its top-level VCALENDAR component
+func ParseICal(data []byte) (*ICalComponent, error) {
+	lines := unfoldICalLines(string(data))
+
+	var stack []*ICalComponent
+	var root *ICalComponent
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		prop, err := parseICalLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			component := &ICalComponent{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, component)
+			} else if root == nil {
+				root = component
+			} else {
+				return nil, fmt.Errorf("line %d: multiple top-level components", i+1)
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s outside of a component", i+1, prop.Name)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, prop)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	if root == nil || root.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("document does not contain a VCALENDAR")
+	}
+
+	return root, nil
+}
+
+// ICalUnescapeText reverses ICalEscapeText
+func ICalUnescapeText(value string) string {
+	var result strings.Builder
+	escaped := false
+	for _, r := range value {
+		if escaped {
+			switch r {
+			case 'n', 'N':
+				result.WriteRune('\n')
+			default:
+				result.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// ICalParseDuration parses a DURATION value such as PT1H30M, P1D or -P1W
+func ICalParseDuration(value string) (time.Duration, error) {
+	original := value
+	sign := time.Duration(1)
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+		value = value[1:]
+	} else {
+		value = strings.TrimPrefix(value, "+")
+	}
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", original)
+	}
+	value = value[1:]
+
+	var total time.Duration
+	inTime := false
+	number := 0
+	hasNumber := false
+	// At least one component is required, and one after T if T is given
+	components, timeComponents := 0, 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+			hasNumber = true
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		if !hasNumber {
+			return 0, fmt.Errorf("invalid duration %q", original)
+		}
+		n := time.Duration(number)
+		switch {
+		case r == 'W' && !inTime:
+			total += n * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += n * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += n * time.Hour
+		case r == 'M' && inTime:
+			total += n * time.Minute
+		case r == 'S' && inTime:
+			total += n * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", original)
+		}
+		components++
+		if inTime {
+			timeComponents++
+		}
+		number = 0
+		hasNumber = false
+	}
+	if hasNumber || components == 0 || (inTime && timeComponents == 0) {
+		return 0, fmt.Errorf("invalid duration %q", original)
+	}
+
+	return sign * total, nil
+}
+
+// unfoldICalLines joins continuation lines (starting with space or tab) to the previous line
+func unfoldICalLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	var lines []string
+	for _, raw := range strings.Split(data, "\n") {
+		if len(raw) > 0 && (raw[0] == ' ' || raw[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += raw[1:]
+			continue
+		}
+		lines = append(lines, raw)
+	}
+	return lines
+}
+
+// parseICalLine splits NAME;PARAM=VALUE;PARAM="QUOTED":VALUE into its parts
+func parseICalLine(line string) (ICalProperty, error) {
+	prop := ICalProperty{Params: make(map[string]string)}
+
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	head := line[:colon]
+	prop.Value = line[colon+1:]
+
+	var segments []string
+	inQuotes = false
+	start := 0
+	for i, r := range head {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ';' && !inQuotes {
+			segments = append(segments, head[start:i])
+			start = i + 1
+		}
+	}
+	segments = append(segments, head[start:])
+
+	prop.Name = strings.ToUpper(strings.TrimSpace(segments[0]))
+	if prop.Name == "" {
+		return prop, fmt.Errorf("missing property name in %q", line)
+	}
+	for _, segment := range segments[1:] {
+		key, value, found := strings.Cut(segment, "=")
+		if !found {
+			return prop, fmt.Errorf("invalid parameter %q", segment)
+		}
+		prop.Params[strings.ToUpper(key)] = strings.Trim(value, "\"")
+	}
+
+	return prop, nil
+}
+
+// ICalParseDateTime parses a DATE or DATE-TIME value. UTC values ("Z" suffix) are converted to defaultLoc,
+// values with a TZID parameter are read in that zone and floating values are read in defaultLoc.
+func ICalParseDateTime(value string, params map[string]string, defaultLoc *time.Location) (time.Time, bool, error) {
+	loc := defaultLoc
+	if tzid, ok := params["TZID"]; ok && tzid != "" {
+		tzLoc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unsupported TZID %q", tzid)
+		}
+		loc = tzLoc
+	}
+
+	if params["VALUE"] == "DATE" || len(value) == len(ICalDateFormat) {
+		parsed, err := time.ParseInLocation(ICalDateFormat, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return parsed, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse(ICalUTCTimeFormat, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return parsed.In(defaultLoc), false, nil
+	}
+
+	parsed, err := time.ParseInLocation(ICalLocalTimeFormat, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return parsed, false, nil
+}
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slide line limits for projected lyrics
+const (
+	DefaultSlideLines = 4
+	MaxSlideLines     = 12
+)
+
+// LyricSection is one stanza of a song, split into slides for projection
+type LyricSection struct {
+	Name   string     // OpenLyrics verse name, e.g. "v1", "c1" or "b1"
+	Label  string     // how the stanza is introduced, e.g. "Reff" or "Verse 2"
+	Slides [][]string // the lines of every slide
+}
+
+// LyricSheet is the stanzas of a song, each once, and the order they are sung in. A chorus that is
+// repeated word for word, or only named again on a line of its own, is kept once and named twice in Order.
+type LyricSheet struct {
+	Sections []LyricSection
+	Order    []string
+}
+
+// A line on its own naming the stanza that follows, e.g. "Verse 1", "[Reff]" or "Bait 2:"
+var lyricLabel = regexp.MustCompile(`(?i)^[\[(]?\s*(verse|bait|ayat|chorus|reff?|refrain|pre[- ]?chorus|pre[- ]?reff?|bridge|jembatan|intro|interlude|outro|ending|coda|tag)\s*(\d*)\s*[\])]?\s*[:.]?$`)
+
+// OpenLyrics verse name letters of the labels
+var lyricLabelTypes = map[string]string{
+	"verse": "v", "bait": "v", "ayat": "v",
+	"chorus": "c", "ref": "c", "reff": "c", "refrain": "c",
+	"prechorus": "p", "pre-chorus": "p", "pre chorus": "p", "preref": "p", "pre-ref": "p", "pre ref": "p",
+	"prereff": "p", "pre-reff": "p", "pre reff": "p",
+	"bridge": "b", "jembatan": "b",
+	"intro":     "i",
+	"interlude": "o",
+	"outro":     "e", "ending": "e", "coda": "e", "tag": "e",
+}
+
+// Labels of stanzas that were not named in the lyrics
+var lyricTypeLabels = map[string]string{
+	"v": "Verse", "c": "Chorus", "p": "Pre-Chorus", "b": "Bridge", "i": "Intro", "o": "Interlude", "e": "Ending",
+}
+
+// SplitLyricSheet splits plain lyrics into stanzas at blank lines and every stanza into slides of at
+// most maxLines lines, as even in length as possible. Stanzas without a label are verses.
+func SplitLyricSheet(lyrics string, maxLines int) *LyricSheet {
+	if maxLines <= 0 {
+		maxLines = DefaultSlideLines
+	}
+
+	sheet := &LyricSheet{}
+	used := make(map[string]bool)
+	counts := make(map[string]int)
+	byName := make(map[string]int)
+
+	lyrics = strings.ReplaceAll(strings.ReplaceAll(lyrics, "\r\n", "\n"), "\r", "\n")
+	for _, stanza := range splitStanzas(lyrics) {
+		kind, number, label := "v", 0, ""
+		if match := lyricLabel.FindStringSubmatch(stanza[0]); match != nil {
+			kind = lyricLabelTypes[strings.ToLower(match[1])]
+			number, _ = strconv.Atoi(match[2])
+			label = strings.Trim(strings.TrimSpace(stanza[0]), "[]():.")
+			stanza = stanza[1:]
+		}
+
+		if len(stanza) == 0 {
+			// A label on its own repeats the stanza it names, the last one of its kind by default
+			name := fmt.Sprintf("%s%d", kind, number)
+			if number == 0 {
+				name = fmt.Sprintf("%s%d", kind, counts[kind])
+			}
+			if _, ok := byName[name]; ok {
+				sheet.Order = append(sheet.Order, name)
+			}
+			continue
+		}
+
+		if repeat := repeatedSection(sheet, kind, number, stanza); repeat != "" {
+			sheet.Order = append(sheet.Order, repeat)
+			continue
+		}
+
+		if number == 0 || used[fmt.Sprintf("%s%d", kind, number)] {
+			number = counts[kind] + 1
+			for used[fmt.Sprintf("%s%d", kind, number)] {
+				number++
+			}
+		}
+		name := fmt.Sprintf("%s%d", kind, number)
+		used[name] = true
+		if number > counts[kind] {
+			counts[kind] = number
+		}
+		if label == "" {
+			label = fmt.Sprintf("%s %d", lyricTypeLabels[kind], number)
+		}
+
+		byName[name] = len(sheet.Sections)
+		sheet.Sections = append(sheet.Sections, LyricSection{Name: name, Label: label, Slides: splitSlides(stanza, maxLines)})
+		sheet.Order = append(sheet.Order, name)
+	}
+	return sheet
+}
+
+// Section returns the stanza with the OpenLyrics name, or nil
+func (s *LyricSheet) Section(name string) *LyricSection {
+	for i := range s.Sections {
+		if s.Sections[i].Name == name {
+			return &s.Sections[i]
+		}
+	}
+	return nil
+}
+
+func splitStanzas(lyrics string) [][]string {
+	var stanzas [][]string
+	var current []string
+	for _, line := range strings.Split(lyrics, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(current) > 0 {
+				stanzas = append(stanzas, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		stanzas = append(stanzas, current)
+	}
+	return stanzas
+}
+
+// repeatedSection returns the name of an earlier stanza of the same kind with the same lines, if any
+func repeatedSection(sheet *LyricSheet, kind string, number int, lines []string) string {
+	for _, section := range sheet.Sections {
+		if !strings.HasPrefix(section.Name, kind) || (number != 0 && section.Name != fmt.Sprintf("%s%d", kind, number)) {
+			continue
+		}
+		var sectionLines []string
+		for _, slide := range section.Slides {
+			sectionLines = append(sectionLines, slide...)
+		}
+		if strings.Join(sectionLines, "\n") == strings.Join(lines, "\n") {
+			return section.Name
+		}
+	}
+	return ""
+}
+
+func splitSlides(lines []string, maxLines int) [][]string {
+	count := (len(lines) + maxLines - 1) / maxLines
+	slides := make([][]string, 0, count)
+	for i := 0; i < count; i++ {
+		start := i * len(lines) / count
+		end := (i + 1) * len(lines) / count
+		slides = append(slides, lines[start:end])
+	}
+	return slides
+}
+
+// OpenLyricsSong is the song data written to an OpenLyrics (https://openlyrics.org) document
+type OpenLyricsSong struct {
+	Title    string
+	Artist   string
+	Key      string
+	Released int
+	Sheet    *LyricSheet
+	Modified time.Time
+}
+
+type openLyricsDocument struct {
+	XMLName      xml.Name             `xml:"song"`
+	Xmlns        string               `xml:"xmlns,attr"`
+	Version      string               `xml:"version,attr"`
+	CreatedIn    string               `xml:"createdIn,attr"`
+	ModifiedIn   string               `xml:"modifiedIn,attr"`
+	ModifiedDate string               `xml:"modifiedDate,attr"`
+	Properties   openLyricsProperties `xml:"properties"`
+	Verses       []openLyricsVerse    `xml:"lyrics>verse"`
+}
+
+type openLyricsProperties struct {
+	Titles     []string `xml:"titles>title"`
+	Authors    []string `xml:"authors>author,omitempty"`
+	Key        string   `xml:"key,omitempty"`
+	Released   string   `xml:"released,omitempty"`
+	VerseOrder string   `xml:"verseOrder,omitempty"`
+}
+
+type openLyricsVerse struct {
+	Name  string            `xml:"name,attr"`
+	Lines []openLyricsLines `xml:"lines"`
+}
+
+// openLyricsLines is one slide; its lines are separated by <br/>
+type openLyricsLines struct {
+	Content string `xml:",innerxml"`
+}
+
+// FormatOpenLyrics writes a song as an OpenLyrics 0.9 document, one <lines> element per slide
+func FormatOpenLyrics(song *OpenLyricsSong) ([]byte, error) {
+	document := openLyricsDocument{
+		Xmlns:        "http://openlyrics.info/namespace/2009/song",
+		Version:      "0.9",
+		CreatedIn:    "en-indo-be",
+		ModifiedIn:   "en-indo-be",
+		ModifiedDate: song.Modified.UTC().Format("2006-01-02T15:04:05"),
+		Properties: openLyricsProperties{
+			Titles:     []string{song.Title},
+			Key:        song.Key,
+			VerseOrder: strings.Join(song.Sheet.Order, " "),
+		},
+	}
+	if song.Artist != "" {
+		document.Properties.Authors = []string{song.Artist}
+	}
+	if song.Released != 0 {
+		document.Properties.Released = strconv.Itoa(song.Released)
+	}
+
+	for _, section := range song.Sheet.Sections {
+		verse := openLyricsVerse{Name: section.Name}
+		for _, slide := range section.Slides {
+			escaped := make([]string, len(slide))
+			for i, line := range slide {
+				var buf bytes.Buffer
+				if err := xml.EscapeText(&buf, []byte(line)); err != nil {
+					return nil, err
+				}
+				escaped[i] = buf.String()
+			}
+			verse.Lines = append(verse.Lines, openLyricsLines{Content: strings.Join(escaped, "<br/>")})
+		}
+		document.Verses = append(document.Verses, verse)
+	}
+
+	output, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(output, '\n')...), nil
+}
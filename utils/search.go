@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldSearchText lowercases s, strips accents and collapses whitespace, so "Kudus Engkau, Tuhan"
+// and "kudús  engkau tuhan" fold to the same text. Stored search columns and search terms are both
+// folded before they are compared.
+func FoldSearchText(s string) string {
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, s)
+	if err != nil {
+		folded = s
+	}
+	return strings.Join(strings.Fields(strings.ToLower(folded)), " ")
+}